@@ -9,6 +9,7 @@ import (
 
 	ioutils "github.com/jfrog/gofrog/io"
 	"github.com/jfrog/jfrog-cli/utils/accesstoken"
+	"github.com/jfrog/jfrog-cli/utils/credentialhelper"
 
 	"github.com/jfrog/gofrog/version"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/transferinstall"
@@ -113,6 +114,7 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/yarnconfig"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/tracing"
 	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
@@ -120,6 +122,7 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/jszwec/csvutil"
 	"github.com/urfave/cli"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
@@ -1291,7 +1294,9 @@ func downloadCmd(c *cli.Context) error {
 		return nil
 	}
 	// This error is being checked later on because we need to generate summary report before return.
+	downloadSpan := tracing.StartSpan("Download", attribute.Int("jfrog.cli.threads", configuration.Threads))
 	err = progressbar.ExecWithProgress(downloadCommand)
+	downloadSpan.End()
 	result := downloadCommand.Result()
 	defer cliutils.CleanupResult(result, &err)
 	basicSummary, err := cliutils.CreateSummaryReportString(result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c), err)
@@ -1353,7 +1358,9 @@ func uploadCmd(c *cli.Context) (err error) {
 		return nil
 	}
 	// This error is being checked later on because we need to generate summary report before return.
+	uploadSpan := tracing.StartSpan("Upload", attribute.Int("jfrog.cli.threads", configuration.Threads))
 	err = progressbar.ExecWithProgress(uploadCmd)
+	uploadSpan.End()
 	result := uploadCmd.Result()
 	defer cliutils.CleanupResult(result, &err)
 	err = cliutils.PrintCommandSummary(uploadCmd.Result(), detailedSummary, printDeploymentView, cliutils.IsFailNoOp(c), err)
@@ -1562,7 +1569,7 @@ func searchCmd(c *cli.Context) (err error) {
 		return err
 	}
 	if !c.Bool("count") {
-		return utils.PrintSearchResults(reader)
+		return cliutils.PrintSearchResults(reader)
 	}
 	log.Output(length)
 	return nil
@@ -1669,7 +1676,9 @@ func buildPublishCmd(c *cli.Context) error {
 	}
 	buildPublishCmd := buildinfo.NewBuildPublishCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetConfig(buildInfoConfiguration).SetDetailedSummary(cliutils.GetDetailedSummary(c))
 
+	publishSpan := tracing.StartSpan("Publish build info")
 	err = commands.Exec(buildPublishCmd)
+	publishSpan.End()
 	if buildPublishCmd.IsDetailedSummary() {
 		if summary := buildPublishCmd.GetSummary(); summary != nil {
 			return cliutils.PrintBuildInfoSummaryReport(summary.IsSucceeded(), summary.GetSha256(), err)
@@ -1889,6 +1898,9 @@ func newRtCurlCommand(c *cli.Context) (*curl.RtCurlCommand, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = credentialhelper.Resolve(rtDetails); err != nil {
+		return nil, err
+	}
 	if rtDetails.ArtifactoryUrl == "" {
 		return nil, errorutils.CheckErrorf("No Artifactory servers configured. Use the 'jf c add' command to set the Artifactory server details.")
 	}
@@ -1917,6 +1929,9 @@ func pipDeprecatedInstallCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if err = credentialhelper.Resolve(rtDetails); err != nil {
+		return err
+	}
 
 	pythonCommand := python.NewPipCommand()
 	pythonCommand.SetServerDetails(rtDetails).SetRepo(pythonConfig.TargetRepo()).SetCommandName("install").SetArgs(cliutils.ExtractCommand(c))
@@ -2289,13 +2304,13 @@ func transferConfigCmd(c *cli.Context) error {
 	}
 
 	// Get source Artifactory server
-	sourceServerDetails, err := coreConfig.GetSpecificConfig(c.Args()[0], false, true)
+	sourceServerDetails, err := credentialhelper.GetSpecificConfig(c.Args()[0], false, true)
 	if err != nil {
 		return err
 	}
 
 	// Get target artifactory server
-	targetServerDetails, err := coreConfig.GetSpecificConfig(c.Args()[1], false, true)
+	targetServerDetails, err := credentialhelper.GetSpecificConfig(c.Args()[1], false, true)
 	if err != nil {
 		return err
 	}
@@ -2318,13 +2333,13 @@ func transferConfigMergeCmd(c *cli.Context) error {
 	}
 
 	// Get source Artifactory server
-	sourceServerDetails, err := coreConfig.GetSpecificConfig(c.Args()[0], false, true)
+	sourceServerDetails, err := credentialhelper.GetSpecificConfig(c.Args()[0], false, true)
 	if err != nil {
 		return err
 	}
 
 	// Get target artifactory server
-	targetServerDetails, err := coreConfig.GetSpecificConfig(c.Args()[1], false, true)
+	targetServerDetails, err := credentialhelper.GetSpecificConfig(c.Args()[1], false, true)
 	if err != nil {
 		return err
 	}
@@ -2347,7 +2362,7 @@ func dataTransferPluginInstallCmd(c *cli.Context) error {
 	} else if c.NArg() == 1 {
 		serverID = c.Args()[0]
 	}
-	serverDetails, err := coreConfig.GetSpecificConfig(serverID, true, true)
+	serverDetails, err := credentialhelper.GetSpecificConfig(serverID, true, true)
 	if err != nil {
 		return err
 	}
@@ -2388,13 +2403,13 @@ func transferFilesCmd(c *cli.Context) error {
 	}
 
 	// Get source Artifactory server
-	sourceServerDetails, err := coreConfig.GetSpecificConfig(c.Args()[0], false, true)
+	sourceServerDetails, err := credentialhelper.GetSpecificConfig(c.Args()[0], false, true)
 	if err != nil {
 		return err
 	}
 
 	// Get target artifactory server
-	targetServerDetails, err := coreConfig.GetSpecificConfig(c.Args()[1], false, true)
+	targetServerDetails, err := credentialhelper.GetSpecificConfig(c.Args()[1], false, true)
 	if err != nil {
 		return err
 	}
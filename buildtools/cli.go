@@ -57,6 +57,7 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/buildtools/yarnconfig"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/credentialhelper"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/urfave/cli"
@@ -615,6 +616,9 @@ func getNugetAndDotnetConfigFields(configFilePath string) (rtDetails *coreConfig
 	if err != nil {
 		return nil, "", false, err
 	}
+	if err = credentialhelper.Resolve(rtDetails); err != nil {
+		return nil, "", false, err
+	}
 	targetRepo = projectConfig.TargetRepo()
 	useNugetV2 = vConfig.GetBool(project.ProjectConfigResolverPrefix + "." + "nugetV2")
 	return
@@ -916,6 +920,9 @@ func pythonCmd(c *cli.Context, projectType project.ProjectType) error {
 	if err != nil {
 		return err
 	}
+	if err = credentialhelper.Resolve(rtDetails); err != nil {
+		return err
+	}
 
 	orgArgs := cliutils.ExtractCommand(c)
 	cmdName, filteredArgs := getCommandName(orgArgs)
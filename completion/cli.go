@@ -4,9 +4,14 @@ import (
 	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
 	"github.com/jfrog/jfrog-cli/completion/shells/bash"
 	"github.com/jfrog/jfrog-cli/completion/shells/fish"
+	"github.com/jfrog/jfrog-cli/completion/shells/nushell"
+	"github.com/jfrog/jfrog-cli/completion/shells/powershell"
 	"github.com/jfrog/jfrog-cli/completion/shells/zsh"
+	"github.com/jfrog/jfrog-cli/docs/common"
 	bash_docs "github.com/jfrog/jfrog-cli/docs/completion/bash"
 	fish_docs "github.com/jfrog/jfrog-cli/docs/completion/fish"
+	nushell_docs "github.com/jfrog/jfrog-cli/docs/completion/nushell"
+	powershell_docs "github.com/jfrog/jfrog-cli/docs/completion/powershell"
 	zsh_docs "github.com/jfrog/jfrog-cli/docs/completion/zsh"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	"github.com/urfave/cli"
@@ -19,6 +24,7 @@ func GetCommands() []cli.Command {
 			Flags:        cliutils.GetCommandFlags(cliutils.Completion),
 			Usage:        bash_docs.GetDescription(),
 			HelpName:     corecommon.CreateUsage("completion bash", bash_docs.GetDescription(), bash_docs.Usage),
+			ArgsUsage:    common.CreateEnvVars(common.JfrogCliCompletionOffline),
 			BashComplete: corecommon.CreateBashCompletionFunc(),
 			Action: func(c *cli.Context) {
 				bash.WriteBashCompletionScript(getInstallFlag(c))
@@ -29,6 +35,7 @@ func GetCommands() []cli.Command {
 			Flags:        cliutils.GetCommandFlags(cliutils.Completion),
 			Usage:        zsh_docs.GetDescription(),
 			HelpName:     corecommon.CreateUsage("completion zsh", zsh_docs.GetDescription(), zsh_docs.Usage),
+			ArgsUsage:    common.CreateEnvVars(common.JfrogCliCompletionOffline),
 			BashComplete: corecommon.CreateBashCompletionFunc(),
 			Action: func(c *cli.Context) {
 				zsh.WriteZshCompletionScript(getInstallFlag(c))
@@ -39,11 +46,34 @@ func GetCommands() []cli.Command {
 			Flags:        cliutils.GetCommandFlags(cliutils.Completion),
 			Usage:        fish_docs.GetDescription(),
 			HelpName:     corecommon.CreateUsage("completion fish", fish_docs.GetDescription(), fish_docs.Usage),
+			ArgsUsage:    common.CreateEnvVars(common.JfrogCliCompletionOffline),
 			BashComplete: corecommon.CreateBashCompletionFunc(),
 			Action: func(c *cli.Context) {
 				fish.WriteFishCompletionScript(c, getInstallFlag(c))
 			},
 		},
+		{
+			Name:         "powershell",
+			Flags:        cliutils.GetCommandFlags(cliutils.Completion),
+			Usage:        powershell_docs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("completion powershell", powershell_docs.GetDescription(), powershell_docs.Usage),
+			ArgsUsage:    common.CreateEnvVars(common.JfrogCliCompletionOffline),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) {
+				powershell.WritePowershellCompletionScript(c, getInstallFlag(c))
+			},
+		},
+		{
+			Name:         "nushell",
+			Flags:        cliutils.GetCommandFlags(cliutils.Completion),
+			Usage:        nushell_docs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("completion nushell", nushell_docs.GetDescription(), nushell_docs.Usage),
+			ArgsUsage:    common.CreateEnvVars(common.JfrogCliCompletionOffline),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) {
+				nushell.WriteNushellCompletionScript(c, getInstallFlag(c))
+			},
+		},
 	})
 }
 
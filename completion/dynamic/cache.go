@@ -0,0 +1,86 @@
+package dynamic
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	cacheFileName = "completion_cache.json"
+	// Repository paths change frequently, so they're fetched again after a short time.
+	cacheTtl = 2 * time.Minute
+	// Expired entries are kept for offline completion, until they're removed from the cache file.
+	cacheRetention = 24 * time.Hour
+)
+
+type cacheEntry struct {
+	Time   time.Time `json:"time"`
+	Values []string  `json:"values"`
+}
+
+// The values fetched from Artifactory, which are kept in a file, since each completion runs in a new process.
+type valuesCache struct {
+	path    string
+	entries map[string]cacheEntry
+}
+
+// Reads the cache file. A missing or corrupted cache file is treated as an empty cache.
+func loadCache(path string) *valuesCache {
+	cache := &valuesCache{path: path, entries: make(map[string]cacheEntry)}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Debug("Failed reading the completion cache:", err.Error())
+		}
+		return cache
+	}
+	if err = json.Unmarshal(content, &cache.entries); err != nil {
+		log.Debug("Failed parsing the completion cache:", err.Error())
+		cache.entries = make(map[string]cacheEntry)
+	}
+	return cache
+}
+
+// Returns the cached values, if they were fetched less than maxAge ago.
+func (cache *valuesCache) get(key string, maxAge time.Duration) ([]string, bool) {
+	entry, exists := cache.entries[key]
+	if !exists || time.Since(entry.Time) > maxAge {
+		return nil, false
+	}
+	return entry.Values, true
+}
+
+func (cache *valuesCache) set(key string, values []string) {
+	cache.entries[key] = cacheEntry{Time: time.Now(), Values: values}
+	for entryKey, entry := range cache.entries {
+		if time.Since(entry.Time) > cacheRetention {
+			delete(cache.entries, entryKey)
+		}
+	}
+	content, err := json.Marshal(cache.entries)
+	if err != nil {
+		log.Debug("Failed writing the completion cache:", err.Error())
+		return
+	}
+	// Write to a temporary file and rename it, so that concurrent completions never read a partially written cache.
+	tmpFile, err := os.CreateTemp(filepath.Dir(cache.path), filepath.Base(cache.path)+".*.tmp")
+	if err != nil {
+		log.Debug("Failed writing the completion cache:", err.Error())
+		return
+	}
+	_, err = tmpFile.Write(content)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpFile.Name(), cache.path)
+	}
+	if err != nil {
+		log.Debug("Failed writing the completion cache:", err.Error())
+		_ = os.Remove(tmpFile.Name())
+	}
+}
@@ -0,0 +1,179 @@
+package dynamic
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// Set by the completion scripts to the word which is completed, since it isn't passed to JFrog CLI as an argument.
+	WordEnv = "JFROG_CLI_COMPLETION_WORD"
+	// Set to true by the completion scripts of shells which complete the commands and flags by themselves, so only the dynamic values are printed.
+	DynamicOnlyEnv = "JFROG_CLI_COMPLETION_DYNAMIC_ONLY"
+	// Set to true to complete repository paths only from the cache, without sending requests to Artifactory.
+	OfflineEnv = "JFROG_CLI_COMPLETION_OFFLINE"
+
+	serverIdFlag  = "server-id"
+	buildNameFlag = "build-name"
+)
+
+// The positions of the repository path arguments, by the full command names.
+var repoPathArgs = map[string][]int{
+	"rt upload":   {1},
+	"rt download": {0},
+	"rt copy":     {0, 1},
+	"rt move":     {0, 1},
+	"rt delete":   {0},
+}
+
+// The upload command's repository path is a target folder, so files aren't completed.
+var foldersOnlyCommands = []string{"rt upload"}
+
+// Provides the values which are completed dynamically.
+type valuesSource interface {
+	getServerIds() []string
+	getBuildNames() []string
+	// Returns the repository keys, if the folder is empty, or the paths in the folder.
+	getRepoPaths(serverId, folder string, foldersOnly bool) []string
+}
+
+type completer struct {
+	source       valuesSource
+	flags        []cli.Flag
+	repoPathArgs []int
+	foldersOnly  bool
+}
+
+// Returns true if the values of the option are completed by JFrog CLI.
+// Used by the completion scripts of shells which complete the commands and options by themselves.
+func CompletesFlagValues(flagName string) bool {
+	return flagName == serverIdFlag || flagName == buildNameFlag
+}
+
+// Returns true if arguments of the command, such as 'rt upload', are completed by JFrog CLI.
+func CompletesArgs(commandName string) bool {
+	_, exists := repoPathArgs[commandName]
+	return exists
+}
+
+// Wraps the completion of the commands and their subcommands, to complete the values of the --server-id and --build-name options,
+// and the repository paths of the commands which accept them, in addition to the commands and options.
+func WrapCommandsWithDynamicCompletion(commands []cli.Command) []cli.Command {
+	return wrapCommands(commands, "", &jfrogSource{})
+}
+
+func wrapCommands(commands []cli.Command, parentName string, source valuesSource) []cli.Command {
+	for i := range commands {
+		commandName := strings.TrimSpace(parentName + " " + commands[i].Name)
+		// The completion of command namespaces lists their subcommands.
+		if len(commands[i].Subcommands) > 0 {
+			commands[i].Subcommands = wrapCommands(commands[i].Subcommands, commandName, source)
+			continue
+		}
+		commandCompleter := &completer{
+			source:       source,
+			flags:        commands[i].Flags,
+			repoPathArgs: repoPathArgs[commandName],
+			foldersOnly:  slices.Contains(foldersOnlyCommands, commandName),
+		}
+		staticComplete := commands[i].BashComplete
+		commands[i].BashComplete = func(c *cli.Context) {
+			var args []string
+			if c.Parent() != nil {
+				args = c.Parent().Args().Tail()
+			}
+			// The completion's output is read by the shell, and its logs would be printed on the command line being completed.
+			if log.GetLogger().GetLogLevel() < log.DEBUG {
+				log.SetLogger(log.NewLogger(log.ERROR, nil))
+			}
+			if values, isDynamic := commandCompleter.complete(args, os.Getenv(WordEnv)); isDynamic {
+				for _, value := range values {
+					fmt.Println(value)
+				}
+				return
+			}
+			if staticComplete != nil && !strings.EqualFold(os.Getenv(DynamicOnlyEnv), "true") {
+				staticComplete(c)
+			}
+		}
+	}
+	return commands
+}
+
+// Returns the values which complete the word, following the command's arguments.
+// If the word isn't completed dynamically, returns false, and the commands and options should be completed instead.
+func (completer *completer) complete(args []string, word string) (values []string, isDynamic bool) {
+	positionalArgs, flagValues, valueFlag := parseArgs(args, completer.flags)
+	prefix := ""
+	if name, value, found := strings.Cut(word, "="); found && strings.HasPrefix(name, "--") && valueFlag == "" {
+		valueFlag, prefix, word = strings.TrimPrefix(name, "--"), name+"=", value
+	}
+	switch valueFlag {
+	case "":
+		if strings.HasPrefix(word, "-") || !slices.Contains(completer.repoPathArgs, len(positionalArgs)) {
+			return nil, false
+		}
+		folder := ""
+		if index := strings.LastIndex(word, "/"); index >= 0 {
+			folder = word[:index+1]
+		}
+		values = completer.source.getRepoPaths(flagValues[serverIdFlag], folder, completer.foldersOnly)
+	case serverIdFlag:
+		values = completer.source.getServerIds()
+	case buildNameFlag:
+		values = completer.source.getBuildNames()
+	default:
+		// The values of other options aren't completed, so the shell may complete file paths instead.
+		return nil, true
+	}
+	for i := range values {
+		values[i] = prefix + values[i]
+	}
+	return values, true
+}
+
+// Returns the positional arguments, the values of the options, and the option which takes the value that follows the arguments, if any.
+// Bash splits '--option=value' into three words, so '=' is accepted as a separate argument.
+func parseArgs(args []string, flags []cli.Flag) (positionalArgs []string, flagValues map[string]string, valueFlag string) {
+	flagValues = make(map[string]string)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			positionalArgs = append(positionalArgs, arg)
+			continue
+		}
+		name, value, found := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if found || !takesValue(flags, name) {
+			flagValues[name] = value
+			continue
+		}
+		if i+1 < len(args) && args[i+1] == "=" {
+			i++
+		}
+		if i+1 == len(args) {
+			valueFlag = name
+			break
+		}
+		i++
+		flagValues[name] = args[i]
+	}
+	return
+}
+
+func takesValue(flags []cli.Flag, name string) bool {
+	for _, flag := range flags {
+		for _, flagName := range strings.Split(flag.GetName(), ",") {
+			if strings.TrimSpace(flagName) != name {
+				continue
+			}
+			docFlag, ok := flag.(cli.DocGenerationFlag)
+			return ok && docFlag.TakesValue()
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package dynamic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	coreTests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+var testFlags = []cli.Flag{
+	cli.StringFlag{Name: serverIdFlag},
+	cli.StringFlag{Name: buildNameFlag},
+	cli.StringFlag{Name: "spec"},
+	cli.BoolFlag{Name: "flat"},
+}
+
+// Records the requested repository paths, and returns them as the values.
+type testSource struct {
+	repoPathsRequests []string
+}
+
+func (source *testSource) getServerIds() []string {
+	return []string{"dev", "prod"}
+}
+
+func (source *testSource) getBuildNames() []string {
+	return []string{"my-build"}
+}
+
+func (source *testSource) getRepoPaths(serverId, folder string, foldersOnly bool) []string {
+	request := serverId + ":" + folder
+	if foldersOnly {
+		request += ":folders"
+	}
+	source.repoPathsRequests = append(source.repoPathsRequests, request)
+	return []string{folder + "path/"}
+}
+
+func TestParseArgs(t *testing.T) {
+	testCases := []struct {
+		args               []string
+		expectedPositional []string
+		expectedValues     map[string]string
+		expectedValueFlag  string
+	}{
+		{nil, nil, map[string]string{}, ""},
+		{[]string{"a", "--flat", "b"}, []string{"a", "b"}, map[string]string{"flat": ""}, ""},
+		{[]string{"--server-id", "dev", "a"}, []string{"a"}, map[string]string{serverIdFlag: "dev"}, ""},
+		{[]string{"--server-id=dev", "a"}, []string{"a"}, map[string]string{serverIdFlag: "dev"}, ""},
+		{[]string{"--server-id", "=", "dev", "a"}, []string{"a"}, map[string]string{serverIdFlag: "dev"}, ""},
+		{[]string{"a", "--server-id"}, []string{"a"}, map[string]string{}, serverIdFlag},
+		{[]string{"a", "--build-name", "="}, []string{"a"}, map[string]string{}, buildNameFlag},
+	}
+	for _, testCase := range testCases {
+		positional, values, valueFlag := parseArgs(testCase.args, testFlags)
+		assert.Equal(t, testCase.expectedPositional, positional, testCase.args)
+		assert.Equal(t, testCase.expectedValues, values, testCase.args)
+		assert.Equal(t, testCase.expectedValueFlag, valueFlag, testCase.args)
+	}
+}
+
+func TestComplete(t *testing.T) {
+	source := &testSource{}
+	downloadCompleter := &completer{source: source, flags: testFlags, repoPathArgs: repoPathArgs["rt download"]}
+	uploadCompleter := &completer{source: source, flags: testFlags, repoPathArgs: repoPathArgs["rt upload"], foldersOnly: true}
+	testCases := []struct {
+		completer         *completer
+		args              []string
+		word              string
+		expectedValues    []string
+		expectedIsDynamic bool
+		expectedRepoPaths string
+	}{
+		{downloadCompleter, []string{"--server-id"}, "", []string{"dev", "prod"}, true, ""},
+		{downloadCompleter, []string{"--server-id", "="}, "p", []string{"dev", "prod"}, true, ""},
+		{downloadCompleter, nil, "--server-id=p", []string{"--server-id=dev", "--server-id=prod"}, true, ""},
+		{downloadCompleter, []string{"a", "--build-name"}, "", []string{"my-build"}, true, ""},
+		// Other options' values aren't completed.
+		{downloadCompleter, []string{"--spec"}, "", nil, true, ""},
+		{downloadCompleter, nil, "--fl", nil, false, ""},
+		{downloadCompleter, nil, "", []string{"path/"}, true, ":"},
+		{downloadCompleter, []string{"--server-id", "prod", "--flat"}, "libs/org/ap", []string{"libs/org/path/"}, true, "prod:libs/org/"},
+		{downloadCompleter, []string{"libs/a"}, "", nil, false, ""},
+		{uploadCompleter, nil, "", nil, false, ""},
+		{uploadCompleter, []string{"a"}, "libs/", []string{"libs/path/"}, true, ":libs/:folders"},
+	}
+	for _, testCase := range testCases {
+		source.repoPathsRequests = nil
+		values, isDynamic := testCase.completer.complete(testCase.args, testCase.word)
+		assert.Equal(t, testCase.expectedValues, values, testCase.args, testCase.word)
+		assert.Equal(t, testCase.expectedIsDynamic, isDynamic, testCase.args, testCase.word)
+		if testCase.expectedRepoPaths == "" {
+			assert.Empty(t, source.repoPathsRequests)
+		} else {
+			assert.Equal(t, []string{testCase.expectedRepoPaths}, source.repoPathsRequests)
+		}
+	}
+}
+
+func TestWrapCommands(t *testing.T) {
+	commands := wrapCommands([]cli.Command{
+		{Name: "rt", Subcommands: []cli.Command{{Name: "download"}, {Name: "upload"}, {Name: "ping"}}},
+	}, "", &testSource{})
+	assert.Nil(t, commands[0].BashComplete)
+	for _, command := range commands[0].Subcommands {
+		assert.NotNil(t, command.BashComplete, command.Name)
+	}
+}
+
+func TestGetRepoPaths(t *testing.T) {
+	cleanUpJfrogHome, err := coreTests.SetJfrogHome()
+	require.NoError(t, err)
+	defer cleanUpJfrogHome()
+	t.Setenv(coreutils.ServerID, "")
+	t.Setenv(OfflineEnv, "")
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var err error
+		switch r.URL.Path {
+		case "/api/repositories":
+			_, err = w.Write([]byte(`[{"key": "libs-release"}, {"key": "libs-snapshot"}]`))
+		case "/api/storage/libs-release/org":
+			_, err = w.Write([]byte(`{"children": [{"uri": "/app", "folder": true}, {"uri": "/readme.txt", "folder": false}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	require.NoError(t, config.SaveServersConf([]*config.ServerDetails{
+		{ServerId: "my-server", Url: server.URL + "/", ArtifactoryUrl: server.URL + "/", AccessToken: "token", IsDefault: true},
+	}))
+	source := &jfrogSource{}
+
+	assert.Equal(t, []string{"libs-release/", "libs-snapshot/"}, source.getRepoPaths("", "", false))
+	assert.Equal(t, []string{"libs-release/org/app/", "libs-release/org/readme.txt"}, source.getRepoPaths("my-server", "libs-release/org/", false))
+	assert.Equal(t, []string{"libs-release/org/app/"}, source.getRepoPaths("", "libs-release/org/", true))
+	assert.Nil(t, source.getRepoPaths("", "missing/", false))
+	assert.Nil(t, source.getRepoPaths("unknown-server", "", false))
+	// The cached paths are completed without requests to Artifactory.
+	assert.Equal(t, 3, requests)
+
+	// Offline completion doesn't send requests, even if the paths aren't cached.
+	t.Setenv(OfflineEnv, "true")
+	assert.Nil(t, source.getRepoPaths("", "libs-snapshot/", false))
+	assert.Equal(t, 3, requests)
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(homeDir, cacheFileName))
+	assert.NoError(t, err)
+}
+
+func TestCache(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), cacheFileName)
+	cache := loadCache(cachePath)
+	_, found := cache.get("key", cacheTtl)
+	assert.False(t, found)
+	cache.set("key", []string{"a", "b"})
+	// The temporary file is renamed to the cache file.
+	entries, err := os.ReadDir(filepath.Dir(cachePath))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, cacheFileName, entries[0].Name())
+
+	cache = loadCache(cachePath)
+	values, found := cache.get("key", cacheTtl)
+	assert.True(t, found)
+	assert.Equal(t, []string{"a", "b"}, values)
+	_, found = cache.get("key", 0)
+	assert.False(t, found)
+
+	require.NoError(t, os.WriteFile(cachePath, []byte("corrupted"), 0600))
+	assert.Empty(t, loadCache(cachePath).entries)
+}
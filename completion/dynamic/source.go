@@ -0,0 +1,165 @@
+package dynamic
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/credentialhelper"
+	"github.com/jfrog/jfrog-cli/utils/overlay"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/slices"
+)
+
+// Completion is interactive, so Artifactory requests aren't retried, and are given up after a short time.
+const requestTimeout = 3 * time.Second
+
+// Reads the values from the JFrog CLI configuration, the local build-info files and Artifactory.
+type jfrogSource struct{}
+
+func (source *jfrogSource) getServerIds() []string {
+	serversConfigs, err := config.GetAllServersConfigs()
+	if err != nil {
+		log.Debug("Failed reading the configured servers:", err.Error())
+	}
+	var serverIds []string
+	for _, serverConfig := range serversConfigs {
+		serverIds = append(serverIds, serverConfig.ServerId)
+	}
+	return serverIds
+}
+
+// Returns the build names set by the environment, the CLI overlay and the project's build configuration,
+// and the names of the builds whose build-info was generated locally and wasn't published yet.
+// The partial build-info files aren't read, since they don't include the build name.
+func (source *jfrogSource) getBuildNames() []string {
+	buildNames := []string{os.Getenv(coreutils.BuildName)}
+	if cliOverlay, err := overlay.Load(); err == nil && cliOverlay != nil {
+		buildNames = append(buildNames, cliOverlay.BuildName)
+	}
+	if buildName, err := build.NewBuildConfiguration("", "", "", "").GetBuildName(); err == nil {
+		buildNames = append(buildNames, buildName)
+	}
+	buildsDir := filepath.Join(coreutils.GetCliPersistentTempDirPath(), build.BuildTempPath)
+	buildFiles, err := filepath.Glob(filepath.Join(buildsDir, "*", "*"))
+	if err != nil {
+		log.Debug("Failed listing the local build-info files:", err.Error())
+	}
+	for _, buildFile := range buildFiles {
+		content, err := os.ReadFile(buildFile)
+		if err != nil {
+			continue
+		}
+		var buildInfo struct {
+			Name string `json:"name"`
+		}
+		if json.Unmarshal(content, &buildInfo) == nil {
+			buildNames = append(buildNames, buildInfo.Name)
+		}
+	}
+	return getUniqueValues(buildNames)
+}
+
+func getUniqueValues(values []string) []string {
+	var uniqueValues []string
+	for _, value := range values {
+		if value != "" && !slices.Contains(uniqueValues, value) {
+			uniqueValues = append(uniqueValues, value)
+		}
+	}
+	sort.Strings(uniqueValues)
+	return uniqueValues
+}
+
+// The repository paths are cached, and fetched from Artifactory when the cached paths expire, unless completion is offline.
+// Offline completion uses the cached paths regardless of their age.
+func (source *jfrogSource) getRepoPaths(serverId, folder string, foldersOnly bool) []string {
+	serverDetails, err := getServerDetails(serverId)
+	if err != nil || serverDetails == nil || serverDetails.ArtifactoryUrl == "" {
+		return nil
+	}
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return nil
+	}
+	cache := loadCache(filepath.Join(homeDir, cacheFileName))
+	cacheKey := serverDetails.ServerId + ":" + folder
+	offline := strings.EqualFold(os.Getenv(OfflineEnv), "true")
+	maxAge := cacheTtl
+	if offline {
+		maxAge = cacheRetention
+	}
+	paths, cached := cache.get(cacheKey, maxAge)
+	if !cached && !offline {
+		if paths, err = fetchRepoPaths(serverDetails, folder); err != nil {
+			log.Debug("Failed fetching the repository paths for completion:", err.Error())
+			return nil
+		}
+		cache.set(cacheKey, paths)
+	}
+	if !foldersOnly {
+		return paths
+	}
+	var folders []string
+	for _, path := range paths {
+		if strings.HasSuffix(path, "/") {
+			folders = append(folders, path)
+		}
+	}
+	return folders
+}
+
+// The server is resolved by the same precedence as the commands use:
+// the --server-id option, the JFROG_CLI_SERVER_ID environment variable, the CLI overlay and the default server.
+// The secrets of servers which use a credential helper are resolved through the helper.
+func getServerDetails(serverId string) (*config.ServerDetails, error) {
+	if serverId == "" {
+		serverId = os.Getenv(coreutils.ServerID)
+	}
+	if serverId == "" {
+		if cliOverlay, err := overlay.Load(); err == nil && cliOverlay != nil {
+			serverId = cliOverlay.ServerId
+		}
+	}
+	return credentialhelper.GetSpecificConfig(serverId, true, false)
+}
+
+// Returns the repository keys, if the folder is empty, or the paths of the folder's children.
+// The paths of folders end with a slash, so they're completed further.
+func fetchRepoPaths(serverDetails *config.ServerDetails, folder string) ([]string, error) {
+	servicesManager, err := utils.CreateServiceManagerWithContext(context.Background(), serverDetails, false, 0, 0, 0, requestTimeout)
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{}
+	if folder == "" {
+		repositories, err := servicesManager.GetAllRepositories()
+		if err != nil {
+			return nil, err
+		}
+		for _, repository := range *repositories {
+			paths = append(paths, repository.Key+"/")
+		}
+		return paths, nil
+	}
+	folderInfo, err := servicesManager.FolderInfo(folder)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range folderInfo.Children {
+		path := folder + strings.TrimPrefix(child.Uri, "/")
+		if child.Folder {
+			path += "/"
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
@@ -15,8 +15,16 @@ _jfrog() {
     local cur opts base
     COMPREPLY=()
     cur="${COMP_WORDS[COMP_CWORD]}"
-    opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} --generate-bash-completion )
+    # Bash splits --option=value into three words. The value is completed after the '='.
+    if [[ "${cur}" == "=" ]]; then
+        cur=""
+    fi
+    opts=$( JFROG_CLI_COMPLETION_WORD="${cur}" ${COMP_WORDS[@]:0:$COMP_CWORD} --generate-bash-completion )
     COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
+    # Repository folders are completed further, so no space is added after them.
+    if [[ ${#COMPREPLY[@]} -eq 1 && "${COMPREPLY[0]}" == */ ]] && type compopt &>/dev/null; then
+        compopt -o nospace
+    fi
 }
 
 complete -F _jfrog -o default jfrog
@@ -9,6 +9,18 @@ import (
 	"github.com/urfave/cli"
 )
 
+// Completes the server IDs, build names and repository paths, which fish can't complete from the commands' static completions.
+const fishDynamicAutocomplete = `
+function __fish_%[1]s_dynamic_complete
+    set -l tokens (commandline -opc)
+    env JFROG_CLI_COMPLETION_WORD=(commandline -ct) JFROG_CLI_COMPLETION_DYNAMIC_ONLY=true $tokens --generate-bash-completion 2>/dev/null
+end
+
+complete -c %[1]s -a '(__fish_%[1]s_dynamic_complete)'
+complete -c %[1]s -n '__fish_prev_arg_in --server-id' -f -l server-id -r -a '(__fish_%[1]s_dynamic_complete)'
+complete -c %[1]s -n '__fish_prev_arg_in --build-name' -f -l build-name -r -a '(__fish_%[1]s_dynamic_complete)'
+`
+
 func WriteFishCompletionScript(c *cli.Context, install bool) {
 	jfApp := c.Parent().Parent().App
 	fishAutocomplete, err := jfApp.ToFishCompletion()
@@ -16,6 +28,7 @@ func WriteFishCompletionScript(c *cli.Context, install bool) {
 		log.Error(err)
 		return
 	}
+	fishAutocomplete += fmt.Sprintf(fishDynamicAutocomplete, jfApp.Name)
 	if !install {
 		fmt.Print(fishAutocomplete)
 		return
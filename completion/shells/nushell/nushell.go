@@ -0,0 +1,119 @@
+package nushell
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/completion/dynamic"
+	"github.com/jfrog/jfrog-cli/completion/shells/tree"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+// Completes the server IDs, build names and repository paths by JFrog CLI.
+// The subcommands and options are completed by Nushell, from the commands' signatures which follow.
+const nushellAutocompleteHeader = `# %[1]s Nushell completion
+
+export def "nu-complete %[1]s" [context: string] {
+    let words = ($context | split row -r '\s+')
+    let args = ($words | drop 1)
+    with-env {JFROG_CLI_COMPLETION_WORD: ($words | last), JFROG_CLI_COMPLETION_DYNAMIC_ONLY: "true"} {
+        run-external ($args | first) ...($args | skip 1) "--generate-bash-completion" | complete | get stdout | lines
+    }
+}
+`
+
+// Nushell accepts options whose names are made of letters, digits, dashes and underscores.
+var flagNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// Generates the completion script from the commands of the app, including the installed plugins.
+func GetNushellCompletionScript(app *cli.App) string {
+	script := &strings.Builder{}
+	fmt.Fprintf(script, nushellAutocompleteHeader, app.Name)
+	root := tree.GetRootCommand(app)
+	writeCommand(script, app.Name, root.Names[0], root)
+	return script.String()
+}
+
+// Writes the signature of the command, and of its subcommands. Each alias of the command gets its own signature.
+func writeCommand(script *strings.Builder, appName, name string, command *tree.Command) {
+	script.WriteString("\n")
+	if command.Usage != "" {
+		fmt.Fprintf(script, "# %s\n", command.Usage)
+	}
+	fmt.Fprintf(script, "export extern %q [\n", name)
+	completer := fmt.Sprintf("@\"nu-complete %s\"", appName)
+	if !command.SkipFlagParsing {
+		shortNames := make(map[string]bool)
+		for _, flag := range command.Flags {
+			writeFlag(script, flag, completer, shortNames)
+		}
+	}
+	// Commands which aren't known by the script, such as plugins installed after it was generated, are passed to JFrog CLI as arguments.
+	argsCompleter := ""
+	if dynamic.CompletesArgs(strings.Join(command.Path, " ")) {
+		argsCompleter = completer
+	}
+	fmt.Fprintf(script, "    ...args: string%s\n", argsCompleter)
+	script.WriteString("]\n")
+	for _, subcommand := range command.Subcommands {
+		for _, subcommandName := range subcommand.Names {
+			writeCommand(script, appName, name+" "+subcommandName, subcommand)
+		}
+	}
+}
+
+func writeFlag(script *strings.Builder, flag tree.Flag, completer string, shortNames map[string]bool) {
+	// Nushell adds the help option to all commands.
+	if flag.Names[0] == "help" || !flagNamePattern.MatchString(flag.Names[0]) {
+		return
+	}
+	definition := "--" + flag.Names[0]
+	for _, shortName := range flag.Names[1:] {
+		if len(shortName) == 1 && shortName != "h" && !shortNames[shortName] {
+			shortNames[shortName] = true
+			definition += "(-" + shortName + ")"
+			break
+		}
+	}
+	if flag.TakesValue {
+		definition += ": string"
+		if dynamic.CompletesFlagValues(flag.Names[0]) {
+			definition += completer
+		}
+	}
+	if flag.Usage != "" {
+		definition += " # " + flag.Usage
+	}
+	script.WriteString("    " + definition + "\n")
+}
+
+func WriteNushellCompletionScript(c *cli.Context, install bool) {
+	nushellAutocomplete := GetNushellCompletionScript(c.Parent().Parent().App)
+	if !install {
+		fmt.Print(nushellAutocomplete)
+		return
+	}
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	completionPath := filepath.Join(homeDir, "jfrog_nushell_completion.nu")
+	if err = os.WriteFile(completionPath, []byte(nushellAutocomplete), 0600); err != nil {
+		log.Error(err)
+		return
+	}
+	sourceCommand := "source " + completionPath
+	fmt.Printf(`Generated Nushell completion script at %s.
+To activate auto-completion permanently, put the following command in your Nushell config file, whose path is stored in the $nu.config-path variable, and restart Nushell:
+
+%s
+
+`,
+		completionPath, sourceCommand)
+}
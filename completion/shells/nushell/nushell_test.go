@@ -0,0 +1,39 @@
+package nushell
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+func TestGetNushellCompletionScript(t *testing.T) {
+	app := cli.NewApp()
+	app.Name = "jf"
+	app.Commands = []cli.Command{
+		{Name: "rt", Usage: "Artifactory commands.", Subcommands: []cli.Command{
+			{Name: "upload", Aliases: []string{"u"}, Usage: "Upload files.", Flags: []cli.Flag{
+				cli.StringFlag{Name: "server-id", Usage: "Server ID.` `"},
+				cli.StringFlag{Name: "threads", Usage: "Threads.` `"},
+				cli.BoolFlag{Name: "flat, f", Usage: "Flat.` `"},
+				cli.BoolFlag{Name: "help, h"},
+			}},
+			{Name: "ping"},
+		}},
+		{Name: "my-plugin", Usage: "My plugin.", SkipFlagParsing: true, Flags: []cli.Flag{cli.BoolFlag{Name: "verbose"}}},
+	}
+
+	script := GetNushellCompletionScript(app)
+	assert.Contains(t, script, "export def \"nu-complete jf\" [context: string] {")
+	assert.Contains(t, script, "# Artifactory commands.\nexport extern \"jf rt\" [\n    ...args: string\n]\n")
+	for _, name := range []string{"jf rt upload", "jf rt u"} {
+		assert.Contains(t, script, "# Upload files.\nexport extern \""+name+"\" [\n"+
+			"    --server-id: string@\"nu-complete jf\" # Server ID.\n"+
+			"    --threads: string # Threads.\n"+
+			"    --flat(-f) # Flat.\n"+
+			"    ...args: string@\"nu-complete jf\"\n]\n")
+	}
+	assert.Contains(t, script, "export extern \"jf rt ping\" [\n    ...args: string\n]\n")
+	// The options of commands which parse their own options are passed as arguments.
+	assert.Contains(t, script, "# My plugin.\nexport extern \"jf my-plugin\" [\n    ...args: string\n]\n")
+}
@@ -0,0 +1,141 @@
+package powershell
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/completion/shells/tree"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+// Completes the subcommands and options of the command which precedes the completed word.
+// The server IDs, build names and repository paths are completed by JFrog CLI, and other values are completed as file paths.
+const powershellAutocompleteTemplate = `# jf PowerShell completion
+
+Register-ArgumentCompleter -Native -CommandName 'jf', 'jfrog' -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $commands = @{
+%s    }
+    $aliases = @{
+%s    }
+
+    $words = @($commandAst.CommandElements | Where-Object { $_.Extent.EndOffset -le $cursorPosition } | ForEach-Object { $_.ToString() })
+    if ($wordToComplete -ne '' -and $words.Count -gt 1 -and $words[-1] -eq $wordToComplete) {
+        $words = @($words | Select-Object -SkipLast 1)
+    }
+    $path = ''
+    foreach ($word in ($words | Select-Object -Skip 1)) {
+        if ($word.StartsWith('-')) {
+            continue
+        }
+        $key = "$path $word".Trim()
+        if (-not $aliases.ContainsKey($key)) {
+            break
+        }
+        $path = $aliases[$key]
+    }
+    $command = $commands[$path]
+    $results = @()
+    if ($command.Commands.Count -eq 0) {
+        $previousWord = $env:JFROG_CLI_COMPLETION_WORD
+        $previousDynamicOnly = $env:JFROG_CLI_COMPLETION_DYNAMIC_ONLY
+        $env:JFROG_CLI_COMPLETION_WORD = $wordToComplete
+        $env:JFROG_CLI_COMPLETION_DYNAMIC_ONLY = 'true'
+        try {
+            $values = @(& $words[0] @($words | Select-Object -Skip 1) --generate-bash-completion 2>$null)
+        } finally {
+            $env:JFROG_CLI_COMPLETION_WORD = $previousWord
+            $env:JFROG_CLI_COMPLETION_DYNAMIC_ONLY = $previousDynamicOnly
+        }
+        foreach ($value in $values) {
+            if ($value -and $value.StartsWith($wordToComplete)) {
+                $results += [System.Management.Automation.CompletionResult]::new($value, $value, 'ParameterValue', $value)
+            }
+        }
+        if ($results.Count -gt 0) {
+            return $results
+        }
+    }
+    if ($wordToComplete.StartsWith('-')) {
+        $candidates, $resultType = $command.Flags, 'ParameterName'
+    } else {
+        $candidates, $resultType = $command.Commands, 'ParameterValue'
+    }
+    foreach ($candidate in $candidates) {
+        if ($candidate.Name.StartsWith($wordToComplete)) {
+            $description = if ($candidate.Description) { $candidate.Description } else { $candidate.Name }
+            $results += [System.Management.Automation.CompletionResult]::new($candidate.Name, $candidate.Name, $resultType, $description)
+        }
+    }
+    return $results
+}
+`
+
+// Generates the completion script from the commands of the app, including the installed plugins.
+func GetPowershellCompletionScript(app *cli.App) string {
+	commands, aliases := &strings.Builder{}, &strings.Builder{}
+	writeCommand(commands, aliases, tree.GetRootCommand(app))
+	return fmt.Sprintf(powershellAutocompleteTemplate, commands.String(), aliases.String())
+}
+
+// Writes the command's subcommands and options, keyed by the command's path, and maps the subcommands' names and aliases to their paths.
+func writeCommand(commands, aliases *strings.Builder, command *tree.Command) {
+	path := strings.Join(command.Path, " ")
+	fmt.Fprintf(commands, "        %s = @{\n            Commands = @(\n", quote(path))
+	for _, subcommand := range command.Subcommands {
+		subcommandPath := strings.Join(subcommand.Path, " ")
+		for _, name := range subcommand.Names {
+			fmt.Fprintf(commands, "                @{ Name = %s; Description = %s }\n", quote(name), quote(subcommand.Usage))
+			fmt.Fprintf(aliases, "        %s = %s\n", quote(strings.TrimSpace(path+" "+name)), quote(subcommandPath))
+		}
+	}
+	commands.WriteString("            )\n            Flags = @(\n")
+	for _, flag := range command.Flags {
+		fmt.Fprintf(commands, "                @{ Name = %s; Description = %s }\n", quote("--"+flag.Names[0]), quote(flag.Usage))
+	}
+	commands.WriteString("            )\n        }\n")
+	for _, subcommand := range command.Subcommands {
+		writeCommand(commands, aliases, subcommand)
+	}
+}
+
+// Returns the text as a single-quoted PowerShell string. PowerShell also treats the typographic single quotes as quotes.
+func quote(text string) string {
+	for _, quoteChar := range []string{"'", "‘", "’", "‚", "‛"} {
+		text = strings.ReplaceAll(text, quoteChar, quoteChar+quoteChar)
+	}
+	return "'" + text + "'"
+}
+
+func WritePowershellCompletionScript(c *cli.Context, install bool) {
+	powershellAutocomplete := GetPowershellCompletionScript(c.Parent().Parent().App)
+	if !install {
+		fmt.Print(powershellAutocomplete)
+		return
+	}
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	completionPath := filepath.Join(homeDir, "jfrog_powershell_completion.ps1")
+	if err = os.WriteFile(completionPath, []byte(powershellAutocomplete), 0600); err != nil {
+		log.Error(err)
+		return
+	}
+	sourceCommand := ". " + completionPath
+	fmt.Printf(`Generated PowerShell completion script at %s.
+To activate auto-completion on this shell only, source the completion script by running the following command:
+
+%s
+
+To activate auto-completion permanently, put the above command in your PowerShell profile, whose path is stored in the $PROFILE variable.
+
+`,
+		completionPath, sourceCommand)
+}
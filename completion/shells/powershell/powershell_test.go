@@ -0,0 +1,30 @@
+package powershell
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+func TestGetPowershellCompletionScript(t *testing.T) {
+	app := cli.NewApp()
+	app.Name = "jf"
+	app.Commands = []cli.Command{
+		{Name: "rt", Usage: "Artifactory commands.", Subcommands: []cli.Command{
+			{Name: "upload", Aliases: []string{"u"}, Usage: "Upload files, which don't exist.", Flags: []cli.Flag{
+				cli.StringFlag{Name: "server-id", Usage: "Server ID.` `"},
+			}},
+		}},
+	}
+
+	script := GetPowershellCompletionScript(app)
+	assert.Contains(t, script, "Register-ArgumentCompleter -Native -CommandName 'jf', 'jfrog'")
+	assert.Contains(t, script, "        'rt' = @{\n            Commands = @(\n"+
+		"                @{ Name = 'upload'; Description = 'Upload files, which don''t exist.' }\n"+
+		"                @{ Name = 'u'; Description = 'Upload files, which don''t exist.' }\n            )\n")
+	assert.Contains(t, script, "        'rt upload' = @{\n            Commands = @(\n            )\n            Flags = @(\n"+
+		"                @{ Name = '--server-id'; Description = 'Server ID.' }\n            )\n")
+	assert.Contains(t, script, "        'rt' = 'rt'\n")
+	assert.Contains(t, script, "        'rt u' = 'rt upload'\n")
+}
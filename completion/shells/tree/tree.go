@@ -0,0 +1,91 @@
+package tree
+
+import (
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// A command of the completion scripts which are generated from the app's commands.
+type Command struct {
+	// The names of the command and its parent commands, such as 'rt upload'. Empty for the app itself.
+	Path  []string
+	Names []string
+	Usage string
+	Flags []Flag
+	// Plugins and some of the build tools commands parse their own options, so their options aren't known.
+	SkipFlagParsing bool
+	Subcommands     []*Command
+}
+
+type Flag struct {
+	// The long name, followed by the short names.
+	Names      []string
+	Usage      string
+	TakesValue bool
+}
+
+// Returns the app as a command, whose subcommands are the app's non-hidden commands, with their aliases, options and single line descriptions.
+func GetRootCommand(app *cli.App) *Command {
+	return &Command{
+		Names:       []string{app.Name},
+		Usage:       ToSingleLine(app.Usage),
+		Flags:       getFlags(app.Flags),
+		Subcommands: getCommands(app.Commands, nil),
+	}
+}
+
+func getCommands(commands []cli.Command, parentPath []string) []*Command {
+	var result []*Command
+	for _, command := range commands {
+		if command.Hidden {
+			continue
+		}
+		path := append(append([]string{}, parentPath...), command.Name)
+		result = append(result, &Command{
+			Path:            path,
+			Names:           command.Names(),
+			Usage:           ToSingleLine(command.Usage),
+			Flags:           getFlags(command.Flags),
+			SkipFlagParsing: command.SkipFlagParsing,
+			Subcommands:     getCommands(command.Subcommands, path),
+		})
+	}
+	return result
+}
+
+func getFlags(flags []cli.Flag) []Flag {
+	var result []Flag
+	seen := make(map[string]bool)
+	for _, flag := range flags {
+		var names []string
+		for _, name := range strings.Split(flag.GetName(), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 || seen[flag.GetName()] {
+			continue
+		}
+		seen[flag.GetName()] = true
+		// The long name is completed, and the short names are accepted by shells which declare the options.
+		for i := range names {
+			if len(names[i]) > 1 {
+				names[0], names[i] = names[i], names[0]
+				break
+			}
+		}
+		resultFlag := Flag{Names: names}
+		if docFlag, ok := flag.(cli.DocGenerationFlag); ok {
+			resultFlag.Usage = ToSingleLine(strings.TrimSuffix(docFlag.GetUsage(), "` `"))
+			resultFlag.TakesValue = docFlag.TakesValue()
+		}
+		result = append(result, resultFlag)
+	}
+	return result
+}
+
+// The commands' descriptions may span multiple lines, which the completion menus show as a single line.
+func ToSingleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,43 @@
+package tree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+func TestGetRootCommand(t *testing.T) {
+	app := cli.NewApp()
+	app.Name = "jf"
+	app.Usage = "JFrog CLI."
+	app.Flags = []cli.Flag{cli.StringFlag{Name: "format", Usage: "Output format.` `"}}
+	app.Commands = []cli.Command{
+		{Name: "rt", Usage: "Artifactory\n\tcommands.", Subcommands: []cli.Command{
+			{Name: "upload", Aliases: []string{"u"}, Flags: []cli.Flag{
+				cli.StringFlag{Name: "server-id", Usage: "Server ID.` `"},
+				cli.BoolFlag{Name: "f, flat", Usage: "Flat.` `"},
+				cli.BoolFlag{Name: "f, flat", Usage: "Flat.` `"},
+			}},
+		}},
+		{Name: "my-plugin", SkipFlagParsing: true},
+		{Name: "secret", Hidden: true},
+	}
+
+	root := GetRootCommand(app)
+	assert.Equal(t, []string{"jf"}, root.Names)
+	assert.Empty(t, root.Path)
+	assert.Equal(t, []Flag{{Names: []string{"format"}, Usage: "Output format.", TakesValue: true}}, root.Flags)
+	assert.Len(t, root.Subcommands, 2)
+
+	rt := root.Subcommands[0]
+	assert.Equal(t, "Artifactory commands.", rt.Usage)
+	upload := rt.Subcommands[0]
+	assert.Equal(t, []string{"rt", "upload"}, upload.Path)
+	assert.Equal(t, []string{"upload", "u"}, upload.Names)
+	assert.Equal(t, []Flag{
+		{Names: []string{"server-id"}, Usage: "Server ID.", TakesValue: true},
+		{Names: []string{"flat", "f"}, Usage: "Flat."},
+	}, upload.Flags)
+	assert.True(t, root.Subcommands[1].SkipFlagParsing)
+}
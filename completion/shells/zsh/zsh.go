@@ -13,9 +13,13 @@ import (
 const ZshAutocomplete = `#compdef _jf jf _jfrog jfrog
 
 _jfrog() {
-	local -a opts
-	opts=("${(@f)$(_CLI_ZSH_AUTOCOMPLETE_HACK=1 ${words[@]:0:#words[@]-1} --generate-bash-completion)}")
+	local -a opts folders
+	opts=("${(@f)$(_CLI_ZSH_AUTOCOMPLETE_HACK=1 JFROG_CLI_COMPLETION_WORD="${words[CURRENT]}" ${words[@]:0:#words[@]-1} --generate-bash-completion)}")
+	# Repository folders are completed further, so no space is added after them.
+	folders=(${(M)opts:#*/})
+	opts=(${opts:#*/})
 	_describe 'values' opts
+	compadd -S '' -- $folders
 	if [[ $compstate[nmatches] -eq 0 && $words[$CURRENT] != -* ]]; then
 		_files
 	fi
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -8,19 +9,27 @@ import (
 
 	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
 	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli/docs/config/add"
+	"github.com/jfrog/jfrog-cli/docs/config/clone"
 	"github.com/jfrog/jfrog-cli/docs/config/edit"
 	"github.com/jfrog/jfrog-cli/docs/config/remove"
+	"github.com/jfrog/jfrog-cli/docs/config/rename"
+	"github.com/jfrog/jfrog-cli/docs/config/rotatetoken"
+	"github.com/jfrog/jfrog-cli/docs/config/test"
 	"github.com/jfrog/jfrog-cli/docs/config/use"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/urfave/cli"
 
 	"github.com/jfrog/jfrog-cli/docs/config/exportcmd"
 	"github.com/jfrog/jfrog-cli/docs/config/importcmd"
 	"github.com/jfrog/jfrog-cli/docs/config/show"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/credentialhelper"
+	"github.com/jfrog/jfrog-cli/utils/overlay"
 )
 
 func GetCommands() []cli.Command {
@@ -45,6 +54,7 @@ func GetCommands() []cli.Command {
 			Name:         "show",
 			Aliases:      []string{"s"},
 			Usage:        show.GetDescription(),
+			Flags:        cliutils.GetCommandFlags(cliutils.ShowConfig),
 			HelpName:     corecommon.CreateUsage("c show", show.GetDescription(), show.Usage),
 			BashComplete: corecommon.CreateBashCompletionFunc(commands.GetAllServerIds()...),
 			Action:       showCmd,
@@ -81,6 +91,40 @@ func GetCommands() []cli.Command {
 			BashComplete: corecommon.CreateBashCompletionFunc(commands.GetAllServerIds()...),
 			Action:       useCmd,
 		},
+		{
+			Name:         "rename",
+			Usage:        rename.GetDescription(),
+			HelpName:     corecommon.CreateUsage("c rename", rename.GetDescription(), rename.Usage),
+			UsageText:    rename.GetArguments(),
+			BashComplete: corecommon.CreateBashCompletionFunc(commands.GetAllServerIds()...),
+			Action:       renameCmd,
+		},
+		{
+			Name:         "clone",
+			Usage:        clone.GetDescription(),
+			HelpName:     corecommon.CreateUsage("c clone", clone.GetDescription(), clone.Usage),
+			UsageText:    clone.GetArguments(),
+			BashComplete: corecommon.CreateBashCompletionFunc(commands.GetAllServerIds()...),
+			Action:       cloneCmd,
+		},
+		{
+			Name:         "test",
+			Usage:        test.GetDescription(),
+			Flags:        cliutils.GetCommandFlags(cliutils.TestConfig),
+			HelpName:     corecommon.CreateUsage("c test", test.GetDescription(), test.Usage),
+			UsageText:    test.GetArguments(),
+			BashComplete: corecommon.CreateBashCompletionFunc(commands.GetAllServerIds()...),
+			Action:       testCmd,
+		},
+		{
+			Name:         "rotate-token",
+			Usage:        rotatetoken.GetDescription(),
+			Flags:        cliutils.GetCommandFlags(cliutils.RotateTokenConfig),
+			HelpName:     corecommon.CreateUsage("c rotate-token", rotatetoken.GetDescription(), rotatetoken.Usage),
+			UsageText:    rotatetoken.GetArguments(),
+			BashComplete: corecommon.CreateBashCompletionFunc(commands.GetAllServerIds()...),
+			Action:       rotateTokenCmd,
+		},
 	})
 }
 
@@ -113,6 +157,11 @@ const (
 )
 
 func addOrEdit(c *cli.Context, operation configOperation) error {
+	// Commands which load the server's configuration through jfrog-cli-core, such as the build-tool commands,
+	// can't resolve secrets held by a credential helper, and would run unauthenticated.
+	if c.String(cliutils.CredentialHelper) != "" {
+		return errorutils.CheckErrorf("storing the server's secrets in a credential helper is not supported yet, since not all commands can resolve them")
+	}
 	configCommandConfiguration, err := CreateConfigCommandConfiguration(c)
 	if err != nil {
 		return err
@@ -136,7 +185,30 @@ func addOrEdit(c *cli.Context, operation configOperation) error {
 	}
 	configCmd := commands.NewConfigCommand(commands.AddOrEdit, serverId).SetDetails(configCommandConfiguration.ServerDetails).SetInteractive(configCommandConfiguration.Interactive).
 		SetEncPassword(configCommandConfiguration.EncPassword).SetUseBasicAuthOnly(configCommandConfiguration.BasicAuthOnly)
-	return configCmd.Run()
+	helperName, err := getCredentialHelperName(serverId, operation)
+	if err != nil {
+		return err
+	}
+	if helperName != "" {
+		return credentialhelper.RunConfigCommand(configCmd, helperName)
+	}
+	if err = configCmd.Run(); err != nil {
+		return err
+	}
+	details, err := configCmd.ServerDetails()
+	if err != nil || details == nil {
+		return err
+	}
+	// If the server was overwritten, its secrets are stored in the config file now.
+	return credentialhelper.SetHelperName(details.ServerId, "")
+}
+
+// Servers which were configured with a credential helper keep using it when edited.
+func getCredentialHelperName(serverId string, operation configOperation) (string, error) {
+	if operation != editOperation {
+		return "", nil
+	}
+	return credentialhelper.GetHelperName(serverId)
 }
 
 func showCmd(c *cli.Context) error {
@@ -147,6 +219,43 @@ func showCmd(c *cli.Context) error {
 	if c.NArg() == 1 {
 		serverId = c.Args()[0]
 	}
+	if c.Bool(cliutils.Effective) {
+		format, err := cliutils.GetOutputFormat(c, cliutils.Table)
+		if err != nil {
+			return err
+		}
+		return showEffectiveConfig(serverId, format)
+	}
+	return commands.ShowConfig(serverId)
+}
+
+// Shows the settings which apply to commands executed from the working directory, and the configuration of the effective server.
+func showEffectiveConfig(serverId string, format cliutils.OutputFormat) error {
+	cliOverlay, err := overlay.Load()
+	if err != nil {
+		return err
+	}
+	var defaultServerId string
+	defaultServer, err := config.GetDefaultServerConf()
+	if err != nil {
+		log.Debug("Couldn't get the default server:", err.Error())
+	} else if defaultServer != nil {
+		defaultServerId = defaultServer.ServerId
+	}
+	settings := overlay.GetEffectiveSettings(cliOverlay, defaultServerId)
+	if err = cliutils.PrintCommandOutput(cliutils.CommandOutput{Data: settings, Title: "Effective Settings", EmptyMessage: "No settings"}, format); err != nil {
+		return err
+	}
+	// The server's configuration isn't structured, so it is shown in the table format only.
+	if format != cliutils.Table {
+		return nil
+	}
+	if serverId == "" {
+		serverId = settings[0].Value
+	}
+	if serverId == "" {
+		return nil
+	}
 	return commands.ShowConfig(serverId)
 }
 
@@ -158,7 +267,18 @@ func deleteCmd(c *cli.Context) error {
 
 	// Clear all configurations
 	if c.NArg() == 0 {
-		return commands.NewConfigCommand(commands.Clear, "").SetInteractive(!quiet).Run()
+		servers, err := config.GetAllServersConfigs()
+		if err != nil {
+			return err
+		}
+		if err = commands.NewConfigCommand(commands.Clear, "").SetInteractive(!quiet).Run(); err != nil {
+			return err
+		}
+		if exists, err := config.IsServerConfExists(); err != nil || exists {
+			// The clear was not confirmed.
+			return err
+		}
+		return eraseCredentials(servers...)
 	}
 
 	// Delete single configuration
@@ -166,7 +286,28 @@ func deleteCmd(c *cli.Context) error {
 	if !quiet && !coreutils.AskYesNo("Are you sure you want to delete \""+serverId+"\" configuration?", false) {
 		return nil
 	}
-	return commands.NewConfigCommand(commands.Delete, serverId).Run()
+	servers, err := config.GetAllServersConfigs()
+	if err != nil {
+		return err
+	}
+	if err = commands.NewConfigCommand(commands.Delete, serverId).Run(); err != nil {
+		return err
+	}
+	for _, server := range servers {
+		if server.ServerId == serverId {
+			return eraseCredentials(server)
+		}
+	}
+	return nil
+}
+
+// Erases the secrets of servers which use a credential helper, after the servers were removed from the config.
+func eraseCredentials(servers ...*config.ServerDetails) error {
+	var errs []error
+	for _, server := range servers {
+		errs = append(errs, credentialhelper.EraseServerCredentials(server))
+	}
+	return errors.Join(errs...)
 }
 
 func importCmd(c *cli.Context) error {
@@ -196,6 +337,125 @@ func useCmd(c *cli.Context) error {
 	return commands.NewConfigCommand(commands.Use, serverId).Run()
 }
 
+func renameCmd(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	return renameServer(c.Args()[0], c.Args()[1])
+}
+
+func cloneCmd(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	return cloneServer(c.Args()[0], c.Args()[1])
+}
+
+func testCmd(c *cli.Context) error {
+	if c.NArg() > 1 || (c.NArg() == 1 && c.Bool(cliutils.All)) {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	format, err := cliutils.GetOutputFormat(c, cliutils.Table)
+	if err != nil {
+		return err
+	}
+	var servers []*config.ServerDetails
+	if c.Bool(cliutils.All) {
+		if servers, err = config.GetAllServersConfigs(); err != nil {
+			return err
+		}
+		for _, server := range servers {
+			if err = credentialhelper.Resolve(server); err != nil {
+				return err
+			}
+		}
+	} else {
+		// If no server ID was given, test the default server.
+		server, err := credentialhelper.GetSpecificConfig(c.Args().Get(0), true, false)
+		if err != nil {
+			return err
+		}
+		if server.ServerId == "" {
+			return errorutils.CheckErrorf("no server is configured. Use the 'jf c add' command to configure a server")
+		}
+		servers = []*config.ServerDetails{server}
+	}
+	return runConfigTest(servers, format)
+}
+
+func rotateTokenCmd(c *cli.Context) error {
+	if c.NArg() > 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	// If no server ID was given, rotate the token of the default server.
+	return rotateToken(c.Args().Get(0), c.Bool(cliutils.Revoke))
+}
+
+func renameServer(serverId, newServerId string) error {
+	servers, server, err := prepareNewServerId(serverId, newServerId)
+	if err != nil {
+		return err
+	}
+	// Secrets stored by a credential helper are keyed by the server ID, so they are moved to the new ID.
+	// The old ID's secrets are erased only after the renamed server was saved, so that a failed save doesn't lose them.
+	if err = credentialhelper.CopyServerCredentials(server, newServerId); err != nil {
+		return err
+	}
+	oldServer := *server
+	server.ServerId = newServerId
+	if err = config.SaveServersConf(servers); err != nil {
+		return errors.Join(err, eraseCredentials(server))
+	}
+	if err = eraseCredentials(&oldServer); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Server '%s' was renamed to '%s'.", serverId, newServerId))
+	return nil
+}
+
+func cloneServer(serverId, newServerId string) error {
+	servers, source, err := prepareNewServerId(serverId, newServerId)
+	if err != nil {
+		return err
+	}
+	newServer := *source
+	newServer.ServerId = newServerId
+	newServer.IsDefault = false
+	if err = credentialhelper.CopyServerCredentials(source, newServerId); err != nil {
+		return err
+	}
+	if err = config.SaveServersConf(append(servers, &newServer)); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Server '%s' was cloned to '%s'.", serverId, newServerId))
+	return nil
+}
+
+// Validates that the server exists and that the new server ID is available.
+// Returns all the configured servers, and the details of the requested server.
+func prepareNewServerId(serverId, newServerId string) ([]*config.ServerDetails, *config.ServerDetails, error) {
+	if err := ValidateServerId(newServerId); err != nil {
+		return nil, nil, err
+	}
+	servers, err := config.GetAllServersConfigs()
+	if err != nil {
+		return nil, nil, err
+	}
+	var server *config.ServerDetails
+	for _, details := range servers {
+		switch details.ServerId {
+		case newServerId:
+			return nil, nil, errorutils.CheckErrorf("Server ID '%s' already exists.", newServerId)
+		case serverId:
+			server = details
+		}
+	}
+	if server == nil {
+		return nil, nil, errorutils.CheckErrorf("Server ID '%s' doesn't exist.", serverId)
+	}
+	return servers, server, nil
+}
+
 func CreateConfigCommandConfiguration(c *cli.Context) (configCommandConfiguration *commands.ConfigCommandConfiguration, err error) {
 	configCommandConfiguration = new(commands.ConfigCommandConfiguration)
 	configCommandConfiguration.ServerDetails, err = cliutils.CreateServerDetailsFromFlags(c)
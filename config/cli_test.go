@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	coreTests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameAndCloneServer(t *testing.T) {
+	cleanUpJfrogHome, err := coreTests.SetJfrogHome()
+	require.NoError(t, err)
+	defer cleanUpJfrogHome()
+	require.NoError(t, config.SaveServersConf([]*config.ServerDetails{
+		{ServerId: "first", Url: "https://first.jfrog.io/", IsDefault: true},
+		{ServerId: "second", Url: "https://second.jfrog.io/"},
+	}))
+
+	assert.NoError(t, renameServer("first", "renamed"))
+	assert.NoError(t, cloneServer("renamed", "cloned"))
+	servers, err := config.GetAllServersConfigs()
+	require.NoError(t, err)
+	require.Len(t, servers, 3)
+	assert.Equal(t, "renamed", servers[0].ServerId)
+	assert.True(t, servers[0].IsDefault)
+	assert.Equal(t, "cloned", servers[2].ServerId)
+	assert.Equal(t, "https://first.jfrog.io/", servers[2].Url)
+	assert.False(t, servers[2].IsDefault)
+
+	// The source server must exist, and the new server ID must be available.
+	assert.ErrorContains(t, renameServer("first", "third"), "doesn't exist")
+	assert.ErrorContains(t, renameServer("renamed", "second"), "already exists")
+	assert.ErrorContains(t, cloneServer("second", "cloned"), "already exists")
+	assert.Error(t, cloneServer("second", "use"))
+}
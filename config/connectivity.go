@@ -0,0 +1,197 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	plManager "github.com/jfrog/jfrog-cli-core/v2/pipelines/manager"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	xrayUtils "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	"github.com/jfrog/jfrog-cli/utils/accesstoken"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	checkPassed  = "ok"
+	checkFailed  = "failed"
+	checkSkipped = "skipped"
+)
+
+// Pings a single JFrog service of a configured server.
+type serviceChecker struct {
+	name string
+	// Returns the service's URL, or an empty string if the service isn't configured.
+	getUrl func(*config.ServerDetails) string
+	ping   func(*config.ServerDetails) error
+}
+
+var serviceCheckers = []serviceChecker{
+	{
+		name:   "Artifactory",
+		getUrl: func(details *config.ServerDetails) string { return details.ArtifactoryUrl },
+		ping: func(details *config.ServerDetails) error {
+			servicesManager, err := rtUtils.CreateServiceManager(details, 0, 0, false)
+			if err != nil {
+				return err
+			}
+			_, err = servicesManager.Ping()
+			return err
+		},
+	},
+	{
+		name:   "Xray",
+		getUrl: func(details *config.ServerDetails) string { return details.XrayUrl },
+		ping: func(details *config.ServerDetails) error {
+			servicesManager, err := xrayUtils.CreateXrayServiceManager(details)
+			if err != nil {
+				return err
+			}
+			_, err = servicesManager.GetVersion()
+			return err
+		},
+	},
+	{
+		name:   "Distribution",
+		getUrl: func(details *config.ServerDetails) string { return details.DistributionUrl },
+		ping: func(details *config.ServerDetails) error {
+			servicesManager, err := rtUtils.CreateDistributionServiceManager(details, false)
+			if err != nil {
+				return err
+			}
+			_, err = servicesManager.GetDistributionVersion()
+			return err
+		},
+	},
+	{
+		name:   "Pipelines",
+		getUrl: func(details *config.ServerDetails) string { return details.PipelinesUrl },
+		ping: func(details *config.ServerDetails) error {
+			servicesManager, err := plManager.CreateServiceManager(details)
+			if err != nil {
+				return err
+			}
+			_, err = servicesManager.GetSystemInfo()
+			return err
+		},
+	},
+	{
+		name:   "Access",
+		getUrl: getAccessUrl,
+		ping: func(details *config.ServerDetails) error {
+			accessDetails := *details
+			accessDetails.AccessUrl = getAccessUrl(details)
+			servicesManager, err := rtUtils.CreateAccessServiceManager(&accessDetails, false)
+			if err != nil {
+				return err
+			}
+			_, err = servicesManager.Ping()
+			return err
+		},
+	},
+}
+
+// The Access URL isn't stored for servers configured by older versions of JFrog CLI, so it is derived from the platform URL.
+func getAccessUrl(details *config.ServerDetails) string {
+	if details.AccessUrl != "" {
+		return details.AccessUrl
+	}
+	if details.Url == "" {
+		return ""
+	}
+	return clientUtils.AddTrailingSlashIfNeeded(details.Url) + "access/"
+}
+
+type serverTestReport struct {
+	ServerId string `json:"serverId" yaml:"serverId"`
+	Success  bool   `json:"success" yaml:"success"`
+	// The access token's expiry time in RFC 3339 format, if the server is configured with a JWT access token which expires.
+	TokenExpiry  string               `json:"tokenExpiry,omitempty" yaml:"tokenExpiry,omitempty"`
+	TokenExpired bool                 `json:"tokenExpired,omitempty" yaml:"tokenExpired,omitempty"`
+	Services     []serviceCheckResult `json:"services" yaml:"services"`
+}
+
+type serviceCheckResult struct {
+	Service string `json:"service" yaml:"service"`
+	Url     string `json:"url,omitempty" yaml:"url,omitempty"`
+	Status  string `json:"status" yaml:"status"`
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type serviceCheckRow struct {
+	ServerId string `col-name:"Server ID"`
+	Service  string `col-name:"Service"`
+	Url      string `col-name:"URL"`
+	Status   string `col-name:"Status"`
+	Details  string `col-name:"Details"`
+}
+
+// Tests the connectivity and credentials of the provided servers, and prints a report in the requested format.
+// An error is returned if any of the configured services can't be reached, or if an access token expired.
+func runConfigTest(servers []*config.ServerDetails, format cliutils.OutputFormat) error {
+	var reports []serverTestReport
+	success := true
+	for _, server := range servers {
+		report := testServer(server, serviceCheckers, time.Now())
+		success = success && report.Success
+		reports = append(reports, report)
+	}
+	if err := printTestReports(reports, format); err != nil {
+		return err
+	}
+	if !success {
+		return errorutils.CheckErrorf("some of the configured servers failed the test")
+	}
+	return nil
+}
+
+func testServer(server *config.ServerDetails, checkers []serviceChecker, now time.Time) serverTestReport {
+	report := serverTestReport{ServerId: server.ServerId, Success: true}
+	if server.AccessToken != "" {
+		expiry, err := accesstoken.GetExpiry(server.AccessToken)
+		if err != nil {
+			log.Debug(fmt.Sprintf("Couldn't get the expiry of the access token of '%s': %s", server.ServerId, err.Error()))
+		} else if !expiry.IsZero() {
+			report.TokenExpiry = expiry.Format(time.RFC3339)
+			// Refreshable tokens are refreshed automatically, so an expired refreshable token is not a failure.
+			report.TokenExpired = expiry.Before(now) && server.RefreshToken == "" && server.ArtifactoryRefreshToken == ""
+			report.Success = !report.TokenExpired
+		}
+	}
+	for _, checker := range checkers {
+		result := serviceCheckResult{Service: checker.name, Url: checker.getUrl(server)}
+		if result.Url == "" {
+			result.Status = checkSkipped
+		} else if err := checker.ping(server); err != nil {
+			result.Status = checkFailed
+			result.Error = err.Error()
+			report.Success = false
+		} else {
+			result.Status = checkPassed
+		}
+		report.Services = append(report.Services, result)
+	}
+	return report
+}
+
+func printTestReports(reports []serverTestReport, format cliutils.OutputFormat) error {
+	var rows []serviceCheckRow
+	for _, report := range reports {
+		for _, result := range report.Services {
+			rows = append(rows, serviceCheckRow{ServerId: report.ServerId, Service: result.Service, Url: result.Url, Status: result.Status, Details: result.Error})
+		}
+		if report.TokenExpiry == "" {
+			continue
+		}
+		tokenRow := serviceCheckRow{ServerId: report.ServerId, Service: "Access Token", Status: checkPassed, Details: "Expires at " + report.TokenExpiry}
+		if report.TokenExpired {
+			tokenRow.Status = checkFailed
+			tokenRow.Details = "Expired at " + report.TokenExpiry
+		}
+		rows = append(rows, tokenRow)
+	}
+	return cliutils.PrintCommandOutput(cliutils.CommandOutput{Data: reports, Rows: rows, Title: "Servers Test", EmptyMessage: "No servers are configured"}, format)
+}
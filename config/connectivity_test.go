@@ -0,0 +1,64 @@
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+)
+
+var fakeCheckers = []serviceChecker{
+	{
+		name:   "Artifactory",
+		getUrl: func(details *config.ServerDetails) string { return details.ArtifactoryUrl },
+		ping:   func(*config.ServerDetails) error { return nil },
+	},
+	{
+		name:   "Xray",
+		getUrl: func(details *config.ServerDetails) string { return details.XrayUrl },
+		ping:   func(*config.ServerDetails) error { return errors.New("connection refused") },
+	},
+}
+
+func createJwt(expiry time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"user","exp":%d}`, expiry.Unix())))
+	return "header." + payload + ".signature"
+}
+
+func TestTestServer(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name            string
+		server          *config.ServerDetails
+		expectedSuccess bool
+		expectedStatus  []string
+		expectedExpired bool
+	}{
+		{"all services reachable", &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}, true, []string{checkPassed, checkSkipped}, false},
+		{"unreachable service", &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", XrayUrl: "https://acme.jfrog.io/xray/"}, false, []string{checkPassed, checkFailed}, false},
+		{"valid token", &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: createJwt(now.Add(time.Hour))}, true, []string{checkPassed, checkSkipped}, false},
+		{"expired token", &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: createJwt(now.Add(-time.Hour))}, false, []string{checkPassed, checkSkipped}, true},
+		{"expired refreshable token", &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: createJwt(now.Add(-time.Hour)), RefreshToken: "refresh"}, true, []string{checkPassed, checkSkipped}, false},
+		{"reference token", &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: "reference-token"}, true, []string{checkPassed, checkSkipped}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			report := testServer(test.server, fakeCheckers, now)
+			assert.Equal(t, test.expectedSuccess, report.Success)
+			assert.Equal(t, test.expectedExpired, report.TokenExpired)
+			for i, status := range test.expectedStatus {
+				assert.Equal(t, status, report.Services[i].Status)
+			}
+		})
+	}
+}
+
+func TestGetAccessUrl(t *testing.T) {
+	assert.Equal(t, "https://acme.jfrog.io/access/", getAccessUrl(&config.ServerDetails{Url: "https://acme.jfrog.io"}))
+	assert.Equal(t, "https://access.acme.io/", getAccessUrl(&config.ServerDetails{Url: "https://acme.jfrog.io/", AccessUrl: "https://access.acme.io/"}))
+	assert.Empty(t, getAccessUrl(&config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}))
+}
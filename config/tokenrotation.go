@@ -0,0 +1,215 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/lock"
+	"github.com/jfrog/jfrog-cli/utils/accesstoken"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/credentialhelper"
+	"github.com/jfrog/jfrog-client-go/access/services"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	defaultTokenExpiryWarningDays = 7
+	tokenExpiryCheckIndicator     = "Token_Expiry_Check_Indicator"
+)
+
+// Replaces the access token of a configured server with a new token, which has the same subject, scope, audience and lifetime.
+// The old token is revoked if requested, after the new token is saved.
+func rotateToken(serverId string, revoke bool) error {
+	details, err := credentialhelper.GetSpecificConfig(serverId, true, false)
+	if err != nil {
+		return err
+	}
+	if details.ServerId == "" {
+		return errorutils.CheckErrorf("no server is configured. Use the 'jf c add' command to configure a server")
+	}
+	if details.AccessToken == "" {
+		return errorutils.CheckErrorf("server '%s' isn't configured with an access token", details.ServerId)
+	}
+	oldPayload, err := accesstoken.DecodePayload(details.AccessToken)
+	if err != nil {
+		return errorutils.CheckErrorf("the access token of server '%s' can't be rotated: %s", details.ServerId, err.Error())
+	}
+	tokensService, err := accesstoken.NewTokensService(details)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Creating a new access token for server '%s'...", details.ServerId))
+	newToken, err := tokensService.GetServicesManager().CreateAccessToken(createRotationParams(oldPayload, details.RefreshToken != ""))
+	if err != nil {
+		return err
+	}
+	if err = saveRotatedToken(details, newToken.AccessToken, newToken.RefreshToken); err != nil {
+		return err
+	}
+	message := fmt.Sprintf("The access token of server '%s' was rotated.", details.ServerId)
+	if newToken.ExpiresIn != nil && *newToken.ExpiresIn > 0 {
+		message += fmt.Sprintf(" The new token expires at %s.", time.Now().Add(time.Duration(*newToken.ExpiresIn)*time.Second).Format(time.RFC3339))
+	}
+	log.Info(message)
+	if !revoke {
+		return nil
+	}
+	// The old token may no longer be usable for authentication, so the token is revoked using the new one.
+	details.AccessToken = newToken.AccessToken
+	if tokensService, err = accesstoken.NewTokensService(details); err != nil {
+		return err
+	}
+	if err = tokensService.RevokeToken(oldPayload.TokenId); err != nil {
+		return errors.Join(errors.New("the new access token was saved, but revoking the old token failed"), err)
+	}
+	log.Info("The old access token was revoked.")
+	return nil
+}
+
+func createRotationParams(oldPayload *accesstoken.Payload, refreshable bool) services.CreateTokenParams {
+	params := services.CreateTokenParams{Username: oldPayload.GetUsername()}
+	params.Scope = oldPayload.Scope
+	params.Audience = strings.Join(oldPayload.Audience, " ")
+	// Tokens which never expire are replaced by tokens which never expire, if allowed by the server.
+	params.ExpiresIn = clientUtils.Pointer(uint(oldPayload.GetLifetime().Seconds()))
+	params.Refreshable = clientUtils.Pointer(refreshable)
+	return params
+}
+
+// Writes the new tokens to the server's entry while the config is locked, to avoid overriding changes made concurrently by other processes.
+// The tokens of servers which use a credential helper are stored by the helper.
+func saveRotatedToken(details *config.ServerDetails, accessToken, refreshToken string) (err error) {
+	lockDirPath, err := coreutils.GetJfrogConfigLockDir()
+	if err != nil {
+		return
+	}
+	unlockFunc, err := lock.CreateLock(lockDirPath)
+	// Defer the lockFile.Unlock() function before throwing a possible error to avoid deadlock situations.
+	defer func() {
+		err = errors.Join(err, unlockFunc())
+	}()
+	if err != nil {
+		return
+	}
+
+	helperName, err := credentialhelper.GetHelperName(details.ServerId)
+	if err != nil {
+		return
+	}
+	if helperName != "" {
+		credentials := credentialhelper.GetCredentials(details)
+		credentials.AccessToken = accessToken
+		credentials.RefreshToken = refreshToken
+		return credentialhelper.StoreCredentials(helperName, details, credentials)
+	}
+
+	servers, err := config.GetAllServersConfigs()
+	if err != nil {
+		return
+	}
+	for _, server := range servers {
+		if server.ServerId == details.ServerId {
+			server.AccessToken = accessToken
+			server.RefreshToken = refreshToken
+			return config.SaveServersConf(servers)
+		}
+	}
+	return errorutils.CheckErrorf("Server ID '%s' doesn't exist.", details.ServerId)
+}
+
+// Warns about access tokens of configured servers which expire within the configured number of days.
+// Refreshable tokens are skipped, as they are refreshed automatically. The check runs at most once a day.
+func WarnExpiringTokens() {
+	warningDays, err := getTokenExpiryWarningDays()
+	if err != nil {
+		log.Debug(err.Error())
+		return
+	}
+	if warningDays == 0 {
+		return
+	}
+	shouldCheck, err := shouldCheckTokensExpiry()
+	if err != nil {
+		log.Debug("Failed checking the configured access tokens expiry:", err.Error())
+		return
+	}
+	if !shouldCheck {
+		return
+	}
+	servers, err := config.GetAllServersConfigs()
+	if err != nil {
+		log.Debug("Failed checking the configured access tokens expiry:", err.Error())
+		return
+	}
+	// The access tokens of servers which use a credential helper are held by the helper.
+	for _, server := range servers {
+		if err = credentialhelper.Resolve(server); err != nil {
+			log.Debug(fmt.Sprintf("Failed resolving the credentials of server '%s': %s", server.ServerId, err.Error()))
+		}
+	}
+	for _, warning := range getTokenExpiryWarnings(servers, time.Now(), warningDays) {
+		log.Warn(warning)
+	}
+}
+
+func getTokenExpiryWarningDays() (int, error) {
+	value := os.Getenv(cliutils.TokenExpiryWarningDays)
+	if value == "" {
+		return defaultTokenExpiryWarningDays, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("the value of %s must be a non-negative number of days, but got: '%s'", cliutils.TokenExpiryWarningDays, value)
+	}
+	return days, nil
+}
+
+func getTokenExpiryWarnings(servers []*config.ServerDetails, now time.Time, warningDays int) (warnings []string) {
+	deadline := now.AddDate(0, 0, warningDays)
+	for _, server := range servers {
+		if server.AccessToken == "" || server.RefreshToken != "" || server.ArtifactoryRefreshToken != "" {
+			continue
+		}
+		expiry, err := accesstoken.GetExpiry(server.AccessToken)
+		if err != nil || expiry.IsZero() || expiry.After(deadline) {
+			continue
+		}
+		if expiry.Before(now) {
+			warnings = append(warnings, fmt.Sprintf("The access token of server '%s' expired at %s. Run 'jf c rotate-token %s' or 'jf c edit %s' to replace it.",
+				server.ServerId, expiry.Format(time.RFC3339), server.ServerId, server.ServerId))
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("The access token of server '%s' expires at %s. Run 'jf c rotate-token %s' to rotate it.",
+			server.ServerId, expiry.Format(time.RFC3339), server.ServerId))
+	}
+	return
+}
+
+func shouldCheckTokensExpiry() (bool, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return false, err
+	}
+	indicatorFile := filepath.Join(homeDir, tokenExpiryCheckIndicator)
+	fileInfo, err := os.Stat(indicatorFile)
+	if err != nil && !os.IsNotExist(err) {
+		return false, errorutils.CheckError(err)
+	}
+	if err == nil && time.Since(fileInfo.ModTime()) < cliutils.TokenExpiryCheckInterval {
+		return false, nil
+	}
+	if err = os.MkdirAll(homeDir, 0700); err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	// Create the indicator file or update its modification time.
+	return true, errorutils.CheckError(os.WriteFile(indicatorFile, []byte{}, 0600))
+}
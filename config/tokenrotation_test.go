@@ -0,0 +1,119 @@
+package config
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	coreTests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/jfrog/jfrog-cli/utils/accesstoken"
+	"github.com/jfrog/jfrog-client-go/access/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createUserJwt(tokenId, audience string, issuedAt, expiry int64) string {
+	payload := fmt.Sprintf(`{"sub":"jfac@01abc/users/admin","scp":"applied-permissions/user","aud":%s,"jti":"%s","iat":%d,"exp":%d}`, audience, tokenId, issuedAt, expiry)
+	return "header." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
+
+func TestCreateRotationParams(t *testing.T) {
+	payload, err := accesstoken.DecodePayload(createUserJwt("old-id", `["jfrt@*","jfxr@*"]`, 1000, 1000+3600))
+	require.NoError(t, err)
+	params := createRotationParams(payload, true)
+	assert.Equal(t, "admin", params.Username)
+	assert.Equal(t, "applied-permissions/user", params.Scope)
+	assert.Equal(t, "jfrt@* jfxr@*", params.Audience)
+	assert.Equal(t, uint(3600), *params.ExpiresIn)
+	assert.True(t, *params.Refreshable)
+
+	// Tokens which never expire are replaced by tokens which never expire.
+	payload, err = accesstoken.DecodePayload(createUserJwt("old-id", `"*@*"`, 1000, 0))
+	require.NoError(t, err)
+	params = createRotationParams(payload, false)
+	assert.Equal(t, uint(0), *params.ExpiresIn)
+	assert.Equal(t, "*@*", params.Audience)
+}
+
+func TestRotateToken(t *testing.T) {
+	cleanUpJfrogHome, err := coreTests.SetJfrogHome()
+	require.NoError(t, err)
+	defer cleanUpJfrogHome()
+
+	now := time.Now().Unix()
+	oldToken := createUserJwt("old-id", `"*@*"`, now, now+3600)
+	newToken := createUserJwt("new-id", `"*@*"`, now, now+3600)
+	var requestedParams services.CreateTokenParams
+	var revokedTokenId, revokeAuthorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/access/api/v1/tokens":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&requestedParams))
+			_, err := fmt.Fprintf(w, `{"access_token":"%s","expires_in":3600}`, newToken)
+			assert.NoError(t, err)
+		case r.Method == http.MethodDelete && r.URL.Path == "/access/api/v1/tokens/old-id":
+			revokedTokenId = "old-id"
+			revokeAuthorization = r.Header.Get("Authorization")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	require.NoError(t, config.SaveServersConf([]*config.ServerDetails{
+		{ServerId: "my-server", Url: server.URL + "/", AccessToken: oldToken, IsDefault: true},
+		{ServerId: "other", Url: "https://other.jfrog.io/", AccessToken: "other-token"},
+	}))
+
+	assert.NoError(t, rotateToken("my-server", true))
+	assert.Equal(t, "admin", requestedParams.Username)
+	assert.Equal(t, "applied-permissions/user", requestedParams.Scope)
+	assert.Equal(t, uint(3600), *requestedParams.ExpiresIn)
+	assert.Equal(t, "old-id", revokedTokenId)
+	assert.Equal(t, "Bearer "+newToken, revokeAuthorization)
+
+	servers, err := config.GetAllServersConfigs()
+	require.NoError(t, err)
+	assert.Equal(t, newToken, servers[0].AccessToken)
+	assert.Equal(t, "other-token", servers[1].AccessToken)
+
+	// Reference tokens can't be rotated, because their scope can't be read.
+	assert.ErrorContains(t, rotateToken("other", false), "can't be rotated")
+}
+
+func TestGetTokenExpiryWarnings(t *testing.T) {
+	now := time.Now()
+	servers := []*config.ServerDetails{
+		{ServerId: "expiring", AccessToken: createUserJwt("1", `"*@*"`, now.Unix(), now.AddDate(0, 0, 3).Unix())},
+		{ServerId: "expired", AccessToken: createUserJwt("2", `"*@*"`, now.AddDate(0, 0, -2).Unix(), now.AddDate(0, 0, -1).Unix())},
+		{ServerId: "valid", AccessToken: createUserJwt("3", `"*@*"`, now.Unix(), now.AddDate(0, 0, 30).Unix())},
+		{ServerId: "refreshable", AccessToken: createUserJwt("4", `"*@*"`, now.Unix(), now.AddDate(0, 0, 1).Unix()), RefreshToken: "refresh"},
+		{ServerId: "non-expiring", AccessToken: createUserJwt("5", `"*@*"`, now.Unix(), 0)},
+		{ServerId: "reference", AccessToken: "reference-token"},
+	}
+	warnings := getTokenExpiryWarnings(servers, now, 7)
+	require.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0], "server 'expiring' expires at")
+	assert.Contains(t, warnings[1], "server 'expired' expired at")
+}
+
+func TestGetTokenExpiryWarningDays(t *testing.T) {
+	t.Setenv("JFROG_CLI_TOKEN_EXPIRY_WARNING_DAYS", "")
+	days, err := getTokenExpiryWarningDays()
+	assert.NoError(t, err)
+	assert.Equal(t, defaultTokenExpiryWarningDays, days)
+
+	t.Setenv("JFROG_CLI_TOKEN_EXPIRY_WARNING_DAYS", "0")
+	days, err = getTokenExpiryWarningDays()
+	assert.NoError(t, err)
+	assert.Zero(t, days)
+
+	t.Setenv("JFROG_CLI_TOKEN_EXPIRY_WARNING_DAYS", "-1")
+	_, err = getTokenExpiryWarningDays()
+	assert.Error(t, err)
+}
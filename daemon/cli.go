@@ -0,0 +1,218 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
+	"github.com/jfrog/jfrog-cli/docs/daemon/start"
+	"github.com/jfrog/jfrog-cli/docs/daemon/status"
+	"github.com/jfrog/jfrog-cli/docs/daemon/stop"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+const (
+	serveCommandName = "serve"
+	// The full name of the command which runs the daemon.
+	ServeCommandPath = cliutils.CmdDaemon + " " + serveCommandName
+	// How long to wait for the daemon to start or stop.
+	waitTimeout  = 10 * time.Second
+	pollInterval = 100 * time.Millisecond
+)
+
+// The daemon runs the commands with runCommand.
+func GetCommands(runCommand CommandRunner) []cli.Command {
+	return cliutils.GetSortedCommands(cli.CommandsByName{
+		{
+			Name:         "start",
+			Usage:        start.GetDescription(),
+			HelpName:     corecommon.CreateUsage("daemon start", start.GetDescription(), start.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       startCmd,
+		},
+		{
+			Name:         "stop",
+			Usage:        stop.GetDescription(),
+			HelpName:     corecommon.CreateUsage("daemon stop", stop.GetDescription(), stop.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       stopCmd,
+		},
+		{
+			Name:         "status",
+			Usage:        status.GetDescription(),
+			Flags:        cliutils.GetCommandFlags(cliutils.DaemonStatus),
+			HelpName:     corecommon.CreateUsage("daemon status", status.GetDescription(), status.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       statusCmd,
+		},
+		{
+			// Run by 'daemon start' in the background.
+			Name:   serveCommandName,
+			Hidden: true,
+			Action: func(c *cli.Context) error {
+				return serveCmd(c, runCommand)
+			},
+		},
+	})
+}
+
+func startCmd(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	if daemonStatus, err := GetStatus(); err == nil {
+		log.Info(fmt.Sprintf("The JFrog CLI daemon is already running (PID %d).", daemonStatus.Pid))
+		return nil
+	}
+	daemonDir, err := createDaemonDir()
+	if err != nil {
+		return err
+	}
+	logPath := filepath.Join(daemonDir, logFileName)
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		if closeErr := logFile.Close(); closeErr != nil {
+			log.Debug("Failed closing the daemon log file:", closeErr.Error())
+		}
+	}()
+	executable, err := os.Executable()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	daemonCmd := exec.Command(executable, cliutils.CmdDaemon, serveCommandName)
+	// The daemon runs in its own directory, so it doesn't hold the directory it was started from, or use its CLI overlay.
+	daemonCmd.Dir = daemonDir
+	daemonCmd.Stdout = logFile
+	daemonCmd.Stderr = logFile
+	if err = daemonCmd.Start(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	pid := daemonCmd.Process.Pid
+	if err = daemonCmd.Process.Release(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	for deadline := time.Now().Add(waitTimeout); time.Now().Before(deadline); time.Sleep(pollInterval) {
+		if _, err = GetStatus(); err == nil {
+			log.Info(fmt.Sprintf("The JFrog CLI daemon is running (PID %d). The following jf commands are run by it. Its log is written to %s", pid, logPath))
+			return nil
+		}
+	}
+	return errorutils.CheckErrorf("the JFrog CLI daemon didn't start within %s. See its log at %s", waitTimeout, logPath)
+}
+
+func stopCmd(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	daemonStatus, err := GetStatus()
+	if err != nil {
+		log.Info("The JFrog CLI daemon isn't running.")
+		return nil
+	}
+	if err = requestStop(); err != nil {
+		return err
+	}
+	// The socket is removed once the daemon stops listening.
+	for deadline := time.Now().Add(waitTimeout); time.Now().Before(deadline); time.Sleep(pollInterval) {
+		exists, err := fileutils.IsFileExists(daemonStatus.Socket, false)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			break
+		}
+	}
+	log.Info(fmt.Sprintf("The JFrog CLI daemon (PID %d) stopped accepting commands, and exits once its running command completes.", daemonStatus.Pid))
+	return nil
+}
+
+func statusCmd(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	format, err := cliutils.GetOutputFormat(c, cliutils.Table)
+	if err != nil {
+		return err
+	}
+	daemonStatus, err := GetStatus()
+	if err != nil {
+		log.Debug("The JFrog CLI daemon isn't reachable:", err.Error())
+		if format == cliutils.Table {
+			log.Output("The JFrog CLI daemon isn't running.")
+			return nil
+		}
+		// Structured output is an empty list, so scripts can check whether the daemon is running.
+		return cliutils.PrintCommandOutput(cliutils.CommandOutput{Data: []Status{}}, format)
+	}
+	return cliutils.PrintCommandOutput(cliutils.CommandOutput{Data: []Status{*daemonStatus}, Rows: []statusRow{newStatusRow(daemonStatus)}}, format)
+}
+
+// The app of a subcommand's context contains only its namespace's commands, while the daemon runs all the commands.
+func getRootApp(c *cli.Context) *cli.App {
+	for c.Parent() != nil {
+		c = c.Parent()
+	}
+	return c.App
+}
+
+// The table format supports string columns only.
+type statusRow struct {
+	Pid            string `col-name:"PID"`
+	Version        string `col-name:"Version"`
+	StartedAt      string `col-name:"Started At"`
+	ServedCommands string `col-name:"Served Commands"`
+	Socket         string `col-name:"Socket"`
+}
+
+func newStatusRow(daemonStatus *Status) statusRow {
+	return statusRow{
+		Pid:            strconv.Itoa(daemonStatus.Pid),
+		Version:        daemonStatus.Version,
+		StartedAt:      daemonStatus.StartedAt,
+		ServedCommands: strconv.Itoa(daemonStatus.ServedCommands),
+		Socket:         daemonStatus.Socket,
+	}
+}
+
+func serveCmd(c *cli.Context, runCommand CommandRunner) error {
+	if c.NArg() > 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	// The daemon outlives the terminal it was started from.
+	signal.Ignore(syscall.SIGHUP)
+	if _, err := createDaemonDir(); err != nil {
+		return err
+	}
+	socketPath, err := GetSocketPath()
+	if err != nil {
+		return err
+	}
+	server := NewServer(getRootApp(c), runCommand)
+	if err = server.Listen(socketPath); err != nil {
+		return err
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		log.Info("Stopping the daemon.")
+		if stopErr := server.Stop(); stopErr != nil {
+			log.Error(stopErr)
+		}
+	}()
+	log.Info(fmt.Sprintf("The JFrog CLI daemon (PID %d) is listening on %s", os.Getpid(), socketPath))
+	return server.Serve()
+}
@@ -0,0 +1,234 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/httpintercept"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Set to false to run commands in the current process, even if a daemon is running.
+const DaemonEnv = "JFROG_CLI_DAEMON"
+
+const (
+	dialTimeout = time.Second
+	// The size of the Stdin chunks sent to the daemon.
+	inputChunkSize = 32 * 1024
+)
+
+// Commands which are never forwarded to the daemon. The daemon commands manage the daemon itself,
+// self-update replaces the client's executable, and the others are interactive, or may prompt for passwords, which requires the client's terminal.
+var localCommands = []string{cliutils.CmdDaemon, cliutils.CmdConfig, "c", "login", cliutils.CmdCompletion, "intro", cliutils.SelfUpdate, cliutils.CmdShell}
+
+// Forwards the command to the daemon, if a daemon is running, and returns the command's exit code.
+// Returns false if the command wasn't forwarded, and should be run by the current process.
+func Forward(args []string) (exitCode int, forwarded bool) {
+	if !shouldForward(args) {
+		return 0, false
+	}
+	socketPath, err := GetSocketPath()
+	if err != nil {
+		return 0, false
+	}
+	if exists, err := fileutils.IsFileExists(socketPath, false); err != nil || !exists {
+		return 0, false
+	}
+	conn, err := net.DialTimeout("unix", socketPath, dialTimeout)
+	if err != nil {
+		log.Debug("The JFrog CLI daemon isn't reachable, running the command without it:", err.Error())
+		return 0, false
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	exitCode, forwarded, err = forward(conn, args)
+	if err != nil {
+		log.Error(err)
+	}
+	return
+}
+
+func shouldForward(args []string) bool {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") || strings.EqualFold(os.Getenv(DaemonEnv), "false") {
+		return false
+	}
+	// The HTTP requests are logged, traced, recorded or replayed by a proxy which the command's own process must start before its clients are created.
+	if httpintercept.IsRequired() {
+		return false
+	}
+	for _, command := range localCommands {
+		if args[0] == command {
+			return false
+		}
+	}
+	return true
+}
+
+func forward(conn net.Conn, args []string) (exitCode int, forwarded bool, err error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return 0, false, errorutils.CheckError(err)
+	}
+	encoder := json.NewEncoder(conn)
+	request := Request{Action: ExecAction, Version: cliutils.GetVersion(), Args: args, Env: os.Environ(), Dir: wd}
+	if err = encoder.Encode(request); err != nil {
+		log.Debug("Failed sending the command to the JFrog CLI daemon, running the command without it:", err.Error())
+		return 0, false, nil
+	}
+	decoder := json.NewDecoder(conn)
+	writer := &inputWriter{encoder: encoder}
+	signals := make(chan os.Signal, 1)
+	defer signal.Stop(signals)
+	for {
+		message := Message{}
+		if err = decoder.Decode(&message); err != nil {
+			if !forwarded {
+				log.Debug("The JFrog CLI daemon didn't accept the command, running the command without it:", err.Error())
+				return 0, false, nil
+			}
+			return 1, true, errorutils.CheckErrorf("the JFrog CLI daemon stopped while running the command: %s", err.Error())
+		}
+		switch {
+		case message.Started:
+			forwarded = true
+			go sendInput(writer)
+			// The command runs in the daemon's process, so the signals which would have interrupted it are forwarded to the daemon.
+			signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+			go forwardSignals(signals, writer)
+		case message.Result != nil:
+			if message.Result.Rejected != "" {
+				log.Debug("The JFrog CLI daemon rejected the command, running the command without it:", message.Result.Rejected)
+				return 0, false, nil
+			}
+			if writer.isInterrupted() {
+				log.Warn("The command was interrupted. The JFrog CLI daemon exits in order to interrupt it, so the following commands are run without it, until it's started again by 'jf daemon start'.")
+			}
+			return message.Result.ExitCode, true, nil
+		case message.Stream == Stdout:
+			_, err = os.Stdout.Write(message.Data)
+		case message.Stream == Stderr:
+			_, err = os.Stderr.Write(message.Data)
+		}
+		if err != nil {
+			log.Debug("Failed writing the command's output:", err.Error())
+		}
+	}
+}
+
+// Serializes the messages to the daemon, which are sent by the Stdin and the signals goroutines concurrently.
+type inputWriter struct {
+	encoder     *json.Encoder
+	mutex       sync.Mutex
+	interrupted bool
+}
+
+func (writer *inputWriter) write(input Input) error {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	if input.Signal != 0 {
+		writer.interrupted = true
+	}
+	return writer.encoder.Encode(input)
+}
+
+func (writer *inputWriter) isInterrupted() bool {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	return writer.interrupted
+}
+
+// Sends the Stdin to the daemon until it ends. The process exits once the command ends, even if the Stdin didn't end.
+func sendInput(writer *inputWriter) {
+	buffer := make([]byte, inputChunkSize)
+	for {
+		n, err := os.Stdin.Read(buffer)
+		if n > 0 {
+			if writeErr := writer.write(Input{Data: buffer[:n]}); writeErr != nil {
+				return
+			}
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				log.Debug("Failed reading the Stdin:", err.Error())
+			}
+			_ = writer.write(Input{Eof: true})
+			return
+		}
+	}
+}
+
+// Sends the received signals to the daemon, which interrupts the command and replies with its result.
+func forwardSignals(signals chan os.Signal, writer *inputWriter) {
+	for received := range signals {
+		number, ok := received.(syscall.Signal)
+		if !ok {
+			continue
+		}
+		if err := writer.write(Input{Signal: int(number)}); err != nil {
+			log.Debug("Failed sending the signal to the JFrog CLI daemon:", err.Error())
+			return
+		}
+	}
+}
+
+// Sends a status or a stop request to the daemon.
+func sendRequest(action Action) (result *Result, err error) {
+	socketPath, err := GetSocketPath()
+	if err != nil {
+		return
+	}
+	conn, err := net.DialTimeout("unix", socketPath, dialTimeout)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(conn.Close()))
+	}()
+	if err = json.NewEncoder(conn).Encode(Request{Action: action, Version: cliutils.GetVersion()}); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	message := Message{}
+	if err = json.NewDecoder(conn).Decode(&message); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if message.Result == nil {
+		return nil, errorutils.CheckErrorf("unexpected response from the JFrog CLI daemon")
+	}
+	if message.Result.Rejected != "" {
+		return nil, errorutils.CheckErrorf("the JFrog CLI daemon rejected the request: %s", message.Result.Rejected)
+	}
+	return message.Result, nil
+}
+
+// Returns the status of the running daemon, or an error if no daemon is reachable.
+func GetStatus() (*Status, error) {
+	result, err := sendRequest(StatusAction)
+	if err != nil {
+		return nil, err
+	}
+	if result.Status == nil {
+		return nil, errorutils.CheckErrorf("the JFrog CLI daemon didn't return its status")
+	}
+	return result.Status, nil
+}
+
+func requestStop() error {
+	_, err := sendRequest(StopAction)
+	if err != nil {
+		return fmt.Errorf("failed stopping the JFrog CLI daemon: %w", err)
+	}
+	return nil
+}
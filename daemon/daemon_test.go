@@ -0,0 +1,271 @@
+package daemon
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/general/batch"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/httprecord"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func TestShouldForward(t *testing.T) {
+	t.Setenv(DaemonEnv, "")
+	t.Setenv(httprecord.RecordEnv, "")
+	t.Setenv(httprecord.ReplayEnv, "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	assert.True(t, shouldForward([]string{"rt", "ping"}))
+	assert.False(t, shouldForward(nil))
+	assert.False(t, shouldForward([]string{"--version"}))
+	assert.False(t, shouldForward([]string{cliutils.CmdDaemon, "stop"}))
+	assert.False(t, shouldForward([]string{"c", "add"}))
+
+	t.Setenv(httprecord.RecordEnv, "cassette.json")
+	assert.False(t, shouldForward([]string{"rt", "ping"}))
+	t.Setenv(httprecord.RecordEnv, "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")
+	assert.False(t, shouldForward([]string{"rt", "ping"}))
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv(DaemonEnv, "false")
+	assert.False(t, shouldForward([]string{"rt", "ping"}))
+}
+
+func createTestApp() *cli.App {
+	app := cli.NewApp()
+	app.Name = "jf"
+	app.Version = "1.0.0"
+	app.Commands = []cli.Command{
+		{Name: "cat", Action: func(*cli.Context) error {
+			content, err := io.ReadAll(os.Stdin)
+			log.Output(strings.ToUpper(string(content)))
+			return err
+		}},
+		{Name: "context", Action: func(*cli.Context) error {
+			wd, err := os.Getwd()
+			log.Output(os.Getenv("TEST_DAEMON_VAR"), filepath.Base(wd))
+			return err
+		}},
+		{Name: "fail", Action: func(*cli.Context) error {
+			return errors.New("test failure")
+		}},
+	}
+	return app
+}
+
+type execResult struct {
+	stdout, stderr string
+	result         *Result
+}
+
+// Sends an exec request to the daemon, the way the client does.
+func sendExecRequest(t *testing.T, socketPath string, request Request, input string) execResult {
+	conn, err := net.Dial("unix", socketPath)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, conn.Close())
+	}()
+	encoder := json.NewEncoder(conn)
+	request.Action = ExecAction
+	if request.Env == nil {
+		request.Env = os.Environ()
+	}
+	if request.Dir == "" {
+		request.Dir, err = os.Getwd()
+		require.NoError(t, err)
+	}
+	require.NoError(t, encoder.Encode(request))
+	decoder := json.NewDecoder(conn)
+	var stdout, stderr bytes.Buffer
+	for {
+		message := Message{}
+		require.NoError(t, decoder.Decode(&message))
+		switch {
+		case message.Started:
+			// Commands which don't read their input may complete before it's sent.
+			_ = encoder.Encode(Input{Data: []byte(input), Eof: true})
+		case message.Result != nil:
+			return execResult{stdout: stdout.String(), stderr: stderr.String(), result: message.Result}
+		case message.Stream == Stdout:
+			stdout.Write(message.Data)
+		case message.Stream == Stderr:
+			stderr.Write(message.Data)
+		}
+	}
+}
+
+func TestServer(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv(coreutils.HomeDir, homeDir)
+	socketPath, err := GetSocketPath()
+	require.NoError(t, err)
+	_, err = createDaemonDir()
+	require.NoError(t, err)
+
+	server := NewServer(createTestApp(), func(app *cli.App, args []string) error {
+		_, err := batch.ExecCommand(app, args, false)
+		return err
+	})
+	require.NoError(t, server.Listen(socketPath))
+	serveDone := make(chan error)
+	go func() {
+		serveDone <- server.Serve()
+	}()
+	daemonWd, err := os.Getwd()
+	require.NoError(t, err)
+
+	result := sendExecRequest(t, socketPath, Request{Version: "1.0.0", Args: []string{"cat"}}, "hello")
+	assert.Equal(t, "HELLO\n", result.stdout)
+	assert.Equal(t, 0, result.result.ExitCode)
+
+	clientDir := filepath.Join(t.TempDir(), "client-dir")
+	require.NoError(t, os.Mkdir(clientDir, 0755))
+	result = sendExecRequest(t, socketPath, Request{Version: "1.0.0", Args: []string{"context"}, Env: append(os.Environ(), "TEST_DAEMON_VAR=client-value"), Dir: clientDir}, "")
+	assert.Equal(t, "client-value client-dir\n", result.stdout)
+	// The daemon's environment and working directory are restored.
+	_, isSet := os.LookupEnv("TEST_DAEMON_VAR")
+	assert.False(t, isSet)
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, daemonWd, wd)
+
+	result = sendExecRequest(t, socketPath, Request{Version: "1.0.0", Args: []string{"fail"}}, "")
+	assert.Equal(t, 1, result.result.ExitCode)
+	assert.Contains(t, result.stderr, "test failure")
+
+	result = sendExecRequest(t, socketPath, Request{Version: "0.9.0", Args: []string{"cat"}}, "")
+	assert.Contains(t, result.result.Rejected, "differs from the daemon's version")
+	result = sendExecRequest(t, socketPath, Request{Version: "1.0.0", Args: []string{"new-plugin"}}, "")
+	assert.Contains(t, result.result.Rejected, "doesn't know the command")
+
+	status, err := GetStatus()
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), status.Pid)
+	assert.Equal(t, 3, status.ServedCommands)
+
+	require.NoError(t, requestStop())
+	require.NoError(t, <-serveDone)
+	_, err = os.Stat(socketPath)
+	assert.True(t, os.IsNotExist(err))
+	_, err = GetStatus()
+	assert.Error(t, err)
+}
+
+func TestServerRejections(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	t.Setenv("HTTPS_PROXY", "")
+	socketPath, err := GetSocketPath()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(socketPath), 0755))
+	require.NoError(t, os.Chmod(filepath.Dir(socketPath), 0755))
+	assert.ErrorContains(t, NewServer(createTestApp(), nil).Listen(socketPath), "must be accessible by the user only")
+	_, err = createDaemonDir()
+	require.NoError(t, err)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	app := createTestApp()
+	app.Commands = append(app.Commands, cli.Command{Name: "block", Action: func(*cli.Context) error {
+		close(started)
+		<-release
+		return nil
+	}})
+	server := NewServer(app, func(app *cli.App, args []string) error {
+		_, err := batch.ExecCommand(app, args, false)
+		return err
+	})
+	require.NoError(t, server.Listen(socketPath))
+	serveDone := make(chan error)
+	go func() {
+		serveDone <- server.Serve()
+	}()
+
+	result := sendExecRequest(t, socketPath, Request{Version: "1.0.0", Args: []string{"cat"}, Env: append(os.Environ(), "HTTPS_PROXY=http://proxy:8080")}, "")
+	assert.Contains(t, result.result.Rejected, "proxy environment variables differ")
+
+	blockDone := make(chan execResult)
+	go func() {
+		blockDone <- sendExecRequest(t, socketPath, Request{Version: "1.0.0", Args: []string{"block"}}, "")
+	}()
+	<-started
+	// Clients don't wait for the running command. They're rejected, and run their commands by themselves.
+	result = sendExecRequest(t, socketPath, Request{Version: "1.0.0", Args: []string{"cat"}}, "")
+	assert.Contains(t, result.result.Rejected, "running another command")
+	close(release)
+	assert.Equal(t, 0, (<-blockDone).result.ExitCode)
+
+	require.NoError(t, server.Stop())
+	require.NoError(t, <-serveDone)
+}
+
+func TestInterruptedCommand(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	socketPath, err := GetSocketPath()
+	require.NoError(t, err)
+	_, err = createDaemonDir()
+	require.NoError(t, err)
+	exitCodes := make(chan int, 1)
+	previousExitProcess := exitProcess
+	exitProcess = func(code int) {
+		exitCodes <- code
+	}
+	defer func() {
+		exitProcess = previousExitProcess
+	}()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	app := createTestApp()
+	app.Commands = append(app.Commands, cli.Command{Name: "block", Action: func(*cli.Context) error {
+		close(started)
+		<-release
+		return nil
+	}})
+	server := NewServer(app, func(app *cli.App, args []string) error {
+		_, err := batch.ExecCommand(app, args, false)
+		return err
+	})
+	require.NoError(t, server.Listen(socketPath))
+	serveDone := make(chan error)
+	go func() {
+		serveDone <- server.Serve()
+	}()
+
+	conn, err := net.Dial("unix", socketPath)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, conn.Close())
+	}()
+	encoder, decoder := json.NewEncoder(conn), json.NewDecoder(conn)
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, encoder.Encode(Request{Action: ExecAction, Version: "1.0.0", Args: []string{"block"}, Env: os.Environ(), Dir: wd}))
+	message := Message{}
+	require.NoError(t, decoder.Decode(&message))
+	require.True(t, message.Started)
+	<-started
+
+	// The signal is received after the client's Stdin ended.
+	require.NoError(t, encoder.Encode(Input{Eof: true}))
+	require.NoError(t, encoder.Encode(Input{Signal: 2}))
+	for message.Result == nil {
+		require.NoError(t, decoder.Decode(&message))
+	}
+	assert.Equal(t, 130, message.Result.ExitCode)
+	assert.Equal(t, 130, <-exitCodes)
+	// The daemon stops accepting commands before it exits.
+	close(release)
+	require.NoError(t, <-serveDone)
+	_, err = os.Stat(socketPath)
+	assert.True(t, os.IsNotExist(err))
+}
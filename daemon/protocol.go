@@ -0,0 +1,106 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The daemon and its clients exchange JSON messages over a UNIX socket.
+// The client sends a Request, and for exec requests it then sends its Stdin, and the interrupt signals it receives, as Input messages.
+// The daemon replies with Output messages, and ends with a Result message.
+
+const (
+	daemonDirName  = "daemon"
+	socketFileName = "jf.sock"
+	logFileName    = "daemon.log"
+)
+
+type Action string
+
+const (
+	ExecAction   Action = "exec"
+	StatusAction Action = "status"
+	StopAction   Action = "stop"
+)
+
+type Request struct {
+	Action Action `json:"action"`
+	// The version of the client. Commands are executed only if it's the daemon's version.
+	Version string `json:"version"`
+	// The command's arguments, without the executable name.
+	Args []string `json:"args,omitempty"`
+	// The client's environment variables, in the form of 'key=value'.
+	Env []string `json:"env,omitempty"`
+	// The client's working directory.
+	Dir string `json:"dir,omitempty"`
+}
+
+type Input struct {
+	Data []byte `json:"data,omitempty"`
+	Eof  bool   `json:"eof,omitempty"`
+	// The number of the interrupt signal which the client received, such as SIGINT on Ctrl+C. The daemon interrupts the running command.
+	Signal int `json:"signal,omitempty"`
+}
+
+type Stream string
+
+const (
+	Stdout Stream = "stdout"
+	Stderr Stream = "stderr"
+)
+
+// Every message from the daemon is either the start of the command, output of the command, or the request's result.
+type Message struct {
+	// Sent once the command starts running. The client starts sending its Stdin only then, so it doesn't consume input of rejected commands.
+	Started bool    `json:"started,omitempty"`
+	Stream  Stream  `json:"stream,omitempty"`
+	Data    []byte  `json:"data,omitempty"`
+	Result  *Result `json:"result,omitempty"`
+}
+
+type Result struct {
+	ExitCode int `json:"exitCode"`
+	// The reason the request wasn't served. The client runs the command by itself in this case.
+	Rejected string  `json:"rejected,omitempty"`
+	Status   *Status `json:"status,omitempty"`
+}
+
+type Status struct {
+	Pid            int    `json:"pid"`
+	Version        string `json:"version"`
+	StartedAt      string `json:"startedAt"`
+	ServedCommands int    `json:"servedCommands"`
+	Socket         string `json:"socket"`
+}
+
+// The daemon's files are kept in a directory which only the user can access,
+// since anyone who can connect to the socket can run commands with the user's configuration.
+func getDaemonDir() (string, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, daemonDirName), nil
+}
+
+func GetSocketPath() (string, error) {
+	daemonDir, err := getDaemonDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(daemonDir, socketFileName), nil
+}
+
+func createDaemonDir() (string, error) {
+	daemonDir, err := getDaemonDir()
+	if err != nil {
+		return "", err
+	}
+	if err = os.MkdirAll(daemonDir, 0700); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return daemonDir, errorutils.CheckError(os.Chmod(daemonDir, 0700))
+}
@@ -0,0 +1,356 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	corelog "github.com/jfrog/jfrog-cli-core/v2/utils/log"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+	"golang.org/x/exp/slices"
+)
+
+const outputChunkSize = 32 * 1024
+
+// Exits the daemon's process when a command is interrupted. Overridden by tests.
+var exitProcess = os.Exit
+
+// The HTTP clients of jfrog-client-go read the proxy environment variables once per process,
+// so commands are run only for clients with the same proxy settings as the daemon.
+var proxyEnvVars = []string{"HTTP_PROXY", "http_proxy", "HTTPS_PROXY", "https_proxy", "NO_PROXY", "no_proxy"}
+
+// Runs a command in the current process.
+// The daemon sets the client's environment variables, working directory, standard streams and a default logger before running it.
+type CommandRunner func(app *cli.App, args []string) error
+
+// Serves the commands forwarded by the clients. The app was created once, so the clients don't pay for its startup.
+type Server struct {
+	app        *cli.App
+	runCommand CommandRunner
+	socketPath string
+	listener   net.Listener
+	startedAt  time.Time
+	served     int64
+	proxyEnv   string
+	// Commands change the process's environment variables, working directory and standard streams, so they're run one at a time.
+	// Commands sent while another command is running are rejected, so that their clients run them without waiting.
+	execMutex sync.Mutex
+	stopOnce  sync.Once
+}
+
+func NewServer(app *cli.App, runCommand CommandRunner) *Server {
+	// Makes the HTTP clients read the daemon's proxy settings, before any command sets its client's environment variables.
+	_, _ = http.ProxyFromEnvironment(&http.Request{URL: &url.URL{Scheme: "https", Host: "localhost"}})
+	return &Server{app: app, runCommand: runCommand, proxyEnv: getProxyEnv(os.Environ())}
+}
+
+// Listens on the socket, which is accessible by the user only.
+// The socket is created in a directory which only the user can access, so it can't be connected to before its permissions are set.
+func (server *Server) Listen(socketPath string) (err error) {
+	socketDir, err := os.Stat(filepath.Dir(socketPath))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if !coreutils.IsWindows() && socketDir.Mode().Perm()&0077 != 0 {
+		return errorutils.CheckErrorf("the directory of the socket %s must be accessible by the user only", socketPath)
+	}
+	if _, err = os.Stat(socketPath); err == nil {
+		// A socket without a daemon is left behind by a daemon which was killed.
+		if conn, dialErr := net.DialTimeout("unix", socketPath, time.Second); dialErr == nil {
+			return errors.Join(errorutils.CheckErrorf("a daemon is already listening on %s", socketPath), conn.Close())
+		}
+		if err = os.Remove(socketPath); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	if server.listener, err = net.Listen("unix", socketPath); err != nil {
+		return errorutils.CheckError(err)
+	}
+	server.socketPath = socketPath
+	server.startedAt = time.Now()
+	return errorutils.CheckError(os.Chmod(socketPath, 0600))
+}
+
+// Serves the clients until the server is stopped. A command which is running when the server is stopped is completed.
+func (server *Server) Serve() error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := server.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return errorutils.CheckError(err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			server.handle(conn)
+		}()
+	}
+}
+
+// Stops accepting clients. The socket is removed when the listener is closed.
+func (server *Server) Stop() (err error) {
+	server.stopOnce.Do(func() {
+		err = errorutils.CheckError(server.listener.Close())
+	})
+	return
+}
+
+func (server *Server) GetStatus() *Status {
+	return &Status{
+		Pid:            os.Getpid(),
+		Version:        server.app.Version,
+		StartedAt:      server.startedAt.Format(time.RFC3339),
+		ServedCommands: int(atomic.LoadInt64(&server.served)),
+		Socket:         server.socketPath,
+	}
+}
+
+// Serializes the messages to the client, which are written by the output streams concurrently.
+type messageWriter struct {
+	encoder *json.Encoder
+	mutex   sync.Mutex
+}
+
+func (writer *messageWriter) write(message Message) error {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	return writer.encoder.Encode(message)
+}
+
+func (server *Server) handle(conn net.Conn) {
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Debug("Failed closing the daemon client connection:", err.Error())
+		}
+	}()
+	decoder := json.NewDecoder(conn)
+	writer := &messageWriter{encoder: json.NewEncoder(conn)}
+	request := Request{}
+	if err := decoder.Decode(&request); err != nil {
+		log.Debug("Failed reading the daemon client request:", err.Error())
+		return
+	}
+	result := &Result{}
+	switch request.Action {
+	case StatusAction:
+		result.Status = server.GetStatus()
+	case StopAction:
+		log.Info("Stopping the daemon, as requested by a client.")
+		if err := server.Stop(); err != nil {
+			log.Error(err)
+		}
+	case ExecAction:
+		result = server.exec(request, decoder, writer)
+	default:
+		result.Rejected = fmt.Sprintf("unsupported action '%s'", request.Action)
+	}
+	if err := writer.write(Message{Result: result}); err != nil {
+		log.Debug("Failed sending the result to the daemon client:", err.Error())
+	}
+}
+
+func (server *Server) exec(request Request, decoder *json.Decoder, writer *messageWriter) *Result {
+	if request.Version != server.app.Version {
+		return &Result{Rejected: fmt.Sprintf("the client's version %s differs from the daemon's version %s", request.Version, server.app.Version)}
+	}
+	// Plugins installed after the daemon was started are unknown to it.
+	if len(request.Args) == 0 || server.app.Command(request.Args[0]) == nil {
+		return &Result{Rejected: fmt.Sprintf("the daemon doesn't know the command '%s'", strings.Join(request.Args, " "))}
+	}
+	if getProxyEnv(request.Env) != server.proxyEnv {
+		return &Result{Rejected: "the client's proxy environment variables differ from the daemon's"}
+	}
+	if !server.execMutex.TryLock() {
+		return &Result{Rejected: "the daemon is running another command"}
+	}
+	defer server.execMutex.Unlock()
+	atomic.AddInt64(&server.served, 1)
+	log.Debug("Running a command forwarded by a client:", strings.Join(request.Args, " "))
+	if err := writer.write(Message{Started: true}); err != nil {
+		log.Debug("Failed sending the command's start to the daemon client:", err.Error())
+		return &Result{ExitCode: coreutils.ExitCodeError.Code}
+	}
+	err := server.runInClientContext(request, decoder, writer)
+	return &Result{ExitCode: getExitCode(err)}
+}
+
+// Runs the command with the client's environment variables, working directory and standard streams, and restores the daemon's afterwards.
+// Failures are logged to the client's Stderr, like failures of commands which aren't forwarded.
+func (server *Server) runInClientContext(request Request, decoder *json.Decoder, writer *messageWriter) (err error) {
+	stdin, stdout, stderr, logger := os.Stdin, os.Stdout, os.Stderr, log.GetLogger()
+	daemonEnv := os.Environ()
+	daemonDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	streams, err := newClientStreams(decoder, writer, func(signal int) {
+		server.interrupt(signal, writer)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		os.Stdin, os.Stdout, os.Stderr = stdin, stdout, stderr
+		log.SetLogger(logger)
+		err = errors.Join(err, streams.close(), setEnv(daemonEnv), errorutils.CheckError(os.Chdir(daemonDir)))
+	}()
+	os.Stdin, os.Stdout, os.Stderr = streams.stdin, streams.stdout, streams.stderr
+	if err = setEnv(request.Env); err != nil {
+		return err
+	}
+	// The logger writes to the streams it was created with, and uses the client's log level.
+	corelog.SetDefaultLogger()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("the command panicked: %v", recovered)
+		}
+		if err != nil && len(err.Error()) > 0 {
+			log.Error(err)
+		}
+	}()
+	if err = os.Chdir(request.Dir); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return server.runCommand(server.app, request.Args)
+}
+
+// Interrupts the running command, when its client receives an interrupt signal.
+// The commands don't support cancellation, and can't be stopped without stopping the process running them, so the daemon exits,
+// like the client's process would have exited if it had run the command. The socket is removed first, so the following commands are run without the daemon.
+func (server *Server) interrupt(signal int, writer *messageWriter) {
+	exitCode := 128 + signal
+	if err := server.Stop(); err != nil {
+		log.Debug("Failed closing the daemon's socket:", err.Error())
+	}
+	if err := writer.write(Message{Result: &Result{ExitCode: exitCode}}); err != nil {
+		log.Debug("Failed sending the result to the daemon client:", err.Error())
+	}
+	exitProcess(exitCode)
+}
+
+func getExitCode(err error) int {
+	var cliError coreutils.CliError
+	if errors.As(err, &cliError) {
+		return cliError.ExitCode.Code
+	}
+	return coreutils.GetExitCode(err, 0, 0, false).Code
+}
+
+func getProxyEnv(env []string) string {
+	var proxyEnv []string
+	for _, variable := range env {
+		if key, _, found := strings.Cut(variable, "="); found && slices.Contains(proxyEnvVars, key) {
+			proxyEnv = append(proxyEnv, variable)
+		}
+	}
+	slices.Sort(proxyEnv)
+	return strings.Join(proxyEnv, "\n")
+}
+
+func setEnv(env []string) error {
+	os.Clearenv()
+	for _, variable := range env {
+		if key, value, found := strings.Cut(variable, "="); found && key != "" {
+			if err := os.Setenv(key, value); err != nil {
+				return errorutils.CheckError(err)
+			}
+		}
+	}
+	return nil
+}
+
+// The standard streams of a command, which are connected to the client.
+type clientStreams struct {
+	stdin, stdout, stderr *os.File
+	// The other ends of the pipes.
+	stdinWriter, stdoutReader, stderrReader *os.File
+	outputDone                              sync.WaitGroup
+}
+
+// onSignal - Called with the interrupt signals which the client forwards.
+func newClientStreams(decoder *json.Decoder, writer *messageWriter, onSignal func(signal int)) (streams *clientStreams, err error) {
+	streams = &clientStreams{}
+	if streams.stdin, streams.stdinWriter, err = os.Pipe(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if streams.stdoutReader, streams.stdout, err = os.Pipe(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if streams.stderrReader, streams.stderr, err = os.Pipe(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	go streams.receiveInput(decoder, onSignal)
+	streams.outputDone.Add(2)
+	go streams.sendOutput(streams.stdoutReader, Stdout, writer)
+	go streams.sendOutput(streams.stderrReader, Stderr, writer)
+	return streams, nil
+}
+
+// Writes the client's Stdin to the command's Stdin, until the client's Stdin ends, or the command ends.
+// The client's signals are received until the client disconnects, since they may be sent after its Stdin ended.
+func (streams *clientStreams) receiveInput(decoder *json.Decoder, onSignal func(signal int)) {
+	var closeStdin sync.Once
+	defer closeStdin.Do(func() {
+		_ = streams.stdinWriter.Close()
+	})
+	for {
+		input := Input{}
+		if err := decoder.Decode(&input); err != nil {
+			return
+		}
+		if input.Signal != 0 {
+			onSignal(input.Signal)
+			continue
+		}
+		if len(input.Data) > 0 {
+			// Input which the command doesn't read anymore is discarded.
+			_, _ = streams.stdinWriter.Write(input.Data)
+		}
+		if input.Eof {
+			closeStdin.Do(func() {
+				_ = streams.stdinWriter.Close()
+			})
+		}
+	}
+}
+
+func (streams *clientStreams) sendOutput(reader io.Reader, stream Stream, writer *messageWriter) {
+	defer streams.outputDone.Done()
+	buffer := make([]byte, outputChunkSize)
+	for {
+		n, err := reader.Read(buffer)
+		if n > 0 {
+			if writeErr := writer.write(Message{Stream: stream, Data: buffer[:n]}); writeErr != nil {
+				log.Debug("Failed sending the command's output to the daemon client:", writeErr.Error())
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Closes the command's streams, after sending all of its output to the client.
+func (streams *clientStreams) close() error {
+	err := errors.Join(errorutils.CheckError(streams.stdout.Close()), errorutils.CheckError(streams.stderr.Close()))
+	streams.outputDone.Wait()
+	// Closing the Stdin fails the pending writes of the client's input, if the command didn't read all of it.
+	return errors.Join(err, errorutils.CheckError(streams.stdin.Close()), errorutils.CheckError(streams.stdoutReader.Close()), errorutils.CheckError(streams.stderrReader.Close()))
+}
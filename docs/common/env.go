@@ -54,6 +54,31 @@ const (
 		Can be optionally used with the JFROG_CLI_PLUGINS_SERVER environment variable.
 		Determines the name of the local repository to use.`
 
+	JfrogCliPluginsArchitectures = `	JFROG_CLI_PLUGINS_ARCHITECTURES
+		Comma-separated list of additional plugin architectures, as <name>=<goos>/<goarch> pairs. For example: linux-loong64=linux/loong64.
+		The plugin is published for these architectures too, and installed from them on matching machines.`
+
+	JfrogCliPluginsSigningKey = `	JFROG_CLI_PLUGINS_SIGNING_KEY
+		Path to a PEM encoded ECDSA or Ed25519 private key.
+		If provided, the plugin's executables are signed, and the detached signatures are published next to them.`
+
+	JfrogCliPluginsPublicKey = `	JFROG_CLI_PLUGINS_PUBLIC_KEY
+		Path to a PEM encoded ECDSA or Ed25519 public key.
+		If provided, the signature of the plugin's executable is verified during installation.`
+
+	JfrogCliPluginsRequireSignature = `	JFROG_CLI_PLUGINS_REQUIRE_SIGNATURE
+		[Default: false]
+		Set to true to refuse installing plugins without a valid signature.
+		Requires the JFROG_CLI_PLUGINS_PUBLIC_KEY environment variable.`
+
+	JfrogCliPluginsSandbox = `	JFROG_CLI_PLUGINS_SANDBOX
+		[Default: false]
+		Set to true to run plugins isolated from JFrog CLI's config and credentials. Plugins run with an empty
+		JFrog CLI home directory, and without the JFROG_* and JF_* environment variables, except for the log level,
+		temp dir and build environment variables. Plugins which declare access token scopes in their signature get a short-lived access token with these scopes,
+		created for the default server. The token and the server's URL are passed to the plugin
+		using the JFROG_CLI_PLUGIN_ACCESS_TOKEN and JFROG_CLI_PLUGIN_URL environment variables.`
+
 	JfrogCliTransitiveDownload = `	JFROG_CLI_TRANSITIVE_DOWNLOAD
 		[Default: false]
 		Set this option to true to include remote repositories in artifact searches when using the 'rt download' command. 
@@ -66,7 +91,18 @@ const (
 		This environment variable's value format should be <server ID configured by the 'jf c add' command>/<repo name>.
 
 		The repository should proxy https://releases.jfrog.io.
-		This environment variable is used by the 'jf mvn' and 'jf gradle' commands, and also by the 'jf audit' command, when used for maven or gradle projects.`
+		This environment variable is used by the 'jf mvn' and 'jf gradle' commands, and also by the 'jf audit' command, when used for maven or gradle projects.
+		It is also used by the 'jf self-update' command, which otherwise downloads JFrog CLI from GitHub.`
+
+	JfrogCliSelfUpdatePublicKey = `	JFROG_CLI_SELF_UPDATE_PUBLIC_KEY
+		Path to a PEM encoded ECDSA or Ed25519 public key, for organizations which publish their own JFrog CLI builds to a remote repository.
+		If provided, the 'jf self-update' command verifies the signature of the executable downloaded through the JFROG_CLI_RELEASES_REPO remote repository with it, instead of with the release public key embedded in JFrog CLI.
+		Executables downloaded from https://releases.jfrog.io are always verified with the embedded release public key, so this variable can't be used with them.`
+
+	JfrogCliCompletionOffline = `	JFROG_CLI_COMPLETION_OFFLINE
+		[Default: false]
+		Set to true to complete repository paths only from the local completion cache, without sending requests to Artifactory.
+		The configured server IDs and the local build names are completed either way.`
 
 	JfrogCliDependenciesDir = `	JFROG_CLI_DEPENDENCIES_DIR
 		[Default: $JFROG_CLI_HOME_DIR/dependencies]
@@ -95,6 +131,41 @@ const (
 		[Default: false]
 		Set to true if you'd like to avoid checking the latest available JFrog CLI version and printing warning when it newer than the current one. `
 
+	JfrogCliTokenExpiryWarningDays = `	JFROG_CLI_TOKEN_EXPIRY_WARNING_DAYS
+		[Default: 7]
+		Number of days before the access token of a configured server expires, from which JFrog CLI warns about it.
+		Refreshable tokens are refreshed automatically and aren't checked. Set to 0 to disable the warning.`
+
+	JfrogCliOutputFormat = `	JFROG_CLI_OUTPUT_FORMAT
+		The output format of commands which support structured output, unless set by the --format option. These commands are listed in the usage of the global --format option (jf --help).
+		Acceptable values are: json, yaml, table and csv.`
+
+	JfrogCliLogFormat = `	JFROG_CLI_LOG_FORMAT
+		[Default: text]
+		Set to json to log each message as a JSON object, with the level, timestamp, command, server ID and trace ID.
+		At the DEBUG log level, the HTTP requests the command sends are logged as events with the method, URL, status, latency and retries fields.
+		The requests are logged by a local proxy which the command's HTTP clients send their requests through. Its ephemeral CA certificate is written to the certificates directory of JFrog CLI while the command runs.
+		Requests to loopback addresses, and requests of the package managers and other tools the command runs, aren't sent through the proxy and aren't logged.
+		On macOS and Windows, the HTTP clients which don't use the certificates directory, such as the clients of the Mission Control commands, don't trust the proxy. Their first request to a host fails, and their next requests to it aren't logged.`
+
+	OtelExporterOtlpEndpoint = `	OTEL_EXPORTER_OTLP_ENDPOINT
+		If set, the command's trace is exported to this OpenTelemetry collector endpoint over OTLP/HTTP.
+		The trace includes spans of the command's phases, such as the upload, and of the HTTP requests the command sends, which are children of the phase they were sent in.
+		The requests are traced by the local proxy described in JFROG_CLI_LOG_FORMAT, so the requests it doesn't log aren't traced either. The upload and download threads have no spans of their own.
+		The other standard OTEL_* environment variables, such as OTEL_SERVICE_NAME and OTEL_EXPORTER_OTLP_HEADERS, are also supported.`
+
+	JfrogCliHttpRecord = `	JFROG_CLI_HTTP_RECORD
+		If set, the HTTP requests the command sends and their responses are recorded to this file, with tokens and passwords redacted.
+		Bodies larger than 10 MB are truncated. The requests are recorded by the local proxy described in JFROG_CLI_LOG_FORMAT, so the requests it doesn't log aren't recorded either.`
+
+	JfrogCliHttpReplay = `	JFROG_CLI_HTTP_REPLAY
+		If set, the HTTP requests the command sends are answered from the responses recorded in this file, without contacting the servers.
+		The requests are answered by the local proxy described in JFROG_CLI_LOG_FORMAT, so the requests it doesn't log are sent to the servers.`
+
+	JfrogCliDaemon = `	JFROG_CLI_DAEMON
+		[Default: true]
+		If a daemon was started with 'jf daemon start', commands are run by it. Set to false to run the commands without it.`
+
 	JfrogCliCommandSummaryOutputDirectory = `  JFROG_CLI_COMMAND_SUMMARY_OUTPUT_DIR
 		Defines the directory path where the command summaries data is stored.
 		Every command will have its own individual directory within this base directory.
@@ -128,6 +199,7 @@ func GetGlobalEnvVars() string {
 		Ci,
 		JfrogCliPluginsServer,
 		JfrogCliPluginsRepo,
+		JfrogCliPluginsSandbox,
 		JfrogCliTransitiveDownload,
 		JfrogCliReleasesRepo,
 		JfrogCliDependenciesDir,
@@ -138,6 +210,13 @@ func GetGlobalEnvVars() string {
 		JfrogCliFailNoOp,
 		JfrogCliEncryptionKey,
 		JfrogCliAvoidNewVersionWarning,
+		JfrogCliTokenExpiryWarningDays,
+		JfrogCliOutputFormat,
+		JfrogCliLogFormat,
+		OtelExporterOtlpEndpoint,
+		JfrogCliHttpRecord,
+		JfrogCliHttpReplay,
+		JfrogCliDaemon,
 		JfrogCliCommandSummaryOutputDirectory)
 }
 
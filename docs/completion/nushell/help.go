@@ -0,0 +1,7 @@
+package nushell
+
+var Usage = []string{"completion nushell"}
+
+func GetDescription() string {
+	return "Generate Nushell completion script."
+}
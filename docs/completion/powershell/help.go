@@ -0,0 +1,7 @@
+package powershell
+
+var Usage = []string{"completion powershell"}
+
+func GetDescription() string {
+	return "Generate PowerShell completion script."
+}
@@ -0,0 +1,15 @@
+package clone
+
+var Usage = []string{"config clone <server ID> <new server ID>"}
+
+func GetDescription() string {
+	return "Create a copy of an existing server configuration under a new server ID."
+}
+
+func GetArguments() string {
+	return `	server ID
+		The ID of the server configuration to copy.
+
+	new server ID
+		The ID of the new server configuration.`
+}
@@ -0,0 +1,15 @@
+package rename
+
+var Usage = []string{"config rename <server ID> <new server ID>"}
+
+func GetDescription() string {
+	return "Rename an existing server configuration."
+}
+
+func GetArguments() string {
+	return `	server ID
+		The ID of the server configuration to rename.
+
+	new server ID
+		The new ID of the server configuration.`
+}
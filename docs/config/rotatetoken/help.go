@@ -0,0 +1,12 @@
+package rotatetoken
+
+var Usage = []string{"config rotate-token [command options] [server ID]"}
+
+func GetDescription() string {
+	return "Replace the access token of a configured server with a new token, which has the same scope, audience and lifetime."
+}
+
+func GetArguments() string {
+	return `	server ID
+		The ID of the server configuration. If not specified, the default server is used.`
+}
@@ -1,7 +1,7 @@
 package show
 
-var Usage = []string{"config show <server ID>"}
+var Usage = []string{"config show [command options] <server ID>"}
 
 func GetDescription() string {
-	return `Shows the stored configuration. In case this argument is followed by a configured server ID, then only this server's configurations is shown.`
+	return `Shows the stored configuration. In case this argument is followed by a configured server ID, then only this server's configurations is shown. Use the --effective option to also show the settings of the per-project '.jfrog/cli.yaml' overlay, and where each value came from.`
 }
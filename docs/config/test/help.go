@@ -0,0 +1,12 @@
+package test
+
+var Usage = []string{"config test [command options] [server ID]"}
+
+func GetDescription() string {
+	return "Test the connectivity and credentials of a configured server, by pinging each of its configured services and checking its access token's expiry. Fails if any of the checks fail."
+}
+
+func GetArguments() string {
+	return `	server ID
+		The ID of the server configuration to test. If not specified, the default server is tested, unless the --all option is used.`
+}
@@ -0,0 +1,7 @@
+package start
+
+var Usage = []string{"daemon start"}
+
+func GetDescription() string {
+	return "Start a background daemon, which runs the commands of the following jf invocations, so they don't pay for the CLI's startup. The daemon runs one command at a time. It keeps open the connections of the HTTP clients created by JFrog CLI itself, such as the clients of the plugins, self-update and login commands, between commands. The clients of jfrog-cli-core, which run most commands, such as the Artifactory commands, open new connections for each command. While it's busy, if the proxy environment variables differ from the daemon's, or if the command's HTTP requests are logged at the DEBUG level in JSON format, traced, recorded or replayed, the command is run without it. Ctrl+C interrupts the command by stopping the daemon, so the following commands are run without it until it's started again."
+}
@@ -0,0 +1,7 @@
+package status
+
+var Usage = []string{"daemon status"}
+
+func GetDescription() string {
+	return "Show whether the background daemon is running, and how many commands it ran."
+}
@@ -0,0 +1,7 @@
+package stop
+
+var Usage = []string{"daemon stop"}
+
+func GetDescription() string {
+	return "Stop the background daemon, after the command it's running completes. The following jf invocations run their commands by themselves."
+}
@@ -1,7 +1,9 @@
 package login
 
-var Usage = []string{"login"}
+var Usage = []string{"login", "login --oidc-provider=<provider name> --url=<platform URL> [command options]"}
 
 func GetDescription() string {
-	return "Log in to a JFrog Platform via your web browser. Available for Artifactory 7.64.0 and above"
+	return "Log in to a JFrog Platform via your web browser. Available for Artifactory 7.64.0 and above. " +
+		"On CI servers, use --oidc-provider to exchange the workload's OIDC ID token for a short-lived access token, " +
+		"which is saved in an ephemeral server configuration, removed once the token expires, or by the 'jf logout' command."
 }
@@ -0,0 +1,8 @@
+package logout
+
+var Usage = []string{"logout [command options]"}
+
+func GetDescription() string {
+	return "Remove the ephemeral server configurations created by 'jf login --oidc-provider', before their tokens expire. " +
+		"The previous default server is restored."
+}
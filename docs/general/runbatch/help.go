@@ -0,0 +1,16 @@
+package runbatch
+
+var Usage = []string{"run-batch <script path>"}
+
+func GetDescription() string {
+	return `Runs the jf commands listed in a YAML script, one after the other in a single process, and prints a summary of their results.
+The steps don't pay for the CLI's startup. Only the HTTP clients created by JFrog CLI itself, such as the clients of the plugins, self-update and login commands, keep their connections open between the steps.
+The clients of jfrog-cli-core, which run most commands, such as the Artifactory commands, open new connections in each step.`
+}
+
+func GetArguments() string {
+	return `	script path
+		Path to a YAML file with the steps to run. Each step has a 'run' command, such as 'rt search "libs/*.jar"', and optionally a 'name',
+		an 'output' variable to store the command's output in, and 'continue-on-error'. The script may also set a 'server-id' and 'vars' for all the steps.
+		Variables are used in the steps as follows: ${key1}. The fields of an output in JSON format are accessed as follows: ${found.0.path}.`
+}
@@ -0,0 +1,7 @@
+package selfupdate
+
+var Usage = []string{"self-update [command options]"}
+
+func GetDescription() string {
+	return `Updates JFrog CLI to the latest version in the release channel, or to the requested version, from https://releases.jfrog.io. The new executable's sha256 checksum and signature are verified before it replaces the running executable, and unsigned executables are refused.`
+}
@@ -0,0 +1,8 @@
+package shell
+
+var Usage = []string{"shell"}
+
+func GetDescription() string {
+	return `Starts an interactive session, which runs jf commands with tab completion and a persistent history, without the values of secret flags such as --password, credential headers and the credentials in URLs. Failed commands don't end the session, and Ctrl+C interrupts the running command only.
+Run 'use server|project|build <value>' to set the context of the following commands, 'context' to show it, and 'exit' to end the session.`
+}
@@ -0,0 +1,12 @@
+package tokeninspect
+
+var Usage = []string{"ati", "ati <access token>"}
+
+func GetDescription() string {
+	return `Decodes an access token offline, and shows its subject, scopes and expiry. The token's signature isn't verified.`
+}
+
+func GetArguments() string {
+	return `	access token
+		The access token to inspect. If not specified, the token is read from the standard input.`
+}
@@ -0,0 +1,7 @@
+package tokenlist
+
+var Usage = []string{"atl"}
+
+func GetDescription() string {
+	return `Lists access tokens. Administrators get the tokens of all the users, while other users get their own tokens only.`
+}
@@ -0,0 +1,12 @@
+package tokenrevoke
+
+var Usage = []string{"atr <token ID | access token>"}
+
+func GetDescription() string {
+	return `Revokes an access token by its ID or by its value.`
+}
+
+func GetArguments() string {
+	return `	token ID | access token
+		The ID of the token to revoke, or the token itself. Both access tokens and reference tokens are supported.`
+}
@@ -0,0 +1,22 @@
+package export
+
+import "github.com/jfrog/jfrog-cli/docs/common"
+
+var Usage = []string{"plugin export <plugin name and version> [target path]"}
+
+var EnvVar = []string{common.JfrogCliPluginsServer, common.JfrogCliPluginsRepo, common.JfrogCliPluginsArchitectures, common.JfrogCliPluginsPublicKey, common.JfrogCliPluginsRequireSignature}
+
+func GetDescription() string {
+	return "Export a JFrog CLI plugin to a bundle, which can be installed in environments without access to the plugins registry."
+}
+
+func GetArguments() string {
+	return `	plugin name and version
+		Specifies the name and version of the JFrog CLI Plugin you wish to export from the plugins registry.
+		The version should be specified after a '@' separator, such as: 'hello-frog@1.0.0'.
+		To export the latest version, specify the plugin name only.
+
+	target path
+		[Default: ./<plugin name>-<version>.zip]
+		Path of the zip file to create. Install the plugin from the bundle by running 'jf plugin install --from-file=<target path>'.`
+}
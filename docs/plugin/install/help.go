@@ -2,9 +2,9 @@ package install
 
 import "github.com/jfrog/jfrog-cli/docs/common"
 
-var Usage = []string{"plugin install <plugin name and version>"}
+var Usage = []string{"plugin install <plugin name and version>", "plugin install --from-file=<bundle path>"}
 
-var EnvVar = []string{common.JfrogCliPluginsServer, common.JfrogCliPluginsRepo}
+var EnvVar = []string{common.JfrogCliPluginsServer, common.JfrogCliPluginsRepo, common.JfrogCliPluginsArchitectures, common.JfrogCliPluginsPublicKey, common.JfrogCliPluginsRequireSignature}
 
 func GetDescription() string {
 	return "Install or upgrade a JFrog CLI plugin."
@@ -14,5 +14,6 @@ func GetArguments() string {
 	return `	plugin name and version
 		Specifies the name and version of the JFrog CLI Plugin you wish to install or upgrade from the plugins registry.
 		The version should be specified after a '@' separator, such as: 'hello-frog@1.0.0'.
-		To download the latest version, specify the plugin name only.`
+		To download the latest version, specify the plugin name only.
+		Not required when installing from a plugin bundle with the --from-file option.`
 }
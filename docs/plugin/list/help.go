@@ -0,0 +1,7 @@
+package list
+
+var Usage = []string{"plugin list"}
+
+func GetDescription() string {
+	return "List the installed JFrog CLI plugins, their versions and whether newer versions are available."
+}
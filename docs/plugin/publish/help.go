@@ -4,7 +4,7 @@ import "github.com/jfrog/jfrog-cli/docs/common"
 
 var Usage = []string{"plugin publish <plugin name> <plugin version>"}
 
-var EnvVar = []string{common.JfrogCliPluginsServer, common.JfrogCliPluginsRepo}
+var EnvVar = []string{common.JfrogCliPluginsServer, common.JfrogCliPluginsRepo, common.JfrogCliPluginsArchitectures, common.JfrogCliPluginsSigningKey}
 
 func GetDescription() string {
 	return "Publishing a JFrog CLI plugin."
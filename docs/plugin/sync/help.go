@@ -0,0 +1,23 @@
+package sync
+
+var Usage = []string{"plugin sync [lock file path]"}
+
+func GetDescription() string {
+	return "Install, upgrade and remove JFrog CLI plugins, to match the plugins lock file."
+}
+
+func GetArguments() string {
+	return `	lock file path
+		[Default: ./jfrog-plugins.yaml]
+		Path to the plugins lock file, which declares the name, version, server ID, repository and the sha256 checksum per architecture of each plugin.
+		Installed plugins that are not declared in the lock file are removed.
+		Example:
+			plugins:
+			  - name: hello-frog
+			    version: v1.0.0
+			    serverId: my-server
+			    repo: jfrog-cli-plugins
+			    sha256:
+			      linux-amd64: 3b4c...
+			      mac-arm64: 9f1e...`
+}
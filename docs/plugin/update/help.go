@@ -0,0 +1,18 @@
+package update
+
+import "github.com/jfrog/jfrog-cli/docs/common"
+
+var Usage = []string{"plugin update <plugin name>", "plugin update --all"}
+
+var EnvVar = []string{common.JfrogCliPluginsServer, common.JfrogCliPluginsRepo, common.JfrogCliPluginsArchitectures, common.JfrogCliPluginsPublicKey, common.JfrogCliPluginsRequireSignature}
+
+func GetDescription() string {
+	return "Update an installed JFrog CLI plugin to its latest version."
+}
+
+func GetArguments() string {
+	return `	plugin name
+		Specifies the name of the installed JFrog CLI Plugin you wish to update.
+		The plugin is updated from the plugins registry it was installed from.
+		Plugins installed with an explicit version are pinned to that version, and are not updated.`
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/httptransport"
 	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/http/httpclient"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -68,7 +69,7 @@ func askQuestion(question string) (response string, err error) {
 	if errorutils.CheckError(err) != nil {
 		return
 	}
-	client, err := httpclient.ClientBuilder().Build()
+	client, err := httpclient.ClientBuilder().SetHttpClient(httptransport.NewClient()).Build()
 	if errorutils.CheckError(err) != nil {
 		return
 	}
@@ -0,0 +1,284 @@
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+)
+
+// References to variables in the steps' arguments, such as '${repo}' or '${found.0.path}'.
+var variablePattern = regexp.MustCompile(`\$\{([^}]+)}`)
+
+// A list of jf commands, which are run one after the other in a single process.
+// Example:
+//
+//	server-id: my-server
+//	vars:
+//	  repo: libs-release-local
+//	steps:
+//	  - name: Find the release candidate
+//	    run: rt search "${repo}/app/*.jar" --props=stage=rc --limit=1
+//	    output: found
+//	  - name: Promote the release candidate
+//	    run: rt set-props "${found.0.path}" stage=release
+//	    continue-on-error: true
+type Script struct {
+	// The server used by the steps which don't set the --server-id option.
+	ServerId string            `yaml:"server-id,omitempty"`
+	Vars     map[string]string `yaml:"vars,omitempty"`
+	Steps    []Step            `yaml:"steps"`
+}
+
+type Step struct {
+	Name string `yaml:"name,omitempty"`
+	// The command's arguments, without the executable name.
+	Run Arguments `yaml:"run"`
+	// If set, the command's output is stored in a variable with this name. Output in JSON format can be accessed by its fields.
+	Output string `yaml:"output,omitempty"`
+	// If true, the following steps are run even if this step fails, and its failure doesn't fail the batch.
+	ContinueOnError bool `yaml:"continue-on-error,omitempty"`
+}
+
+func (step *Step) GetDisplayName(index int) string {
+	if step.Name != "" {
+		return step.Name
+	}
+	return fmt.Sprintf("Step %d", index+1)
+}
+
+// The arguments can be provided as a list, or as a single line, which is split like a shell would split it.
+type Arguments []string
+
+func (args *Arguments) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		*args = list
+		return nil
+	}
+	var line string
+	if err := unmarshal(&line); err != nil {
+		return err
+	}
+	split, err := SplitCommandLine(line)
+	if err != nil {
+		return err
+	}
+	*args = split
+	return nil
+}
+
+// Splits a command line into arguments by whitespaces, except for whitespaces inside single or double quotes.
+// Backslashes escape the next character, except inside single quotes.
+func SplitCommandLine(line string) (args []string, err error) {
+	var current strings.Builder
+	var quote rune
+	inArg, escaped := false, false
+	for _, char := range line {
+		switch {
+		case escaped:
+			current.WriteRune(char)
+			escaped = false
+		case char == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			} else {
+				current.WriteRune(char)
+			}
+		case char == '\'' || char == '"':
+			quote, inArg = char, true
+		case char == ' ' || char == '\t' || char == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(char)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, errorutils.CheckErrorf("unterminated quote or escape in the command: %s", line)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return
+}
+
+func ReadScript(scriptPath string) (*Script, error) {
+	content, err := fileutils.ReadFile(scriptPath)
+	if err != nil {
+		return nil, err
+	}
+	script := &Script{}
+	if err = yaml.UnmarshalStrict(content, script); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the batch script at '%s': %s", scriptPath, err.Error())
+	}
+	return script, script.validate()
+}
+
+func (script *Script) validate() error {
+	if len(script.Steps) == 0 {
+		return errorutils.CheckErrorf("the batch script has no steps")
+	}
+	for i, step := range script.Steps {
+		if len(step.Run) == 0 {
+			return errorutils.CheckErrorf("'%s' has no command to run", step.GetDisplayName(i))
+		}
+	}
+	return nil
+}
+
+// The result of a step, as shown in the batch summary.
+type StepResult struct {
+	Step     string  `json:"step" col-name:"Step"`
+	Command  string  `json:"command" col-name:"Command"`
+	Status   string  `json:"status" col-name:"Status"`
+	Duration float64 `json:"durationSeconds" col-name:"Duration (s)"`
+	Error    string  `json:"error,omitempty" col-name:"Error"`
+}
+
+// Runs the steps of a script with the provided executor, which runs a single command in the current process.
+type Runner struct {
+	script *Script
+	// The values of the variables. Captured outputs in JSON format are stored parsed, so their fields can be accessed.
+	vars    map[string]interface{}
+	execute func(args []string, captureOutput bool) (output string, err error)
+}
+
+func NewRunner(script *Script, execute func(args []string, captureOutput bool) (string, error)) *Runner {
+	vars := map[string]interface{}{}
+	for name, value := range script.Vars {
+		vars[name] = value
+	}
+	return &Runner{script: script, vars: vars, execute: execute}
+}
+
+// Sets variables, which take precedence over the script's variables.
+func (runner *Runner) SetVars(vars map[string]string) *Runner {
+	for name, value := range vars {
+		runner.vars[name] = value
+	}
+	return runner
+}
+
+// Runs the steps in order. Once a step which doesn't continue on error fails, the remaining steps are skipped.
+// Returns the results of all the steps, and the error of the step which stopped the batch, if any.
+func (runner *Runner) Run() (results []StepResult, err error) {
+	for i, step := range runner.script.Steps {
+		result := StepResult{Step: step.GetDisplayName(i), Command: strings.Join(step.Run, " ")}
+		if err != nil {
+			result.Status = StatusSkipped
+			results = append(results, result)
+			continue
+		}
+		log.Info(fmt.Sprintf("[%d/%d] %s", i+1, len(runner.script.Steps), result.Step))
+		start := time.Now()
+		stepErr := runner.runStep(step)
+		result.Duration = time.Since(start).Round(time.Millisecond).Seconds()
+		result.Status = StatusSuccess
+		if stepErr != nil {
+			result.Status = StatusFailed
+			result.Error = stepErr.Error()
+			if step.ContinueOnError {
+				log.Warn(fmt.Sprintf("'%s' failed, continuing: %s", result.Step, stepErr.Error()))
+			} else {
+				err = fmt.Errorf("'%s' failed: %w", result.Step, stepErr)
+			}
+		}
+		results = append(results, result)
+	}
+	return
+}
+
+func (runner *Runner) runStep(step Step) error {
+	args, err := runner.substituteVars(step.Run)
+	if err != nil {
+		return err
+	}
+	output, err := runner.execute(args, step.Output != "")
+	if err != nil {
+		return err
+	}
+	if step.Output != "" {
+		runner.vars[step.Output] = parseOutput(output)
+	}
+	return nil
+}
+
+// Output in JSON format is stored parsed, and any other output is stored as is, without the trailing new line.
+func parseOutput(output string) interface{} {
+	output = strings.TrimSpace(output)
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(output), &parsed); err == nil {
+		return parsed
+	}
+	return output
+}
+
+func (runner *Runner) substituteVars(args []string) (substituted []string, err error) {
+	for _, arg := range args {
+		arg = variablePattern.ReplaceAllStringFunc(arg, func(reference string) string {
+			value, resolveErr := runner.resolve(variablePattern.FindStringSubmatch(reference)[1])
+			if resolveErr != nil && err == nil {
+				err = resolveErr
+			}
+			return value
+		})
+		substituted = append(substituted, arg)
+	}
+	return
+}
+
+// Resolves a variable reference, such as 'repo', or 'found.0.path' to access a field of an output in JSON format.
+func (runner *Runner) resolve(reference string) (string, error) {
+	path := strings.Split(strings.TrimSpace(reference), ".")
+	value, exists := runner.vars[path[0]]
+	if !exists {
+		return "", errorutils.CheckErrorf("the variable '%s' isn't defined", path[0])
+	}
+	for _, key := range path[1:] {
+		switch current := value.(type) {
+		case map[string]interface{}:
+			if value, exists = current[key]; !exists {
+				return "", errorutils.CheckErrorf("'%s' has no field '%s'", reference, key)
+			}
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(current) {
+				return "", errorutils.CheckErrorf("'%s' has no index '%s'", reference, key)
+			}
+			value = current[index]
+		default:
+			return "", errorutils.CheckErrorf("'%s' can't be resolved, since '%s' has no fields", reference, key)
+		}
+	}
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case map[string]interface{}, []interface{}:
+		content, err := json.Marshal(value)
+		return string(content), errorutils.CheckError(err)
+	case nil:
+		return "", nil
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
@@ -0,0 +1,156 @@
+package batch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/utils/jsonlog"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+const testScript = `server-id: my-server
+vars:
+  repo: libs-release-local
+steps:
+  - name: Search
+    run: rt search "${repo}/app/*.jar" --limit=1
+    output: found
+  - run: [rt, set-props, "${found.0.path}", "stage=release"]
+    continue-on-error: true
+  - name: Ping
+    run: rt ping
+`
+
+func TestReadScript(t *testing.T) {
+	scriptPath := filepath.Join(t.TempDir(), "steps.yaml")
+	require.NoError(t, os.WriteFile(scriptPath, []byte(testScript), 0644))
+	script, err := ReadScript(scriptPath)
+	require.NoError(t, err)
+	assert.Equal(t, "my-server", script.ServerId)
+	assert.Equal(t, map[string]string{"repo": "libs-release-local"}, script.Vars)
+	require.Len(t, script.Steps, 3)
+	assert.Equal(t, Arguments{"rt", "search", "${repo}/app/*.jar", "--limit=1"}, script.Steps[0].Run)
+	assert.Equal(t, "found", script.Steps[0].Output)
+	assert.Equal(t, Arguments{"rt", "set-props", "${found.0.path}", "stage=release"}, script.Steps[1].Run)
+	assert.True(t, script.Steps[1].ContinueOnError)
+	assert.Equal(t, "Step 2", script.Steps[1].GetDisplayName(1))
+
+	require.NoError(t, os.WriteFile(scriptPath, []byte("steps:\n  - name: Nothing\n"), 0644))
+	_, err = ReadScript(scriptPath)
+	assert.ErrorContains(t, err, "'Nothing' has no command to run")
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	args, err := SplitCommandLine(`rt u  "my dir/*.zip" 'a "quoted" \ value' escaped\ space --flat`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rt", "u", "my dir/*.zip", `a "quoted" \ value`, "escaped space", "--flat"}, args)
+	args, err = SplitCommandLine(`rt sp "" a=b`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rt", "sp", "", "a=b"}, args)
+	_, err = SplitCommandLine(`rt s "unterminated`)
+	assert.Error(t, err)
+}
+
+func TestRunner(t *testing.T) {
+	script := &Script{
+		Vars: map[string]string{"repo": "libs", "prop": "stage"},
+		Steps: []Step{
+			{Name: "Search", Run: Arguments{"search", "${repo}"}, Output: "found"},
+			{Name: "Set", Run: Arguments{"set", "${found.0.path}", "${prop}=${found.0.props.stage}", "${found.1}"}},
+			{Name: "Flaky", Run: Arguments{"fail"}, ContinueOnError: true},
+			{Name: "Undefined", Run: Arguments{"set", "${missing}"}},
+			{Name: "After failure", Run: Arguments{"set"}},
+		},
+	}
+	var executed [][]string
+	results, err := NewRunner(script, func(args []string, captureOutput bool) (string, error) {
+		executed = append(executed, args)
+		switch args[0] {
+		case "search":
+			assert.True(t, captureOutput)
+			return `[{"path": "libs/a.jar", "props": {"stage": "rc"}}, {"path": "libs/b.jar", "size": 10}]` + "\n", nil
+		case "fail":
+			return "", errors.New("flaky failure")
+		}
+		return "", nil
+	}).SetVars(map[string]string{"repo": "libs-release"}).Run()
+
+	assert.ErrorContains(t, err, "'Undefined' failed: the variable 'missing' isn't defined")
+	assert.Equal(t, [][]string{
+		{"search", "libs-release"},
+		{"set", "libs/a.jar", "stage=rc", `{"path":"libs/b.jar","size":10}`},
+		{"fail"},
+	}, executed)
+	var statuses []string
+	for _, result := range results {
+		statuses = append(statuses, result.Status)
+	}
+	assert.Equal(t, []string{StatusSuccess, StatusSuccess, StatusFailed, StatusFailed, StatusSkipped}, statuses)
+	assert.Equal(t, "flaky failure", results[2].Error)
+	assert.Equal(t, "set ${found.0.path} ${prop}=${found.0.props.stage} ${found.1}", results[1].Command)
+}
+
+func TestExecCommand(t *testing.T) {
+	beforeRuns := 0
+	app := cli.NewApp()
+	app.Name = "jf"
+	app.Before = func(*cli.Context) error {
+		beforeRuns++
+		return nil
+	}
+	app.Commands = []cli.Command{
+		{Name: "echo", Action: func(c *cli.Context) error {
+			log.Output(strings.Join(c.Args(), " "))
+			return nil
+		}},
+		{Name: "exit", Action: func(*cli.Context) error {
+			return cli.NewExitError("exit error", 3)
+		}},
+		{Name: "namespace", Subcommands: []cli.Command{{Name: "sub", Action: func(*cli.Context) error { return nil }}}},
+	}
+
+	output, err := ExecCommand(app, []string{"echo", "hello", "world"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world\n", output)
+	output, err = ExecCommand(app, []string{"echo", "not captured"}, false)
+	require.NoError(t, err)
+	assert.Empty(t, output)
+	_, err = ExecCommand(app, []string{"exit"}, false)
+	assert.EqualError(t, err, "exit error")
+	_, err = ExecCommand(app, []string{"unknown"}, false)
+	assert.ErrorContains(t, err, "'unknown' is not a jf command")
+	_, err = ExecCommand(app, []string{"namespace", "unknown"}, false)
+	assert.ErrorContains(t, err, "'namespace unknown' is not a jf command")
+	assert.Zero(t, beforeRuns)
+}
+
+// A logger which decorates another logger, as the loggers which observe the messages do.
+type decoratingLogger struct {
+	log.Log
+}
+
+func (logger *decoratingLogger) Unwrap() log.Log {
+	return logger.Log
+}
+
+func TestExecCommandCapturesDecoratedLoggerOutput(t *testing.T) {
+	previousLogger := log.GetLogger()
+	log.SetLogger(&decoratingLogger{Log: jsonlog.NewLogger(log.INFO)})
+	defer log.SetLogger(previousLogger)
+	app := cli.NewApp()
+	app.Name = "jf"
+	app.Commands = []cli.Command{{Name: "echo", Action: func(c *cli.Context) error {
+		log.Output(strings.Join(c.Args(), " "))
+		return nil
+	}}}
+
+	output, err := ExecCommand(app, []string{"echo", "captured"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, "captured\n", output)
+}
@@ -0,0 +1,146 @@
+package batch
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/jsonlog"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+func RunBatchCmd(c *cli.Context) (err error) {
+	if c.NArg() != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	format, err := cliutils.GetOutputFormat(c, cliutils.Table)
+	if err != nil {
+		return err
+	}
+	script, err := ReadScript(c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	serverId := c.String("server-id")
+	if serverId == "" {
+		serverId = script.ServerId
+	}
+	if serverId != "" {
+		var restoreServerId func() error
+		if restoreServerId, err = setServerId(serverId); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, restoreServerId())
+		}()
+	}
+	results, err := NewRunner(script, func(args []string, captureOutput bool) (string, error) {
+		return ExecCommand(c.App, args, captureOutput)
+	}).SetVars(coreutils.SpecVarsStringToMap(c.String("vars"))).Run()
+	if format == cliutils.Table {
+		log.Output()
+	}
+	return errors.Join(err, cliutils.PrintCommandOutput(cliutils.CommandOutput{Data: results, Title: "Batch Summary"}, format))
+}
+
+// The server is set by the JFROG_CLI_SERVER_ID environment variable, so both JFrog CLI and its embedded plugins use it.
+func setServerId(serverId string) (restore func() error, err error) {
+	previousServerId, wasSet := os.LookupEnv(coreutils.ServerID)
+	if err = os.Setenv(coreutils.ServerID, serverId); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return func() error {
+		if wasSet {
+			return errorutils.CheckError(os.Setenv(coreutils.ServerID, previousServerId))
+		}
+		return errorutils.CheckError(os.Unsetenv(coreutils.ServerID))
+	}, nil
+}
+
+// Runs a jf command in the current process. The args don't include the executable name.
+// The app's Before function, which runs once per process, isn't run again.
+// If captureOutput is true, the command's output is also returned.
+func ExecCommand(app *cli.App, args []string, captureOutput bool) (output string, err error) {
+	if len(args) == 0 {
+		return "", errorutils.CheckErrorf("no command to run")
+	}
+	// Unknown commands would exit the process, so they're rejected in advance.
+	command := app.Command(args[0])
+	if command == nil {
+		return "", errorutils.CheckErrorf("'%s' is not a jf command", args[0])
+	}
+	if command.Name == cliutils.RunBatch {
+		return "", errorutils.CheckErrorf("'%s' can't be run from a batch", cliutils.RunBatch)
+	}
+	commandApp := *app
+	commandApp.Before = nil
+	// Errors with exit codes would exit the process, so they're returned like any other error.
+	commandApp.ExitErrHandler = func(*cli.Context, error) {}
+	// Unknown subcommands would also exit the process.
+	var unknownCommand string
+	commandApp.CommandNotFound = func(_ *cli.Context, command string) {
+		unknownCommand = command
+	}
+	run := func() error {
+		if err := commandApp.Run(append([]string{app.Name}, args...)); err != nil {
+			return err
+		}
+		if unknownCommand != "" {
+			return errorutils.CheckErrorf("'%s %s' is not a jf command", args[0], unknownCommand)
+		}
+		return nil
+	}
+	if !captureOutput {
+		return "", run()
+	}
+	return runWithOutputCapture(run)
+}
+
+// Runs the action while copying its output, which is still printed to the Stdout.
+func runWithOutputCapture(action func() error) (output string, err error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	stdout := os.Stdout
+	var buffer bytes.Buffer
+	copyDone := make(chan error)
+	go func() {
+		_, copyErr := io.Copy(io.MultiWriter(stdout, &buffer), reader)
+		copyDone <- copyErr
+	}()
+	os.Stdout = writer
+	setLogOutputWriter(writer)
+	defer func() {
+		os.Stdout = stdout
+		setLogOutputWriter(stdout)
+		err = errors.Join(err, errorutils.CheckError(writer.Close()), errorutils.CheckError(<-copyDone), errorutils.CheckError(reader.Close()))
+		output = buffer.String()
+	}()
+	return "", action()
+}
+
+// The loggers write the output to the Stdout they got when they were created, so they're redirected explicitly.
+// Loggers which decorate other loggers are unwrapped, to redirect the logger which writes the output.
+func setLogOutputWriter(writer io.Writer) {
+	logger := log.GetLogger()
+	for {
+		switch current := logger.(type) {
+		case *jsonlog.Logger:
+			current.SetOutputWriter(writer)
+			return
+		case interface{ SetOutputWriter(io.Writer) }:
+			current.SetOutputWriter(writer)
+			return
+		case interface{ Unwrap() log.Log }:
+			logger = current.Unwrap()
+		default:
+			return
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package login
 
 import (
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
 	coreLogin "github.com/jfrog/jfrog-cli-core/v2/general/login"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	"github.com/urfave/cli"
@@ -10,5 +11,17 @@ func LoginCmd(c *cli.Context) error {
 	if c.NArg() > 0 {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
 	}
+	if provider := c.String(cliutils.OidcProvider); provider != "" {
+		oidcLoginCmd := NewOidcLoginCommand().SetProvider(provider).SetPlatformUrl(c.String("url")).SetTokenEnv(c.String(cliutils.OidcTokenEnv)).
+			SetAudience(c.String(cliutils.OidcAudience)).SetServerId(c.String("server-id"))
+		return commands.Exec(oidcLoginCmd)
+	}
 	return coreLogin.NewLoginCommand().Run()
 }
+
+func LogoutCmd(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	return logout(c.String("server-id"))
+}
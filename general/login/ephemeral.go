@@ -0,0 +1,202 @@
+package login
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/lock"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// Stored in the JFrog home directory, and holds the servers created by 'jf login --oidc-provider'.
+	ephemeralServersFileName = "ephemeral-servers.json"
+	// Ephemeral servers whose token never expires are removed after this duration, which is the longest duration of a CI job
+	// on common CI servers.
+	maxEphemeralServerTtl = 6 * time.Hour
+)
+
+// The server is removed once its token expires, rather than when the process which ran 'jf login' exits,
+// since CI servers run each step in a new shell, and the following steps use the server too.
+// The expired servers are removed when a JFrog CLI process starts or exits, and all ephemeral servers are removed by 'jf logout'.
+type ephemeralServer struct {
+	// Unix time in seconds. The server is removed once its token expires, or once maxEphemeralServerTtl passed if the token never expires.
+	Expiry int64 `json:"expiry,omitempty"`
+	// The default server before the ephemeral server was created, to be restored once the ephemeral server is removed.
+	PreviousDefault string `json:"previousDefault,omitempty"`
+}
+
+func getEphemeralServersFilePath() (string, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ephemeralServersFileName), nil
+}
+
+func loadEphemeralServers() (map[string]*ephemeralServer, error) {
+	servers := map[string]*ephemeralServer{}
+	filePath, err := getEphemeralServersFilePath()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return servers, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	return servers, errorutils.CheckError(json.Unmarshal(content, &servers))
+}
+
+func saveEphemeralServers(servers map[string]*ephemeralServer) error {
+	filePath, err := getEphemeralServersFilePath()
+	if err != nil {
+		return err
+	}
+	if len(servers) == 0 {
+		return errorutils.CheckError(os.RemoveAll(filePath))
+	}
+	content, err := json.Marshal(servers)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(filePath, content, 0600))
+}
+
+// The ephemeral servers file and the config are updated together, so both are updated while holding the config lock.
+func withConfigLock(action func() error) (err error) {
+	lockDirPath, err := coreutils.GetJfrogConfigLockDir()
+	if err != nil {
+		return
+	}
+	unlock, err := lock.CreateLock(lockDirPath)
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+	if err != nil {
+		return
+	}
+	return action()
+}
+
+func registerEphemeralServer(serverId string, expiry time.Time, previousDefault string) error {
+	return withConfigLock(func() error {
+		servers, err := loadEphemeralServers()
+		if err != nil {
+			return err
+		}
+		servers[serverId] = &ephemeralServer{Expiry: expiry.Unix(), PreviousDefault: previousDefault}
+		return saveEphemeralServers(servers)
+	})
+}
+
+// Returns whether the server ID belongs to an ephemeral server.
+func isEphemeralServer(serverId string) (bool, error) {
+	servers, err := loadEphemeralServers()
+	if err != nil {
+		return false, err
+	}
+	_, exists := servers[serverId]
+	return exists, nil
+}
+
+func (es *ephemeralServer) isExpired(now time.Time) bool {
+	return es.Expiry != 0 && now.Unix() >= es.Expiry
+}
+
+// Removes the ephemeral servers whose token expired, and restores the previous default server.
+// Runs when every CLI invocation starts and exits, since the servers can't be removed when their token expires.
+func CleanupEphemeralServers() {
+	if err := cleanupEphemeralServers(time.Now()); err != nil {
+		log.Debug("Failed cleaning up the ephemeral servers:", err.Error())
+	}
+}
+
+func cleanupEphemeralServers(now time.Time) error {
+	_, err := removeEphemeralServers(func(_ string, server *ephemeralServer) bool {
+		return server.isExpired(now)
+	})
+	return err
+}
+
+// Removes the ephemeral servers the filter returns true for, and returns their IDs.
+func removeEphemeralServers(filter func(serverId string, server *ephemeralServer) bool) (removed []string, err error) {
+	servers, err := loadEphemeralServers()
+	if err != nil || len(servers) == 0 {
+		return
+	}
+	err = withConfigLock(func() error {
+		// The servers are loaded again, since they may have changed before the lock was acquired.
+		if servers, err = loadEphemeralServers(); err != nil {
+			return err
+		}
+		for serverId, server := range servers {
+			if !filter(serverId, server) {
+				continue
+			}
+			log.Debug(fmt.Sprintf("Removing the ephemeral server '%s'...", serverId))
+			if err = removeServer(serverId, server.PreviousDefault); err != nil {
+				return err
+			}
+			delete(servers, serverId)
+			removed = append(removed, serverId)
+		}
+		return saveEphemeralServers(servers)
+	})
+	return
+}
+
+// Removes the ephemeral servers regardless of their token's expiry, so that CI jobs can remove them once they're done.
+// If a server ID is provided, only this server is removed.
+func logout(serverId string) error {
+	removed, err := removeEphemeralServers(func(ephemeralServerId string, _ *ephemeralServer) bool {
+		return serverId == "" || ephemeralServerId == serverId
+	})
+	if err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		if serverId != "" {
+			return errorutils.CheckErrorf("'%s' is not an ephemeral server created by 'jf login'", serverId)
+		}
+		log.Info("There are no ephemeral servers to remove.")
+		return nil
+	}
+	slices.Sort(removed)
+	log.Info("Removed the ephemeral servers:", strings.Join(removed, ", "))
+	return nil
+}
+
+// Removes the server from the config, and restores the previous default server, if it still exists.
+// The config command can't be used to remove the server, since it acquires the config lock by itself.
+func removeServer(serverId, previousDefault string) error {
+	servers, err := config.GetAllServersConfigs()
+	if err != nil {
+		return err
+	}
+	var remaining []*config.ServerDetails
+	previousDefaultExists := false
+	for _, server := range servers {
+		if server.ServerId != serverId {
+			remaining = append(remaining, server)
+			previousDefaultExists = previousDefaultExists || server.ServerId == previousDefault
+		}
+	}
+	if previousDefaultExists {
+		for _, server := range remaining {
+			server.IsDefault = server.ServerId == previousDefault
+		}
+	}
+	return config.SaveServersConf(remaining)
+}
@@ -0,0 +1,256 @@
+package login
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/httptransport"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	oidcTokenExchangeApi = "access/api/v1/oidc/token"
+	// GitHub Actions doesn't expose the ID token as an environment variable. It is requested using these variables instead.
+	githubIdTokenRequestUrlEnv   = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	githubIdTokenRequestTokenEnv = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+)
+
+// Environment variables which hold the OIDC ID token on common CI servers, by order of precedence.
+var ciIdTokenEnvVars = []string{
+	// CircleCI
+	"CIRCLE_OIDC_TOKEN_V2",
+	"CIRCLE_OIDC_TOKEN",
+	// Bitbucket Pipelines
+	"BITBUCKET_STEP_OIDC_TOKEN",
+	// GitLab, if configured with the legacy predefined ID token.
+	"CI_JOB_JWT_V2",
+}
+
+type OidcLoginCommand struct {
+	platformUrl string
+	provider    string
+	tokenEnv    string
+	audience    string
+	serverId    string
+}
+
+func NewOidcLoginCommand() *OidcLoginCommand {
+	return &OidcLoginCommand{}
+}
+
+func (olc *OidcLoginCommand) SetPlatformUrl(platformUrl string) *OidcLoginCommand {
+	olc.platformUrl = clientUtils.AddTrailingSlashIfNeeded(platformUrl)
+	return olc
+}
+
+func (olc *OidcLoginCommand) SetProvider(provider string) *OidcLoginCommand {
+	olc.provider = provider
+	return olc
+}
+
+func (olc *OidcLoginCommand) SetTokenEnv(tokenEnv string) *OidcLoginCommand {
+	olc.tokenEnv = tokenEnv
+	return olc
+}
+
+func (olc *OidcLoginCommand) SetAudience(audience string) *OidcLoginCommand {
+	olc.audience = audience
+	return olc
+}
+
+func (olc *OidcLoginCommand) SetServerId(serverId string) *OidcLoginCommand {
+	olc.serverId = serverId
+	return olc
+}
+
+func (olc *OidcLoginCommand) CommandName() string {
+	return "oidc_login"
+}
+
+func (olc *OidcLoginCommand) ServerDetails() (*config.ServerDetails, error) {
+	return &config.ServerDetails{Url: olc.platformUrl}, nil
+}
+
+// Exchanges the CI's ID token for a short-lived access token, and saves it in an ephemeral server configuration,
+// which becomes the default server until it is cleaned up.
+func (olc *OidcLoginCommand) Run() error {
+	if olc.platformUrl == "" {
+		return errorutils.CheckErrorf("the JFrog Platform URL is mandatory for logging in with OIDC")
+	}
+	if olc.serverId == "" {
+		olc.serverId = "oidc-" + olc.provider
+	}
+	client, err := httpclient.ClientBuilder().SetHttpClient(httptransport.NewClient()).Build()
+	if err != nil {
+		return err
+	}
+	idToken, err := olc.getIdToken(client)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Exchanging the ID token using the '%s' OIDC provider...", olc.provider))
+	tokenResponse, err := olc.exchangeIdToken(client, idToken)
+	if err != nil {
+		return err
+	}
+	previousDefault, err := getDefaultServerId()
+	if err != nil {
+		return err
+	}
+	// The ephemeral server is removed once its token expires, so it must not replace a server configured by the user.
+	if err = assertEphemeralServerId(olc.serverId); err != nil {
+		return err
+	}
+	details := &config.ServerDetails{Url: olc.platformUrl, AccessToken: tokenResponse.AccessToken, User: tokenResponse.Username}
+	if err = commands.NewConfigCommand(commands.AddOrEdit, olc.serverId).SetDetails(details).SetInteractive(false).SetMakeDefault(true).Run(); err != nil {
+		return err
+	}
+	expiry := time.Now().Add(maxEphemeralServerTtl)
+	if tokenResponse.ExpiresIn > 0 {
+		expiry = time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+	}
+	if previousDefault == olc.serverId {
+		// The ephemeral server replaced itself, so there's no other default server to restore.
+		previousDefault = ""
+	}
+	if err = registerEphemeralServer(olc.serverId, expiry, previousDefault); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Logged in to %s as the ephemeral server '%s'. The server is removed once the token expires, or by running 'jf logout'.", olc.platformUrl, olc.serverId))
+	return nil
+}
+
+// Reads the ID token from the requested environment variable, or detects it on common CI servers.
+func (olc *OidcLoginCommand) getIdToken(client *httpclient.HttpClient) (string, error) {
+	if olc.tokenEnv != "" {
+		idToken := os.Getenv(olc.tokenEnv)
+		if idToken == "" {
+			return "", errorutils.CheckErrorf("the %s environment variable, which should hold the OIDC ID token, is empty", olc.tokenEnv)
+		}
+		return idToken, nil
+	}
+	if os.Getenv(githubIdTokenRequestUrlEnv) != "" {
+		return olc.requestGithubIdToken(client)
+	}
+	for _, envVar := range ciIdTokenEnvVars {
+		if idToken := os.Getenv(envVar); idToken != "" {
+			log.Debug("Using the OIDC ID token from the", envVar, "environment variable.")
+			return idToken, nil
+		}
+	}
+	return "", errorutils.CheckErrorf("couldn't detect an OIDC ID token in the environment. Use the --oidc-token-env option to provide the name of the environment variable which holds it")
+}
+
+func (olc *OidcLoginCommand) requestGithubIdToken(client *httpclient.HttpClient) (string, error) {
+	requestUrl, err := url.Parse(os.Getenv(githubIdTokenRequestUrlEnv))
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if olc.audience != "" {
+		query := requestUrl.Query()
+		query.Set("audience", olc.audience)
+		requestUrl.RawQuery = query.Encode()
+	}
+	log.Debug("Requesting an OIDC ID token from GitHub Actions...")
+	httpDetails := httputils.HttpClientDetails{AccessToken: os.Getenv(githubIdTokenRequestTokenEnv)}
+	resp, body, _, err := client.SendGet(requestUrl.String(), true, httpDetails, "")
+	if err != nil {
+		return "", err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return "", err
+	}
+	idToken := struct {
+		Value string `json:"value"`
+	}{}
+	if err = json.Unmarshal(body, &idToken); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if idToken.Value == "" {
+		return "", errorutils.CheckErrorf("GitHub Actions returned an empty OIDC ID token")
+	}
+	return idToken.Value, nil
+}
+
+type oidcTokenExchangeRequest struct {
+	GrantType        string `json:"grant_type"`
+	SubjectTokenType string `json:"subject_token_type"`
+	SubjectToken     string `json:"subject_token"`
+	ProviderName     string `json:"provider_name"`
+}
+
+type oidcTokenExchangeResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   uint   `json:"expires_in,omitempty"`
+	Username    string `json:"username,omitempty"`
+}
+
+func (olc *OidcLoginCommand) exchangeIdToken(client *httpclient.HttpClient, idToken string) (*oidcTokenExchangeResponse, error) {
+	content, err := json.Marshal(oidcTokenExchangeRequest{
+		GrantType:        "urn:ietf:params:oauth:grant-type:token-exchange",
+		SubjectTokenType: "urn:ietf:params:oauth:token-type:id_token",
+		SubjectToken:     idToken,
+		ProviderName:     olc.provider,
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	httpDetails := httputils.HttpClientDetails{Headers: map[string]string{"Content-Type": "application/json"}}
+	resp, body, err := client.SendPost(olc.platformUrl+oidcTokenExchangeApi, content, httpDetails, "")
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	tokenResponse := new(oidcTokenExchangeResponse)
+	if err = json.Unmarshal(body, tokenResponse); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if tokenResponse.AccessToken == "" {
+		return nil, errorutils.CheckErrorf("the OIDC token exchange returned an empty access token")
+	}
+	return tokenResponse, nil
+}
+
+// The server ID must be available, or belong to an ephemeral server created by a previous login.
+func assertEphemeralServerId(serverId string) error {
+	servers, err := config.GetAllServersConfigs()
+	if err != nil {
+		return err
+	}
+	for _, server := range servers {
+		if server.ServerId != serverId {
+			continue
+		}
+		ephemeral, err := isEphemeralServer(serverId)
+		if err != nil || ephemeral {
+			return err
+		}
+		return errorutils.CheckErrorf("server ID '%s' already exists, and is not an ephemeral server created by 'jf login'. Use the --server-id option to choose another server ID", serverId)
+	}
+	return nil
+}
+
+func getDefaultServerId() (string, error) {
+	servers, err := config.GetAllServersConfigs()
+	if err != nil {
+		return "", err
+	}
+	for _, server := range servers {
+		if server.IsDefault {
+			return server.ServerId, nil
+		}
+	}
+	return "", nil
+}
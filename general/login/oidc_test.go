@@ -0,0 +1,172 @@
+package login
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	coreTests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOidcLogin(t *testing.T) {
+	cleanUpJfrogHome, err := coreTests.SetJfrogHome()
+	require.NoError(t, err)
+	defer cleanUpJfrogHome()
+	require.NoError(t, config.SaveServersConf([]*config.ServerDetails{{ServerId: "static", Url: "https://static.jfrog.io/", AccessToken: "static-token", IsDefault: true}}))
+
+	var exchangeRequest oidcTokenExchangeRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/"+oidcTokenExchangeApi, r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&exchangeRequest))
+		_, err := w.Write([]byte(`{"access_token":"short-lived-token","expires_in":300,"username":"ci-user"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	t.Setenv("MY_ID_TOKEN", "id-token")
+
+	require.NoError(t, NewOidcLoginCommand().SetProvider("github").SetPlatformUrl(server.URL).SetTokenEnv("MY_ID_TOKEN").Run())
+	assert.Equal(t, "id-token", exchangeRequest.SubjectToken)
+	assert.Equal(t, "github", exchangeRequest.ProviderName)
+
+	// The ephemeral server becomes the default server.
+	details, err := config.GetSpecificConfig("", true, false)
+	require.NoError(t, err)
+	assert.Equal(t, "oidc-github", details.ServerId)
+	assert.Equal(t, "short-lived-token", details.AccessToken)
+	assert.Equal(t, "ci-user", details.User)
+	assert.Equal(t, server.URL+"/artifactory/", details.ArtifactoryUrl)
+
+	ephemeralServers, err := loadEphemeralServers()
+	require.NoError(t, err)
+	require.Contains(t, ephemeralServers, "oidc-github")
+	assert.NotZero(t, ephemeralServers["oidc-github"].Expiry)
+	assert.Equal(t, "static", ephemeralServers["oidc-github"].PreviousDefault)
+
+	// The server is kept while its token is valid.
+	require.NoError(t, cleanupEphemeralServers(time.Now()))
+	_, err = config.GetSpecificConfig("oidc-github", false, false)
+	assert.NoError(t, err)
+
+	// The server is removed once its token expires, and the previous default server is restored.
+	require.NoError(t, cleanupEphemeralServers(time.Now().Add(time.Hour)))
+	servers, err := config.GetAllServersConfigs()
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "static", servers[0].ServerId)
+	assert.True(t, servers[0].IsDefault)
+	ephemeralServers, err = loadEphemeralServers()
+	require.NoError(t, err)
+	assert.Empty(t, ephemeralServers)
+}
+
+func TestEphemeralServerIsExpired(t *testing.T) {
+	now := time.Now()
+	assert.False(t, (&ephemeralServer{}).isExpired(now))
+	assert.False(t, (&ephemeralServer{Expiry: now.Add(time.Minute).Unix()}).isExpired(now))
+	assert.True(t, (&ephemeralServer{Expiry: now.Add(-time.Minute).Unix()}).isExpired(now))
+}
+
+func TestGetIdToken(t *testing.T) {
+	client, err := httpclient.ClientBuilder().Build()
+	require.NoError(t, err)
+	for _, envVar := range append(ciIdTokenEnvVars, githubIdTokenRequestUrlEnv, githubIdTokenRequestTokenEnv) {
+		t.Setenv(envVar, "")
+	}
+	loginCmd := NewOidcLoginCommand().SetAudience("jfrog-github")
+
+	_, err = loginCmd.getIdToken(client)
+	assert.ErrorContains(t, err, "couldn't detect an OIDC ID token")
+
+	t.Setenv("BITBUCKET_STEP_OIDC_TOKEN", "bitbucket-token")
+	idToken, err := loginCmd.getIdToken(client)
+	assert.NoError(t, err)
+	assert.Equal(t, "bitbucket-token", idToken)
+
+	// On GitHub Actions, the ID token is requested with the requested audience.
+	githubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer request-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "2.0", r.URL.Query().Get("api-version"))
+		assert.Equal(t, "jfrog-github", r.URL.Query().Get("audience"))
+		_, err := w.Write([]byte(`{"value":"github-token"}`))
+		assert.NoError(t, err)
+	}))
+	defer githubServer.Close()
+	t.Setenv(githubIdTokenRequestUrlEnv, githubServer.URL+"/token?api-version=2.0")
+	t.Setenv(githubIdTokenRequestTokenEnv, "request-token")
+	idToken, err = loginCmd.getIdToken(client)
+	assert.NoError(t, err)
+	assert.Equal(t, "github-token", idToken)
+
+	// An explicit environment variable takes precedence.
+	t.Setenv("MY_ID_TOKEN", "explicit-token")
+	idToken, err = loginCmd.SetTokenEnv("MY_ID_TOKEN").getIdToken(client)
+	assert.NoError(t, err)
+	assert.Equal(t, "explicit-token", idToken)
+}
+
+func TestOidcLoginExistingServerId(t *testing.T) {
+	cleanUpJfrogHome, err := coreTests.SetJfrogHome()
+	require.NoError(t, err)
+	defer cleanUpJfrogHome()
+	require.NoError(t, config.SaveServersConf([]*config.ServerDetails{{ServerId: "static", Url: "https://static.jfrog.io/", AccessToken: "static-token", IsDefault: true}}))
+
+	// A server configured by the user is never replaced by an ephemeral server.
+	assert.ErrorContains(t, assertEphemeralServerId("static"), "is not an ephemeral server")
+	assert.NoError(t, assertEphemeralServerId("oidc-github"))
+	require.NoError(t, registerEphemeralServer("static", time.Now().Add(time.Hour), ""))
+	assert.NoError(t, assertEphemeralServerId("static"))
+}
+
+func TestOidcLoginNonExpiringToken(t *testing.T) {
+	cleanUpJfrogHome, err := coreTests.SetJfrogHome()
+	require.NoError(t, err)
+	defer cleanUpJfrogHome()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(`{"access_token":"non-expiring-token"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	t.Setenv("MY_ID_TOKEN", "id-token")
+
+	require.NoError(t, NewOidcLoginCommand().SetProvider("github").SetPlatformUrl(server.URL).SetTokenEnv("MY_ID_TOKEN").Run())
+	ephemeralServers, err := loadEphemeralServers()
+	require.NoError(t, err)
+	require.Contains(t, ephemeralServers, "oidc-github")
+	// The server is removed once the maximal TTL passes.
+	assert.False(t, ephemeralServers["oidc-github"].isExpired(time.Now().Add(maxEphemeralServerTtl-time.Minute)))
+	assert.True(t, ephemeralServers["oidc-github"].isExpired(time.Now().Add(maxEphemeralServerTtl+time.Minute)))
+}
+
+func TestLogout(t *testing.T) {
+	cleanUpJfrogHome, err := coreTests.SetJfrogHome()
+	require.NoError(t, err)
+	defer cleanUpJfrogHome()
+	require.NoError(t, config.SaveServersConf([]*config.ServerDetails{
+		{ServerId: "static", Url: "https://static.jfrog.io/"},
+		{ServerId: "oidc-github", Url: "https://ci.jfrog.io/", IsDefault: true},
+		{ServerId: "oidc-gitlab", Url: "https://ci.jfrog.io/"},
+	}))
+	require.NoError(t, registerEphemeralServer("oidc-github", time.Now().Add(time.Hour), "static"))
+	require.NoError(t, registerEphemeralServer("oidc-gitlab", time.Now().Add(time.Hour), ""))
+
+	assert.ErrorContains(t, logout("static"), "not an ephemeral server")
+	require.NoError(t, logout("oidc-gitlab"))
+	servers, err := config.GetAllServersConfigs()
+	require.NoError(t, err)
+	assert.Len(t, servers, 2)
+
+	// Logging out without a server ID removes all the ephemeral servers, and restores the previous default server.
+	require.NoError(t, logout(""))
+	servers, err = config.GetAllServersConfigs()
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "static", servers[0].ServerId)
+	assert.True(t, servers[0].IsDefault)
+	require.NoError(t, logout(""))
+}
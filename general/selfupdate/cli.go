@@ -0,0 +1,140 @@
+package selfupdate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+type UpdateCheck struct {
+	CurrentVersion  string `json:"currentVersion"`
+	TargetVersion   string `json:"targetVersion"`
+	Channel         string `json:"channel"`
+	UpdateAvailable bool   `json:"updateAvailable"`
+}
+
+// The table format supports string columns only.
+type updateCheckRow struct {
+	CurrentVersion  string `col-name:"Current Version"`
+	TargetVersion   string `col-name:"Target Version"`
+	Channel         string `col-name:"Channel"`
+	UpdateAvailable string `col-name:"Update Available"`
+}
+
+func SelfUpdateCmd(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	channel := c.String("channel")
+	if channel == "" {
+		channel = StableChannel
+	}
+	if channel != StableChannel && channel != RcChannel {
+		return errorutils.CheckErrorf("the --channel option accepts the following values: %s, %s", StableChannel, RcChannel)
+	}
+	source, err := getReleasesSource()
+	if err != nil {
+		return err
+	}
+	command := newUpdateCommand(source, cliutils.GetVersion()).SetVersion(c.String("version")).SetChannel(channel)
+	if c.Bool("check") {
+		format, err := cliutils.GetOutputFormat(c, cliutils.Table)
+		if err != nil {
+			return err
+		}
+		updateCheck, err := command.Check()
+		if err != nil {
+			return err
+		}
+		return cliutils.PrintCommandOutput(cliutils.CommandOutput{Data: updateCheck, Rows: []updateCheckRow{newUpdateCheckRow(updateCheck)}}, format)
+	}
+	if c.IsSet("format") {
+		return errorutils.CheckErrorf("the --format option is supported with the --check option only")
+	}
+	executablePath, err := getExecutablePath()
+	if err != nil {
+		return err
+	}
+	return command.Run(executablePath)
+}
+
+func newUpdateCheckRow(updateCheck *UpdateCheck) updateCheckRow {
+	return updateCheckRow{
+		CurrentVersion:  updateCheck.CurrentVersion,
+		TargetVersion:   updateCheck.TargetVersion,
+		Channel:         updateCheck.Channel,
+		UpdateAvailable: strconv.FormatBool(updateCheck.UpdateAvailable),
+	}
+}
+
+// Replaces the JFrog CLI executable with the requested version, or with the latest version in the channel.
+type updateCommand struct {
+	source         *releasesSource
+	currentVersion string
+	version        string
+	channel        string
+}
+
+func newUpdateCommand(source *releasesSource, currentVersion string) *updateCommand {
+	return &updateCommand{source: source, currentVersion: currentVersion, channel: StableChannel}
+}
+
+func (uc *updateCommand) SetVersion(version string) *updateCommand {
+	uc.version = strings.TrimPrefix(version, "v")
+	return uc
+}
+
+func (uc *updateCommand) SetChannel(channel string) *updateCommand {
+	uc.channel = channel
+	return uc
+}
+
+// Resolves the version to update to. A requested version may also be older than the current version.
+func (uc *updateCommand) Check() (*UpdateCheck, error) {
+	updateCheck := &UpdateCheck{CurrentVersion: uc.currentVersion, TargetVersion: uc.version, Channel: uc.channel}
+	if updateCheck.TargetVersion != "" {
+		updateCheck.UpdateAvailable = updateCheck.TargetVersion != uc.currentVersion
+		return updateCheck, nil
+	}
+	releases, err := uc.source.getReleases()
+	if err != nil {
+		return nil, err
+	}
+	if updateCheck.TargetVersion, err = GetLatestVersion(releases, uc.channel); err != nil {
+		return nil, err
+	}
+	updateCheck.UpdateAvailable = compareVersions(updateCheck.TargetVersion, uc.currentVersion) > 0
+	return updateCheck, nil
+}
+
+// Downloads and verifies the new executable, and then replaces the executable at executablePath with it.
+func (uc *updateCommand) Run(executablePath string) error {
+	updateCheck, err := uc.Check()
+	if err != nil {
+		return err
+	}
+	if !updateCheck.UpdateAvailable {
+		log.Info(fmt.Sprintf("JFrog CLI is up to date (version %s).", uc.currentVersion))
+		return nil
+	}
+	details, err := uc.source.getExecutableDetails(updateCheck.TargetVersion)
+	if err != nil {
+		return err
+	}
+	downloadedPath, err := downloadExecutable(uc.source, details, executablePath)
+	if err != nil {
+		return err
+	}
+	if err = replaceExecutable(executablePath, downloadedPath); err != nil {
+		return errors.Join(err, errorutils.CheckError(os.Remove(downloadedPath)))
+	}
+	log.Info(fmt.Sprintf("JFrog CLI was updated from version %s to version %s.", uc.currentVersion, updateCheck.TargetVersion))
+	return nil
+}
@@ -0,0 +1,347 @@
+package selfupdate
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/jfrog/gofrog/version"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/dependencies"
+	commandsUtils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-cli/utils/httptransport"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	StableChannel = "stable"
+	// Includes the release candidates, as well as the stable releases.
+	RcChannel = "rc"
+
+	// Path to a PEM encoded ECDSA or Ed25519 public key, used instead of the embedded release public key to verify the signature of the executable
+	// downloaded through a remote repository. Organizations which publish their own builds to the remote repository sign them with their own key.
+	// It isn't used for executables downloaded from https://releases.jfrog.io, so it can't replace the key which JFrog's releases are trusted with.
+	PublicKeyEnv = "JFROG_CLI_SELF_UPDATE_PUBLIC_KEY"
+
+	// The path of the JFrog CLI executables in the Artifactory of https://releases.jfrog.io.
+	releasesPath = "jfrog-cli/v2-jf"
+)
+
+// The PEM encoded public key of the key which signs the JFrog CLI executables.
+// Builds which don't embed it refuse to update from https://releases.jfrog.io.
+//
+//go:embed release-public-key.pem
+var releasePublicKey []byte
+
+// Overridden by tests.
+var releasesUrl = "https://releases.jfrog.io/artifactory/"
+
+type Release struct {
+	Version    string
+	Prerelease bool
+}
+
+type executableDetails struct {
+	downloadUrl  string
+	sha256       string
+	signatureUrl string
+}
+
+// Lists the JFrog CLI releases, and provides the download details of their executables for the current OS and architecture.
+// The releases are downloaded from https://releases.jfrog.io, or through a remote repository in Artifactory, which proxies it.
+type releasesSource struct {
+	client      *jfroghttpclient.JfrogHttpClient
+	httpDetails httputils.HttpClientDetails
+	// The Artifactory URL, with a trailing slash.
+	url string
+	// The path of the releases folder in Artifactory.
+	releasesPath string
+	// The remote repository, or an empty string if the releases are downloaded from https://releases.jfrog.io.
+	repo string
+}
+
+// Returns the source configured by the JFROG_CLI_RELEASES_REPO environment variable, or https://releases.jfrog.io if it isn't set.
+func getReleasesSource() (*releasesSource, error) {
+	server, repo, err := dependencies.GetRemoteDetails(coreutils.ReleasesRemoteEnv)
+	if err != nil {
+		return nil, err
+	}
+	if server == nil {
+		client, err := jfroghttpclient.JfrogClientBuilder().SetHttpClient(httptransport.NewClient()).Build()
+		if err != nil {
+			return nil, err
+		}
+		return &releasesSource{client: client, url: releasesUrl, releasesPath: releasesPath}, nil
+	}
+	client, httpDetails, err := dependencies.CreateHttpClient(server)
+	if err != nil {
+		return nil, err
+	}
+	// The remote repository proxies https://releases.jfrog.io, rather than its Artifactory.
+	return &releasesSource{
+		client:       client,
+		httpDetails:  httpDetails,
+		url:          clientUtils.AddTrailingSlashIfNeeded(server.ArtifactoryUrl),
+		releasesPath: path.Join(repo, "artifactory", releasesPath),
+		repo:         repo,
+	}, nil
+}
+
+// Returns the latest release in the channel.
+func GetLatestVersion(releases []Release, channel string) (string, error) {
+	latest := ""
+	for _, release := range releases {
+		if release.Prerelease && channel != RcChannel {
+			continue
+		}
+		if latest == "" || compareVersions(release.Version, latest) > 0 {
+			latest = release.Version
+		}
+	}
+	if latest == "" {
+		return "", errorutils.CheckErrorf("no JFrog CLI release was found in the '%s' channel", channel)
+	}
+	return latest, nil
+}
+
+// Returns a positive number if version1 is newer than version2, a negative number if it's older, or 0 if they're equal.
+// Unlike gofrog's version comparison, a release candidate is older than the release itself.
+func compareVersions(version1, version2 string) int {
+	number1, prerelease1, _ := strings.Cut(version1, "-")
+	number2, prerelease2, _ := strings.Cut(version2, "-")
+	// gofrog's Compare returns a positive number if its argument is newer.
+	if compare := version.NewVersion(number2).Compare(number1); compare != 0 {
+		return compare
+	}
+	switch {
+	case prerelease1 == prerelease2:
+		return 0
+	case prerelease1 == "":
+		return 1
+	case prerelease2 == "":
+		return -1
+	}
+	return strings.Compare(prerelease1, prerelease2)
+}
+
+func isPrerelease(version string) bool {
+	return strings.Contains(version, "-")
+}
+
+// Returns the platform name used by the JFrog CLI releases, such as 'linux-amd64' or 'mac-arm64'.
+func getPlatform() (string, error) {
+	switch runtime.GOOS {
+	case "linux":
+		switch runtime.GOARCH {
+		case "386", "amd64", "arm64", "arm", "s390x", "ppc64", "ppc64le":
+			return "linux-" + runtime.GOARCH, nil
+		}
+	case "darwin":
+		switch runtime.GOARCH {
+		case "amd64":
+			// The Intel executables are released under this name.
+			return "mac-386", nil
+		case "arm64":
+			return "mac-arm64", nil
+		}
+	case "windows":
+		if runtime.GOARCH == "amd64" {
+			return "windows-amd64", nil
+		}
+	}
+	return "", errorutils.CheckErrorf("JFrog CLI isn't released for %s/%s", runtime.GOOS, runtime.GOARCH)
+}
+
+func getExecutableName() string {
+	if coreutils.IsWindows() {
+		return "jf.exe"
+	}
+	return "jf"
+}
+
+// Sends a GET request, and returns the response body, or nil if it wasn't found.
+func (source *releasesSource) sendGet(url string) ([]byte, error) {
+	log.Debug("Sending GET request to:", url)
+	resp, body, _, err := source.client.SendGet(url, true, &source.httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func (source *releasesSource) String() string {
+	if source.repo == "" {
+		return "https://releases.jfrog.io"
+	}
+	return fmt.Sprintf("the '%s' repository", source.repo)
+}
+
+type storageFolder struct {
+	Children []struct {
+		Uri    string `json:"uri,omitempty"`
+		Folder bool   `json:"folder,omitempty"`
+	} `json:"children,omitempty"`
+}
+
+// The releases are the subfolders of the releases folder, which are listed by the storage API.
+func (source *releasesSource) getReleases() ([]Release, error) {
+	body, err := source.sendGet(source.url + "api/storage/" + source.releasesPath)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		if source.repo != "" {
+			return nil, errorutils.CheckErrorf("the JFrog CLI releases weren't found in %s. Make sure it proxies https://releases.jfrog.io", source)
+		}
+		return nil, errorutils.CheckErrorf("the JFrog CLI releases weren't found in %s", source)
+	}
+	folder := storageFolder{}
+	if err = json.Unmarshal(body, &folder); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the JFrog CLI releases from %s: %s", source, err.Error())
+	}
+	var releases []Release
+	for _, child := range folder.Children {
+		if child.Folder {
+			releaseVersion := strings.TrimPrefix(child.Uri, "/")
+			releases = append(releases, Release{Version: releaseVersion, Prerelease: isPrerelease(releaseVersion)})
+		}
+	}
+	return releases, nil
+}
+
+// The executables are released as '<version>/jfrog-cli-<platform>/jf', with their detached signatures next to them.
+func (source *releasesSource) getExecutableDetails(version string) (*executableDetails, error) {
+	platform, err := getPlatform()
+	if err != nil {
+		return nil, err
+	}
+	downloadUrl := source.url + path.Join(source.releasesPath, version, "jfrog-cli-"+platform, getExecutableName())
+	remoteFileDetails, _, err := source.client.GetRemoteFileDetails(downloadUrl, &source.httpDetails)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting the details of JFrog CLI version %s from %s: %w", version, source, err)
+	}
+	return &executableDetails{downloadUrl: downloadUrl, sha256: remoteFileDetails.Checksum.Sha256, signatureUrl: downloadUrl + commandsUtils.SignatureFileExtension}, nil
+}
+
+// Downloads the executable next to the executable it replaces, so it can be moved in its place atomically, and verifies it.
+// Returns the path of the verified executable.
+func downloadExecutable(source *releasesSource, details *executableDetails, executablePath string) (downloadedPath string, err error) {
+	localPath, executableName := filepath.Split(executablePath)
+	downloadFileDetails := &httpclient.DownloadFileDetails{
+		FileName:      executableName,
+		DownloadPath:  details.downloadUrl,
+		LocalPath:     localPath,
+		LocalFileName: "." + executableName + ".new",
+	}
+	downloadedPath = filepath.Join(localPath, downloadFileDetails.LocalFileName)
+	defer func() {
+		// Never leave an unverified executable behind.
+		if err != nil {
+			if removeErr := os.Remove(downloadedPath); removeErr != nil && !os.IsNotExist(removeErr) {
+				err = errors.Join(err, errorutils.CheckError(removeErr))
+			}
+		}
+	}()
+	log.Info("Downloading JFrog CLI from", details.downloadUrl)
+	resp, err := source.client.DownloadFile(downloadFileDetails, "", &source.httpDetails, false, false)
+	if err != nil {
+		return
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		return
+	}
+	log.Debug("Verifying the executable's sha256 checksum...")
+	if err = verifyExecutableSha256(source, downloadedPath, details.sha256); err != nil {
+		return
+	}
+	if err = verifyExecutableSignature(source, downloadedPath, details.signatureUrl); err != nil {
+		return
+	}
+	err = errorutils.CheckError(os.Chmod(downloadedPath, 0755))
+	return
+}
+
+func verifyExecutableSha256(source *releasesSource, executablePath, expectedSha256 string) error {
+	if expectedSha256 == "" {
+		return errorutils.CheckErrorf("%s didn't report the sha256 checksum of the JFrog CLI executable", source)
+	}
+	actualSha256, err := commandsUtils.CalcSha256(executablePath)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(actualSha256, expectedSha256) {
+		return errorutils.CheckErrorf("the downloaded JFrog CLI executable is corrupted. Its sha256 checksum is '%s', while %s reported '%s'", actualSha256, source, expectedSha256)
+	}
+	return nil
+}
+
+// The signature is verified with the embedded release public key, or with the public key provided by the JFROG_CLI_SELF_UPDATE_PUBLIC_KEY environment variable
+// if the executable is downloaded through a remote repository. Executables without a valid signature are refused.
+func verifyExecutableSignature(source *releasesSource, executablePath, signatureUrl string) error {
+	signature, err := source.sendGet(signatureUrl)
+	if err != nil {
+		return err
+	}
+	if len(signature) == 0 {
+		return errorutils.CheckErrorf("the JFrog CLI executable isn't signed. Its signature wasn't found at %s", signatureUrl)
+	}
+	log.Debug("Verifying the executable's signature...")
+	if publicKeyPath := os.Getenv(PublicKeyEnv); publicKeyPath != "" {
+		if source.repo == "" {
+			return errorutils.CheckErrorf("the %s environment variable can be used only with executables downloaded through the %s remote repository. Executables downloaded from https://releases.jfrog.io are verified with the embedded release public key", PublicKeyEnv, coreutils.ReleasesRemoteEnv)
+		}
+		return commandsUtils.VerifyFileSignature(executablePath, string(signature), publicKeyPath)
+	}
+	if len(bytes.TrimSpace(releasePublicKey)) == 0 {
+		return errorutils.CheckErrorf("the executable's signature can't be verified, since this JFrog CLI build doesn't embed the release public key")
+	}
+	return commandsUtils.VerifyFileSignatureWithKey(executablePath, string(signature), releasePublicKey, "the embedded release public key")
+}
+
+// Moves the new executable in place of the running executable.
+// Windows doesn't allow replacing a running executable, but allows renaming it, so it's renamed first. The renamed executable is removed by the next update.
+func replaceExecutable(executablePath, newExecutablePath string) error {
+	if !coreutils.IsWindows() {
+		return errorutils.CheckError(os.Rename(newExecutablePath, executablePath))
+	}
+	oldExecutablePath := executablePath + ".old"
+	if err := os.Remove(oldExecutablePath); err != nil && !os.IsNotExist(err) {
+		return errorutils.CheckError(err)
+	}
+	if err := os.Rename(executablePath, oldExecutablePath); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err := os.Rename(newExecutablePath, executablePath); err != nil {
+		return errors.Join(errorutils.CheckError(err), errorutils.CheckError(os.Rename(oldExecutablePath, executablePath)))
+	}
+	return nil
+}
+
+// Returns the path of the running executable, after resolving symbolic links, so the link's target is replaced rather than the link.
+func getExecutablePath() (string, error) {
+	executablePath, err := os.Executable()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	executablePath, err = filepath.EvalSymlinks(executablePath)
+	return executablePath, errorutils.CheckError(err)
+}
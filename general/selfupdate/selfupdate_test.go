@@ -0,0 +1,198 @@
+package selfupdate
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	commandsUtils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareVersions(t *testing.T) {
+	assert.Positive(t, compareVersions("2.10.0", "2.9.1"))
+	assert.Negative(t, compareVersions("2.60.0-rc1", "2.60.0"))
+	assert.Positive(t, compareVersions("2.60.0-rc2", "2.60.0-rc1"))
+	assert.Positive(t, compareVersions("2.61.0-rc1", "2.60.0"))
+	assert.Zero(t, compareVersions("2.60.0", "2.60.0"))
+}
+
+func TestGetLatestVersion(t *testing.T) {
+	releases := []Release{{Version: "2.59.0"}, {Version: "2.61.0-rc1", Prerelease: true}, {Version: "2.60.1"}, {Version: "2.9.0"}}
+	latest, err := GetLatestVersion(releases, StableChannel)
+	require.NoError(t, err)
+	assert.Equal(t, "2.60.1", latest)
+	latest, err = GetLatestVersion(releases, RcChannel)
+	require.NoError(t, err)
+	assert.Equal(t, "2.61.0-rc1", latest)
+	_, err = GetLatestVersion([]Release{{Version: "2.61.0-rc1", Prerelease: true}}, StableChannel)
+	assert.ErrorContains(t, err, "no JFrog CLI release was found in the 'stable' channel")
+}
+
+type testRelease struct {
+	executable []byte
+	// Overrides the executable's actual checksum.
+	sha256    string
+	signature string
+}
+
+// Serves the releases folder in the Artifactory layout of https://releases.jfrog.io.
+func createReleasesServer(t *testing.T, folderPath string, releases map[string]testRelease) *httptest.Server {
+	platform, err := getPlatform()
+	require.NoError(t, err)
+	executableSuffix := "/jfrog-cli-" + platform + "/" + getExecutableName()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/storage/"+folderPath {
+			folder := `{"children": [{"uri": "/index.json", "folder": false}`
+			for releaseVersion := range releases {
+				folder += fmt.Sprintf(`, {"uri": "/%s", "folder": true}`, releaseVersion)
+			}
+			_, err := w.Write([]byte(folder + "]}"))
+			assert.NoError(t, err)
+			return
+		}
+		releaseVersion, file, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"+folderPath+"/"), "/")
+		release, exists := releases[releaseVersion]
+		var content string
+		switch {
+		case exists && "/"+file == executableSuffix:
+			w.Header().Set("X-Checksum-Sha256", release.sha256)
+			content = string(release.executable)
+		case exists && release.signature != "" && "/"+file == executableSuffix+commandsUtils.SignatureFileExtension:
+			content = release.signature
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			_, err := w.Write([]byte(content))
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// Creates a release of the executable, signed with a new key, and returns the public key's path.
+func createSignedRelease(t *testing.T, executable []byte) (testRelease, string) {
+	tempDir := t.TempDir()
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	privateKeyPath := filepath.Join(tempDir, "private.pem")
+	require.NoError(t, os.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyBytes}), 0600))
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	publicKeyPath := filepath.Join(tempDir, "public.pem")
+	require.NoError(t, os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}), 0600))
+
+	executablePath := filepath.Join(tempDir, "jf")
+	require.NoError(t, os.WriteFile(executablePath, executable, 0755))
+	sha256, err := commandsUtils.CalcSha256(executablePath)
+	require.NoError(t, err)
+	signature, err := commandsUtils.SignFile(executablePath, privateKeyPath)
+	require.NoError(t, err)
+	return testRelease{executable: executable, sha256: sha256, signature: signature}, publicKeyPath
+}
+
+func TestUpdateFromReleases(t *testing.T) {
+	release, publicKeyPath := createSignedRelease(t, []byte("new executable"))
+	tampered := release
+	tampered.executable = []byte("tampered executable")
+	unsigned := release
+	unsigned.signature = ""
+	server := createReleasesServer(t, releasesPath, map[string]testRelease{"2.60.0": release, "2.61.0-rc1": tampered, "2.59.0": unsigned})
+	previousUrl := releasesUrl
+	releasesUrl = server.URL + "/"
+	defer func() {
+		releasesUrl = previousUrl
+	}()
+	t.Setenv(coreutils.ReleasesRemoteEnv, "")
+	t.Setenv(PublicKeyEnv, "")
+	previousPublicKey := releasePublicKey
+	defer func() {
+		releasePublicKey = previousPublicKey
+	}()
+	var err error
+	releasePublicKey, err = os.ReadFile(publicKeyPath)
+	require.NoError(t, err)
+	source, err := getReleasesSource()
+	require.NoError(t, err)
+	executablePath := filepath.Join(t.TempDir(), "jf")
+
+	// The check reports the latest version in the channel.
+	updateCheck, err := newUpdateCommand(source, "2.59.0").Check()
+	require.NoError(t, err)
+	assert.Equal(t, UpdateCheck{CurrentVersion: "2.59.0", TargetVersion: "2.60.0", Channel: StableChannel, UpdateAvailable: true}, *updateCheck)
+	updateCheck, err = newUpdateCommand(source, "2.60.0").Check()
+	require.NoError(t, err)
+	assert.False(t, updateCheck.UpdateAvailable)
+
+	// An executable which fails the verification doesn't replace the current executable, and isn't left behind.
+	assertFailedUpdate := func(command *updateCommand, expectedError string) {
+		require.NoError(t, os.WriteFile(executablePath, []byte("current executable"), 0755))
+		assert.ErrorContains(t, command.Run(executablePath), expectedError)
+		content, err := os.ReadFile(executablePath)
+		require.NoError(t, err)
+		assert.Equal(t, "current executable", string(content))
+		entries, err := os.ReadDir(filepath.Dir(executablePath))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	}
+	assertFailedUpdate(newUpdateCommand(source, "2.59.0").SetChannel(RcChannel), "the downloaded JFrog CLI executable is corrupted")
+	assertFailedUpdate(newUpdateCommand(source, "2.60.0").SetVersion("v2.59.0"), "the JFrog CLI executable isn't signed")
+	assertFailedUpdate(newUpdateCommand(source, "2.59.0").SetVersion("2.58.0"), "failed getting the details of JFrog CLI version 2.58.0 from https://releases.jfrog.io")
+
+	// The public key of the environment variable can't replace the embedded release public key of https://releases.jfrog.io.
+	t.Setenv(PublicKeyEnv, publicKeyPath)
+	assertFailedUpdate(newUpdateCommand(source, "2.59.0"), "can be used only with executables downloaded through the")
+	t.Setenv(PublicKeyEnv, "")
+
+	// Without the embedded release public key, the signature can't be verified, so the update is refused.
+	embeddedPublicKey := releasePublicKey
+	releasePublicKey = nil
+	assertFailedUpdate(newUpdateCommand(source, "2.59.0"), "doesn't embed the release public key")
+
+	// The executable signed by the embedded release public key's private key replaces the current executable.
+	releasePublicKey = embeddedPublicKey
+	require.NoError(t, newUpdateCommand(source, "2.59.0").Run(executablePath))
+	content, err := os.ReadFile(executablePath)
+	require.NoError(t, err)
+	assert.Equal(t, "new executable", string(content))
+}
+
+func TestUpdateFromRemoteRepository(t *testing.T) {
+	release, publicKeyPath := createSignedRelease(t, []byte("new executable"))
+	folderPath := path.Join("releases-remote", "artifactory", releasesPath)
+	server := createReleasesServer(t, folderPath, map[string]testRelease{"2.59.0": release, "2.60.0": release, "2.61.0-rc1": release})
+	client, err := jfroghttpclient.JfrogClientBuilder().Build()
+	require.NoError(t, err)
+	source := &releasesSource{client: client, url: server.URL + "/", releasesPath: folderPath, repo: "releases-remote"}
+	t.Setenv(PublicKeyEnv, publicKeyPath)
+
+	updateCheck, err := newUpdateCommand(source, "2.59.0").SetChannel(RcChannel).Check()
+	require.NoError(t, err)
+	assert.Equal(t, "2.61.0-rc1", updateCheck.TargetVersion)
+
+	localExecutablePath := filepath.Join(t.TempDir(), "jf")
+	require.NoError(t, os.WriteFile(localExecutablePath, []byte("current executable"), 0755))
+	require.NoError(t, newUpdateCommand(source, "2.59.0").Run(localExecutablePath))
+	content, err := os.ReadFile(localExecutablePath)
+	require.NoError(t, err)
+	assert.Equal(t, "new executable", string(content))
+
+	assert.ErrorContains(t, newUpdateCommand(source, "2.60.0").SetVersion("2.58.0").Run(localExecutablePath), "failed getting the details of JFrog CLI version 2.58.0 from the 'releases-remote' repository")
+}
@@ -0,0 +1,44 @@
+package shell
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/urfave/cli"
+)
+
+func ShellCmd(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	session := NewSession(c.App, runInChildProcess)
+	historyPath, err := getHistoryPath()
+	if err != nil {
+		return err
+	}
+	if err = session.SetHistoryPath(historyPath); err != nil {
+		return err
+	}
+	return session.Run()
+}
+
+// Runs the command in a child process, which the terminal interrupts on Ctrl+C, rather than the session.
+// The child process inherits the session's context through its environment variables.
+func runInChildProcess(_ *cli.App, args []string) error {
+	executablePath, err := os.Executable()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	cmd := exec.Command(executablePath, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// The command already logged its error.
+		return nil
+	}
+	return errorutils.CheckError(err)
+}
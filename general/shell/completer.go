@@ -0,0 +1,121 @@
+package shell
+
+import (
+	"strings"
+
+	"github.com/c-bata/go-prompt"
+	"github.com/urfave/cli"
+)
+
+const serverIdFlag = "--server-id"
+
+func (session *Session) complete(document prompt.Document) []prompt.Suggest {
+	return session.getSuggestions(document.TextBeforeCursor())
+}
+
+// Suggests the subcommands, flags and server IDs which complete the last word of the text.
+func (session *Session) getSuggestions(text string) []prompt.Suggest {
+	words := strings.Fields(text)
+	currentWord := ""
+	if len(words) > 0 && !strings.HasSuffix(text, " ") {
+		currentWord = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+	if len(words) > 0 && words[0] == "jf" {
+		words = words[1:]
+	}
+	var suggestions []prompt.Suggest
+	switch {
+	case len(words) == 0:
+		suggestions = append(getBuiltinSuggestions(), getCommandSuggestions(session.app.Commands)...)
+	case words[0] == useCommand:
+		suggestions = session.getUseSuggestions(words[1:])
+	case strings.HasPrefix(currentWord, serverIdFlag+"="):
+		for _, serverId := range session.getServerIds() {
+			suggestions = append(suggestions, prompt.Suggest{Text: serverIdFlag + "=" + serverId})
+		}
+	case words[len(words)-1] == serverIdFlag:
+		suggestions = session.getServerIdSuggestions()
+	default:
+		command, commands := findLastCommand(session.app.Commands, words)
+		if strings.HasPrefix(currentWord, "-") && command != nil {
+			suggestions = getFlagSuggestions(command.Flags)
+		} else {
+			suggestions = getCommandSuggestions(commands)
+		}
+	}
+	return prompt.FilterHasPrefix(suggestions, currentWord, true)
+}
+
+func getBuiltinSuggestions() []prompt.Suggest {
+	return []prompt.Suggest{
+		{Text: useCommand, Description: "Set or clear the context of the following commands"},
+		{Text: contextCommand, Description: "Show the context of the following commands"},
+		{Text: exitCommand, Description: "End the session"},
+	}
+}
+
+func (session *Session) getUseSuggestions(args []string) []prompt.Suggest {
+	switch {
+	case len(args) == 0:
+		var suggestions []prompt.Suggest
+		for _, key := range contextKeys {
+			suggestions = append(suggestions, prompt.Suggest{Text: key.name, Description: key.description})
+		}
+		return suggestions
+	case len(args) == 1 && args[0] == "server":
+		return session.getServerIdSuggestions()
+	}
+	return nil
+}
+
+func (session *Session) getServerIdSuggestions() []prompt.Suggest {
+	var suggestions []prompt.Suggest
+	for _, serverId := range session.getServerIds() {
+		suggestions = append(suggestions, prompt.Suggest{Text: serverId})
+	}
+	return suggestions
+}
+
+// Returns the last command in the words, which may be a command namespace, and the commands which may follow it.
+func findLastCommand(commands []cli.Command, words []string) (*cli.Command, []cli.Command) {
+	var lastCommand *cli.Command
+	for _, word := range words {
+		command := findCommand(commands, word)
+		if command == nil {
+			break
+		}
+		lastCommand, commands = command, command.Subcommands
+	}
+	if lastCommand == nil {
+		return nil, nil
+	}
+	return lastCommand, commands
+}
+
+func getCommandSuggestions(commands []cli.Command) []prompt.Suggest {
+	var suggestions []prompt.Suggest
+	for _, command := range commands {
+		if !command.Hidden {
+			suggestions = append(suggestions, prompt.Suggest{Text: command.Name, Description: command.Usage})
+		}
+	}
+	return suggestions
+}
+
+// Flags which take a value are suggested with '=', so their value is completed next.
+func getFlagSuggestions(flags []cli.Flag) []prompt.Suggest {
+	var suggestions []prompt.Suggest
+	for _, flag := range flags {
+		name := "--" + strings.TrimSpace(strings.Split(flag.GetName(), ",")[0])
+		suggestion := prompt.Suggest{Text: name}
+		if docFlag, ok := flag.(cli.DocGenerationFlag); ok {
+			if docFlag.TakesValue() {
+				suggestion.Text += "="
+			}
+			suggestion.Description = strings.TrimSuffix(docFlag.GetUsage(), "` `")
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions
+}
@@ -0,0 +1,340 @@
+package shell
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/c-bata/go-prompt"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/general/batch"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+	"golang.org/x/exp/slices"
+	"golang.org/x/term"
+)
+
+const (
+	historyFileName = "shell_history"
+	// The number of commands kept in the history file.
+	maxHistorySize = 1000
+
+	useCommand     = "use"
+	contextCommand = "context"
+	exitCommand    = "exit"
+	quitCommand    = "quit"
+)
+
+// Runs a jf command.
+type CommandRunner func(app *cli.App, args []string) error
+
+var (
+	// The values of these flags are redacted from the history, such as in '--password=<value>', '--access-token <value>' or 'rt curl -u <user>:<password>'.
+	// These flags always take a value, so the value is redacted even if it starts with '-'.
+	secretFlagPattern = regexp.MustCompile(`(^|\s)(--?(?:password|access-token|refresh-token|api-key|ssh-passphrase|passphrase|user|oauth2-bearer)|-u)(=|\s+)("[^"]*"|'[^']*'|\S+)`)
+	// The values of credential headers, such as in 'rt curl -H "Authorization: Bearer <token>"'.
+	quotedSecretHeaderPattern = regexp.MustCompile(`(?i)(["'])((?:authorization|proxy-authorization|x-jfrog-art-api|cookie)\s*:)[^"']*`)
+	secretHeaderPattern       = regexp.MustCompile(`(?i)(^|\s|=)((?:authorization|proxy-authorization|x-jfrog-art-api|cookie)\s*:)\S*`)
+	// The credentials in URLs, such as in 'https://<user>:<password>@acme.jfrog.io'.
+	urlCredentialsPattern = regexp.MustCompile(`(://[^/\s:@]*:)[^/\s@]+@`)
+)
+
+// A context value, which is applied to the following commands by an environment variable, so both JFrog CLI and its embedded plugins use it.
+type contextKey struct {
+	name        string
+	env         string
+	description string
+}
+
+var contextKeys = []contextKey{
+	{name: "server", env: coreutils.ServerID, description: "Server ID configured using the 'jf config' command"},
+	{name: "project", env: coreutils.Project, description: "JFrog project key"},
+	{name: "build", env: coreutils.BuildName, description: "Build name"},
+}
+
+func getContextKey(name string) *contextKey {
+	for i := range contextKeys {
+		if contextKeys[i].name == name {
+			return &contextKeys[i]
+		}
+	}
+	return nil
+}
+
+// An interactive session, which runs jf commands until it's exited.
+type Session struct {
+	app        *cli.App
+	runCommand CommandRunner
+	// The environment variables of the context keys when the session started, which are restored when a context value is cleared.
+	initialEnv  map[string]*string
+	historyPath string
+	history     []string
+	// The configured server IDs, which are read once per command, since the command may change them.
+	serverIds []string
+	exited    bool
+}
+
+func NewSession(app *cli.App, runCommand CommandRunner) *Session {
+	session := &Session{app: app, runCommand: runCommand, initialEnv: make(map[string]*string)}
+	for _, key := range contextKeys {
+		if value, exists := os.LookupEnv(key.env); exists {
+			session.initialEnv[key.env] = &value
+		}
+	}
+	return session
+}
+
+// Reads the history of the previous sessions, and appends the commands of this session to it.
+func (session *Session) SetHistoryPath(historyPath string) error {
+	session.historyPath = historyPath
+	content, err := os.ReadFile(historyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errorutils.CheckError(err)
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if line != "" {
+			session.history = append(session.history, line)
+		}
+	}
+	return nil
+}
+
+func getHistoryPath() (string, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, historyFileName), nil
+}
+
+// Replaces the values of the secret flags, the credential headers and the credentials in URLs in the line with asterisks.
+func redactSecrets(line string) string {
+	line = secretFlagPattern.ReplaceAllString(line, "$1$2$3***")
+	line = quotedSecretHeaderPattern.ReplaceAllString(line, "$1$2 ***")
+	line = secretHeaderPattern.ReplaceAllString(line, "$1$2***")
+	return urlCredentialsPattern.ReplaceAllString(line, "$1***@")
+}
+
+func (session *Session) addToHistory(line string) {
+	line = redactSecrets(line)
+	if len(session.history) > 0 && session.history[len(session.history)-1] == line {
+		return
+	}
+	session.history = append(session.history, line)
+	if len(session.history) > maxHistorySize {
+		session.history = session.history[len(session.history)-maxHistorySize:]
+	}
+	if session.historyPath == "" {
+		return
+	}
+	// The history file is written after each command, so it's kept even if the session is killed.
+	if err := os.WriteFile(session.historyPath, []byte(strings.Join(session.history, "\n")+"\n"), 0600); err != nil {
+		log.Debug("Failed writing the shell history:", err.Error())
+	}
+}
+
+// Runs the session on a terminal, with completion and history. Otherwise, the commands are read line by line.
+func (session *Session) Run() error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return session.runLines(os.Stdin)
+	}
+	log.Output(fmt.Sprintf("Welcome to the JFrog CLI shell. Run jf commands without the 'jf' prefix, such as 'rt ping'.\n"+
+		"Run '%s server|project|build <value>' to set the context of the following commands, '%s' to show it, and '%s' to end the session.", useCommand, contextCommand, exitCommand))
+	for !session.exited {
+		submitted := false
+		submit := func(*prompt.Buffer) {
+			submitted = true
+		}
+		line := prompt.Input(session.getPrefix(), session.complete,
+			prompt.OptionHistory(session.history),
+			prompt.OptionAddKeyBind(prompt.KeyBind{Key: prompt.Enter, Fn: submit}, prompt.KeyBind{Key: prompt.ControlM, Fn: submit}))
+		// An empty line which wasn't submitted is Ctrl+D.
+		if !submitted {
+			return nil
+		}
+		session.Execute(line)
+	}
+	return nil
+}
+
+func (session *Session) runLines(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	for !session.exited && scanner.Scan() {
+		session.Execute(scanner.Text())
+	}
+	return errorutils.CheckError(scanner.Err())
+}
+
+// The prompt shows the context values which were set in the session.
+func (session *Session) getPrefix() string {
+	var values []string
+	for _, key := range contextKeys {
+		if session.isSetInSession(key) {
+			values = append(values, key.name+"="+os.Getenv(key.env))
+		}
+	}
+	if len(values) == 0 {
+		return "jf> "
+	}
+	return fmt.Sprintf("jf [%s]> ", strings.Join(values, " "))
+}
+
+func (session *Session) isSetInSession(key contextKey) bool {
+	value, exists := os.LookupEnv(key.env)
+	initialValue := session.initialEnv[key.env]
+	if !exists {
+		return false
+	}
+	return initialValue == nil || *initialValue != value
+}
+
+// Runs a line of the session. Failures are logged, and don't end the session.
+func (session *Session) Execute(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return
+	}
+	session.addToHistory(line)
+	if err := session.execute(line); err != nil {
+		log.Error(err)
+	}
+}
+
+func (session *Session) execute(line string) error {
+	args, err := batch.SplitCommandLine(line)
+	if err != nil {
+		return err
+	}
+	// Commands copied from scripts may include the executable name.
+	if len(args) > 0 && args[0] == "jf" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	switch args[0] {
+	case exitCommand, quitCommand:
+		session.exited = true
+		return nil
+	case useCommand:
+		return session.use(args[1:])
+	case contextCommand:
+		session.printContext()
+		return nil
+	case cliutils.CmdShell:
+		return errorutils.CheckErrorf("already in a JFrog CLI shell")
+	}
+	if err = session.checkCommandExists(args); err != nil {
+		return err
+	}
+	// The command may change the configured servers.
+	session.serverIds = nil
+	return session.runInterruptible(args)
+}
+
+// Ctrl+C interrupts the running command, and the session continues.
+func (session *Session) runInterruptible(args []string) error {
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+	err := session.runCommand(session.app, args)
+	select {
+	case <-interrupts:
+		log.Info("The command was interrupted.")
+		return nil
+	default:
+		return err
+	}
+}
+
+// Suggests similar commands for typos, instead of running unknown commands.
+func (session *Session) checkCommandExists(args []string) error {
+	commands, path := session.app.Commands, ""
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return nil
+		}
+		command := findCommand(commands, arg)
+		if command == nil {
+			message := fmt.Sprintf("'%s%s' is not a jf command.", path, arg)
+			if bestSimilarity := cliutils.SearchSimilarCmds(commands, arg); len(bestSimilarity) > 0 {
+				message += " " + cliutils.GetSimilarCmdsMessage(bestSimilarity, path)
+			}
+			return errorutils.CheckError(errors.New(message))
+		}
+		if len(command.Subcommands) == 0 {
+			return nil
+		}
+		commands, path = command.Subcommands, path+command.Name+" "
+	}
+	return nil
+}
+
+func findCommand(commands []cli.Command, name string) *cli.Command {
+	for i := range commands {
+		if commands[i].HasName(name) {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+// Sets or clears a context value.
+func (session *Session) use(args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return errorutils.CheckErrorf("usage: %s server|project|build [<value>]. Without a value, the context value is cleared", useCommand)
+	}
+	key := getContextKey(args[0])
+	if key == nil {
+		return errorutils.CheckErrorf("unknown context key '%s'. Acceptable values are: server, project, build", args[0])
+	}
+	if len(args) == 1 {
+		if initialValue := session.initialEnv[key.env]; initialValue != nil {
+			return errorutils.CheckError(os.Setenv(key.env, *initialValue))
+		}
+		return errorutils.CheckError(os.Unsetenv(key.env))
+	}
+	if key.env == coreutils.ServerID {
+		serverIds := session.getServerIds()
+		if !slices.Contains(serverIds, args[1]) {
+			return errorutils.CheckErrorf("the server ID '%s' isn't configured. Configured server IDs: %s", args[1], strings.Join(serverIds, ", "))
+		}
+	}
+	return errorutils.CheckError(os.Setenv(key.env, args[1]))
+}
+
+func (session *Session) printContext() {
+	for _, key := range contextKeys {
+		value := os.Getenv(key.env)
+		if value == "" {
+			value = "-"
+		}
+		log.Output(fmt.Sprintf("%s: %s", key.name, value))
+	}
+}
+
+func (session *Session) getServerIds() []string {
+	if session.serverIds == nil {
+		session.serverIds = []string{}
+		serversConfigs, err := config.GetAllServersConfigs()
+		if err != nil {
+			log.Debug("Failed reading the configured servers:", err.Error())
+		}
+		for _, serverConfig := range serversConfigs {
+			session.serverIds = append(session.serverIds, serverConfig.ServerId)
+		}
+	}
+	return session.serverIds
+}
@@ -1,6 +1,7 @@
 package token
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	commonCliUtils "github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
@@ -13,6 +14,7 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/urfave/cli"
+	"sort"
 	"strconv"
 )
 
@@ -21,6 +23,12 @@ func AccessTokenCreateCmd(c *cli.Context) error {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
 	}
 
+	// The token is printed as JSON by default, as it always was.
+	format, err := cliutils.GetOutputFormat(c, cliutils.Json)
+	if err != nil {
+		return err
+	}
+
 	serverDetails, err := createPlatformDetailsByFlags(c)
 	if err != nil {
 		return err
@@ -62,9 +70,29 @@ func AccessTokenCreateCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	log.Output(clientUtils.IndentJson(resString))
+	if format == cliutils.Json {
+		log.Output(clientUtils.IndentJson(resString))
+		return nil
+	}
+	return printCreatedToken(resString, format)
+}
 
-	return nil
+// Prints the Access response in formats other than JSON, with the same fields.
+func printCreatedToken(response []byte, format cliutils.OutputFormat) error {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(response, &fields); err != nil {
+		return errorutils.CheckError(err)
+	}
+	var keys []string
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var rows []tokenFieldRow
+	for _, key := range keys {
+		rows = append(rows, tokenFieldRow{Field: key, Value: fmt.Sprint(fields[key])})
+	}
+	return cliutils.PrintCommandOutput(cliutils.CommandOutput{Data: fields, Rows: rows, Title: "Access Token"}, format)
 }
 
 func createPlatformDetailsByFlags(c *cli.Context) (*coreConfig.ServerDetails, error) {
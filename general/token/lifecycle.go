@@ -0,0 +1,251 @@
+package token
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli/utils/accesstoken"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+const (
+	// Reference tokens are base64 encoded, starting with 'reftkn'.
+	referenceTokenPrefix = "cmVmdGtu"
+)
+
+type tokenRow struct {
+	TokenId     string `col-name:"Token ID"`
+	Subject     string `col-name:"Subject"`
+	Description string `col-name:"Description"`
+	IssuedAt    string `col-name:"Issued At"`
+	Expiry      string `col-name:"Expiry"`
+	Refreshable bool   `col-name:"Refreshable"`
+}
+
+type tokenFieldRow struct {
+	Field string `col-name:"Field"`
+	Value string `col-name:"Value"`
+}
+
+// The offline decoded details of an access token, as printed by 'access-token-inspect'.
+type inspectedToken struct {
+	TokenId  string   `json:"tokenId,omitempty" yaml:"tokenId,omitempty"`
+	Subject  string   `json:"subject,omitempty" yaml:"subject,omitempty"`
+	Username string   `json:"username,omitempty" yaml:"username,omitempty"`
+	Scopes   []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	Audience []string `json:"audience,omitempty" yaml:"audience,omitempty"`
+	Issuer   string   `json:"issuer,omitempty" yaml:"issuer,omitempty"`
+	IssuedAt string   `json:"issuedAt,omitempty" yaml:"issuedAt,omitempty"`
+	Expiry   string   `json:"expiry,omitempty" yaml:"expiry,omitempty"`
+	Expired  bool     `json:"expired" yaml:"expired"`
+}
+
+type tokensFilter struct {
+	username    string
+	description string
+	// Tokens which expire after the deadline are filtered out. A zero deadline disables the filter.
+	expiryDeadline time.Time
+}
+
+func AccessTokenListCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	format, err := cliutils.GetOutputFormat(c, cliutils.Table)
+	if err != nil {
+		return err
+	}
+	filter, err := createTokensFilter(c)
+	if err != nil {
+		return err
+	}
+	serverDetails, err := createPlatformDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	tokensService, err := accesstoken.NewTokensService(serverDetails)
+	if err != nil {
+		return err
+	}
+	tokens, err := tokensService.ListTokens()
+	if err != nil {
+		return err
+	}
+	return printTokens(filterTokens(tokens, filter), format)
+}
+
+func AccessTokenInspectCmd(c *cli.Context) error {
+	if c.NArg() > 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	format, err := cliutils.GetOutputFormat(c, cliutils.Table)
+	if err != nil {
+		return err
+	}
+	token := c.Args().Get(0)
+	if token == "" {
+		// Reading the token from the stdin allows inspecting it without exposing it in the shell's history.
+		if token, err = readTokenFromStdin(); err != nil {
+			return err
+		}
+	}
+	inspected, err := inspectToken(token, time.Now())
+	if err != nil {
+		return err
+	}
+	return printInspectedToken(inspected, format)
+}
+
+func AccessTokenRevokeCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	serverDetails, err := createPlatformDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	tokensService, err := accesstoken.NewTokensService(serverDetails)
+	if err != nil {
+		return err
+	}
+	return revokeToken(tokensService, c.Args().Get(0))
+}
+
+// Revokes a token by its ID or by its value. The ID of JWT access tokens is decoded offline.
+func revokeToken(tokensService *accesstoken.TokensService, tokenIdOrValue string) error {
+	if strings.HasPrefix(tokenIdOrValue, referenceTokenPrefix) {
+		if err := tokensService.RevokeTokenByValue(tokenIdOrValue); err != nil {
+			return err
+		}
+		log.Info("The reference token was revoked.")
+		return nil
+	}
+	tokenId := tokenIdOrValue
+	if strings.Count(tokenIdOrValue, ".") == 2 {
+		payload, err := accesstoken.DecodePayload(tokenIdOrValue)
+		if err != nil {
+			return err
+		}
+		tokenId = payload.TokenId
+	}
+	if err := tokensService.RevokeToken(tokenId); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Token '%s' was revoked.", tokenId))
+	return nil
+}
+
+func createTokensFilter(c *cli.Context) (filter tokensFilter, err error) {
+	filter.username = c.String(cliutils.Subject)
+	filter.description = strings.ToLower(c.String(cliutils.Description))
+	if !c.IsSet(cliutils.ExpiresWithin) {
+		return
+	}
+	days, err := strconv.Atoi(c.String(cliutils.ExpiresWithin))
+	if err != nil || days < 0 {
+		return filter, cliutils.PrintHelpAndReturnError(fmt.Sprintf("The '--%s' option must be a non-negative number of days. ", cliutils.ExpiresWithin), c)
+	}
+	filter.expiryDeadline = time.Now().AddDate(0, 0, days)
+	return
+}
+
+func filterTokens(tokens []accesstoken.TokenInfo, filter tokensFilter) (filtered []accesstoken.TokenInfo) {
+	for _, token := range tokens {
+		if filter.username != "" && !strings.HasSuffix(token.Subject, "/users/"+filter.username) {
+			continue
+		}
+		if filter.description != "" && !strings.Contains(strings.ToLower(token.Description), filter.description) {
+			continue
+		}
+		if !filter.expiryDeadline.IsZero() && (token.Expiry == 0 || time.Unix(token.Expiry, 0).After(filter.expiryDeadline)) {
+			continue
+		}
+		filtered = append(filtered, token)
+	}
+	return
+}
+
+func printTokens(tokens []accesstoken.TokenInfo, format cliutils.OutputFormat) error {
+	var rows []tokenRow
+	for _, token := range tokens {
+		rows = append(rows, tokenRow{
+			TokenId:     token.TokenId,
+			Subject:     token.Subject,
+			Description: token.Description,
+			IssuedAt:    formatUnixTime(token.IssuedAt),
+			Expiry:      formatExpiry(token.Expiry),
+			Refreshable: token.Refreshable,
+		})
+	}
+	return cliutils.PrintCommandOutput(cliutils.CommandOutput{Data: tokens, Rows: rows, Title: "Access Tokens", EmptyMessage: "No access tokens were found"}, format)
+}
+
+func inspectToken(token string, now time.Time) (*inspectedToken, error) {
+	payload, err := accesstoken.DecodePayload(token)
+	if err != nil {
+		return nil, err
+	}
+	expiry := payload.GetExpiry()
+	return &inspectedToken{
+		TokenId:  payload.TokenId,
+		Subject:  payload.Subject,
+		Username: payload.GetUsername(),
+		Scopes:   strings.Fields(payload.Scope),
+		Audience: payload.Audience,
+		Issuer:   payload.Issuer,
+		IssuedAt: formatUnixTime(payload.IssuedAt),
+		Expiry:   formatExpiry(payload.ExpirationTime),
+		Expired:  !expiry.IsZero() && expiry.Before(now),
+	}, nil
+}
+
+func printInspectedToken(token *inspectedToken, format cliutils.OutputFormat) error {
+	rows := []tokenFieldRow{
+		{"Token ID", token.TokenId},
+		{"Subject", token.Subject},
+		{"Username", token.Username},
+		{"Scopes", strings.Join(token.Scopes, "\n")},
+		{"Audience", strings.Join(token.Audience, "\n")},
+		{"Issuer", token.Issuer},
+		{"Issued At", token.IssuedAt},
+		{"Expiry", token.Expiry},
+		{"Expired", strconv.FormatBool(token.Expired)},
+	}
+	return cliutils.PrintCommandOutput(cliutils.CommandOutput{Data: token, Rows: rows, Title: "Access Token"}, format)
+}
+
+// Formats Unix times in seconds.
+func formatUnixTime(seconds int64) string {
+	if seconds == 0 {
+		return ""
+	}
+	return time.Unix(seconds, 0).Format(time.RFC3339)
+}
+
+// A zero expiry means that the token never expires.
+func formatExpiry(seconds int64) string {
+	if seconds == 0 {
+		return "Never"
+	}
+	return formatUnixTime(seconds)
+}
+
+func readTokenFromStdin() (string, error) {
+	log.Info("Reading the access token from the standard input...")
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", errorutils.CheckError(err)
+		}
+		return "", errorutils.CheckErrorf("no access token was provided")
+	}
+	return strings.TrimSpace(scanner.Text()), nil
+}
@@ -0,0 +1,99 @@
+package token
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/accesstoken"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createJwt(payload string) string {
+	return "header." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
+
+func TestFilterTokens(t *testing.T) {
+	now := time.Now()
+	tokens := []accesstoken.TokenInfo{
+		{TokenId: "1", Subject: "jfac@01abc/users/admin", Description: "CI token", Expiry: now.AddDate(0, 0, 3).Unix()},
+		{TokenId: "2", Subject: "jfac@01abc/users/admin", Description: "Personal", Expiry: now.AddDate(0, 0, 30).Unix()},
+		{TokenId: "3", Subject: "jfac@01abc/users/bob", Description: "Nightly ci"},
+		{TokenId: "4", Subject: "jfac@01abc/users/superadmin", Expiry: now.AddDate(0, 0, -1).Unix()},
+	}
+	getIds := func(filtered []accesstoken.TokenInfo) (ids []string) {
+		for _, token := range filtered {
+			ids = append(ids, token.TokenId)
+		}
+		return
+	}
+	assert.Equal(t, []string{"1", "2", "3", "4"}, getIds(filterTokens(tokens, tokensFilter{})))
+	assert.Equal(t, []string{"1", "2"}, getIds(filterTokens(tokens, tokensFilter{username: "admin"})))
+	assert.Equal(t, []string{"1", "3"}, getIds(filterTokens(tokens, tokensFilter{description: "ci"})))
+	assert.Equal(t, []string{"1", "4"}, getIds(filterTokens(tokens, tokensFilter{expiryDeadline: now.AddDate(0, 0, 7)})))
+}
+
+func TestInspectToken(t *testing.T) {
+	token := createJwt(`{"sub":"jfac@01abc/users/admin","scp":"applied-permissions/user applied-permissions/groups:readers","aud":"*@*","iss":"jfac@01abc","jti":"token-id","iat":1700000000,"exp":1700003600}`)
+	inspected, err := inspectToken(token, time.Unix(1700000000, 0))
+	require.NoError(t, err)
+	assert.Equal(t, "token-id", inspected.TokenId)
+	assert.Equal(t, "admin", inspected.Username)
+	assert.Equal(t, []string{"applied-permissions/user", "applied-permissions/groups:readers"}, inspected.Scopes)
+	assert.Equal(t, []string{"*@*"}, inspected.Audience)
+	assert.Equal(t, time.Unix(1700003600, 0).Format(time.RFC3339), inspected.Expiry)
+	assert.False(t, inspected.Expired)
+
+	inspected, err = inspectToken(token, time.Unix(1700003601, 0))
+	require.NoError(t, err)
+	assert.True(t, inspected.Expired)
+
+	_, err = inspectToken("cmVmdGtuOjAxOjE3MDAwMDAwMDA6reference", time.Now())
+	assert.Error(t, err)
+}
+
+func TestRevokeToken(t *testing.T) {
+	var revokedIds, revokedValues []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/access/api/v1/tokens/revoke":
+			assert.NoError(t, r.ParseForm())
+			revokedValues = append(revokedValues, r.PostForm.Get("token"))
+		case r.Method == http.MethodDelete:
+			revokedIds = append(revokedIds, r.URL.Path[len("/access/api/v1/tokens/"):])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	tokensService, err := accesstoken.NewTokensService(&config.ServerDetails{Url: server.URL + "/", AccessToken: "admin-token"})
+	require.NoError(t, err)
+
+	assert.NoError(t, revokeToken(tokensService, "token-id"))
+	assert.NoError(t, revokeToken(tokensService, createJwt(`{"jti":"jwt-id"}`)))
+	assert.NoError(t, revokeToken(tokensService, "cmVmdGtuOjAxOjE3MDAwMDAwMDA6reference"))
+	assert.Equal(t, []string{"token-id", "jwt-id"}, revokedIds)
+	assert.Equal(t, []string{"cmVmdGtuOjAxOjE3MDAwMDAwMDA6reference"}, revokedValues)
+}
+
+func TestListTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/access/api/v1/tokens", r.URL.Path)
+		_, err := w.Write([]byte(`{"tokens":[{"token_id":"1","subject":"jfac@01abc/users/admin","description":"CI","issued_at":1700000000,"expiry":1700003600,"refreshable":true}]}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	tokensService, err := accesstoken.NewTokensService(&config.ServerDetails{Url: server.URL + "/", AccessToken: "admin-token"})
+	require.NoError(t, err)
+	tokens, err := tokensService.ListTokens()
+	require.NoError(t, err)
+	assert.Equal(t, []accesstoken.TokenInfo{{TokenId: "1", Subject: "jfac@01abc/users/admin", Description: "CI", IssuedAt: 1700000000, Expiry: 1700003600, Refreshable: true}}, tokens)
+	assert.NoError(t, printTokens(tokens, cliutils.Table))
+	assert.NoError(t, printTokens(nil, cliutils.Json))
+}
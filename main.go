@@ -3,8 +3,8 @@ package main
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
-	"github.com/agnivade/levenshtein"
 	artifactoryCLI "github.com/jfrog/jfrog-cli-artifactory/evidence/cli"
 	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
 	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
@@ -16,14 +16,26 @@ import (
 	"github.com/jfrog/jfrog-cli/artifactory"
 	"github.com/jfrog/jfrog-cli/buildtools"
 	"github.com/jfrog/jfrog-cli/completion"
+	"github.com/jfrog/jfrog-cli/completion/dynamic"
 	"github.com/jfrog/jfrog-cli/config"
+	"github.com/jfrog/jfrog-cli/daemon"
 	"github.com/jfrog/jfrog-cli/distribution"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	aiDocs "github.com/jfrog/jfrog-cli/docs/general/ai"
 	loginDocs "github.com/jfrog/jfrog-cli/docs/general/login"
+	logoutDocs "github.com/jfrog/jfrog-cli/docs/general/logout"
+	runBatchDocs "github.com/jfrog/jfrog-cli/docs/general/runbatch"
+	selfUpdateDocs "github.com/jfrog/jfrog-cli/docs/general/selfupdate"
+	shellDocs "github.com/jfrog/jfrog-cli/docs/general/shell"
 	tokenDocs "github.com/jfrog/jfrog-cli/docs/general/token"
+	tokenInspectDocs "github.com/jfrog/jfrog-cli/docs/general/tokeninspect"
+	tokenListDocs "github.com/jfrog/jfrog-cli/docs/general/tokenlist"
+	tokenRevokeDocs "github.com/jfrog/jfrog-cli/docs/general/tokenrevoke"
 	"github.com/jfrog/jfrog-cli/general/ai"
+	"github.com/jfrog/jfrog-cli/general/batch"
 	"github.com/jfrog/jfrog-cli/general/login"
+	"github.com/jfrog/jfrog-cli/general/selfupdate"
+	"github.com/jfrog/jfrog-cli/general/shell"
 	"github.com/jfrog/jfrog-cli/general/token"
 	"github.com/jfrog/jfrog-cli/lifecycle"
 	"github.com/jfrog/jfrog-cli/missioncontrol"
@@ -31,6 +43,10 @@ import (
 	"github.com/jfrog/jfrog-cli/plugins"
 	"github.com/jfrog/jfrog-cli/plugins/utils"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/httpintercept"
+	"github.com/jfrog/jfrog-cli/utils/jsonlog"
+	"github.com/jfrog/jfrog-cli/utils/overlay"
+	"github.com/jfrog/jfrog-cli/utils/tracing"
 	"github.com/jfrog/jfrog-client-go/http/httpclient"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -66,6 +82,10 @@ var traceID string
 
 func main() {
 	log.SetDefaultLogger()
+	setLogFormat()
+	if exitCode, forwarded := daemon.Forward(os.Args[1:]); forwarded {
+		os.Exit(exitCode)
+	}
 	err := execMain()
 	if cleanupErr := fileutils.CleanOldDirs(); cleanupErr != nil {
 		clientlog.Warn(cleanupErr)
@@ -73,9 +93,18 @@ func main() {
 	coreutils.ExitOnErr(err)
 }
 
-func execMain() error {
+func execMain() (err error) {
 	// Set JFrog CLI's user-agent on the jfrog-client-go.
 	clientutils.SetUserAgent(coreutils.GetCliUserAgent())
+	interception, err := httpintercept.Start(os.Args[1:])
+	if err != nil {
+		return err
+	}
+	if interception != nil {
+		defer func() {
+			err = errors.Join(err, interception.Stop())
+		}()
+	}
 
 	app := cli.NewApp()
 	app.Name = jfrogAppName
@@ -84,13 +113,14 @@ func execMain() error {
 	args := os.Args
 	cliutils.SetCliExecutableName(args[0])
 	app.EnableBashCompletion = true
+	app.Flags = cliutils.GetGlobalFlags()
 	commands, err := getCommands()
 	if err != nil {
 		clientlog.Error(err)
 		os.Exit(1)
 	}
 	sort.Slice(commands, func(i, j int) bool { return commands[i].Name < commands[j].Name })
-	app.Commands = commands
+	app.Commands = dynamic.WrapCommandsWithDynamicCompletion(overlay.WrapCommandsWithDefaults(commands))
 	cli.CommandHelpTemplate = commandHelpTemplate
 	cli.AppHelpTemplate = getAppHelpTemplate()
 	app.CommandNotFound = func(c *cli.Context, command string) {
@@ -99,15 +129,8 @@ func execMain() error {
 			clientlog.Debug(err)
 			os.Exit(1)
 		}
-		if bestSimilarity := searchSimilarCmds(c.App.Commands, command); len(bestSimilarity) > 0 {
-			text := "The most similar "
-			if len(bestSimilarity) == 1 {
-				text += "command is:\n\tjf " + bestSimilarity[0]
-			} else {
-				sort.Strings(bestSimilarity)
-				text += "commands are:\n\tjf " + strings.Join(bestSimilarity, "\n\tjf ")
-			}
-			_, err = fmt.Fprintln(c.App.Writer, text)
+		if bestSimilarity := cliutils.SearchSimilarCmds(c.App.Commands, command); len(bestSimilarity) > 0 {
+			_, err = fmt.Fprintln(c.App.Writer, cliutils.GetSimilarCmdsMessage(bestSimilarity, "jf "))
 			if err != nil {
 				clientlog.Debug(err)
 			}
@@ -115,6 +138,9 @@ func execMain() error {
 		os.Exit(1)
 	}
 	app.Before = func(ctx *cli.Context) error {
+		if jsonLogger := jsonlog.GetLogger(); jsonLogger != nil {
+			jsonLogger.SetContext(jsonlog.GetCommandPath(app.Commands, ctx.Args()), jsonlog.GetServerId(ctx.Args()))
+		}
 		clientlog.Debug("JFrog CLI version:", app.Version)
 		clientlog.Debug("OS/Arch:", runtime.GOOS+"/"+runtime.GOARCH)
 		warningMessage, err := cliutils.CheckNewCliVersionAvailable(app.Version)
@@ -124,16 +150,69 @@ func execMain() error {
 		if warningMessage != "" {
 			clientlog.Warn(warningMessage)
 		}
+		if err = applyOverlay(); err != nil {
+			return err
+		}
+		if err = cliutils.ExportGlobalOutputFormat(ctx); err != nil {
+			return err
+		}
+		login.CleanupEphemeralServers()
+		config.WarnExpiringTokens()
 		if err = setUberTraceIdToken(); err != nil {
 			clientlog.Warn("failed generating a trace ID token:", err.Error())
 		}
+		// The daemon itself isn't traced, and the commands whose HTTP requests are traced are run without it.
+		if commandPath := jsonlog.GetCommandPath(app.Commands, ctx.Args()); commandPath != daemon.ServeCommandPath {
+			if err = tracing.Start(commandPath, traceID, app.Version); err != nil {
+				clientlog.Warn("failed starting the command's trace:", err.Error())
+			}
+		}
 		return nil
 	}
 	err = app.Run(args)
+	// The ephemeral servers whose token expired while the command ran are removed too.
+	login.CleanupEphemeralServers()
+	tracing.Shutdown(err)
+	logTraceIdOnFailure(err)
+	return err
+}
+
+// Runs a command in a process which runs many commands, such as the daemon. The checks which are done once per process
+// were done when the process started, but the overlay, the log format, the ephemeral servers and the tokens expiry
+// depend on the command's working directory and environment variables.
+// The commands whose HTTP requests are traced aren't sent to the daemon, so the command isn't traced.
+func runCommandInProcess(app *cli.App, args []string) (err error) {
+	setLogFormat()
+	overlay.Reset()
+	if err = applyOverlay(); err != nil {
+		return err
+	}
+	commandPath := jsonlog.GetCommandPath(app.Commands, args)
+	if jsonLogger := jsonlog.GetLogger(); jsonLogger != nil {
+		jsonLogger.SetContext(commandPath, jsonlog.GetServerId(args))
+	}
+	login.CleanupEphemeralServers()
+	defer login.CleanupEphemeralServers()
+	config.WarnExpiringTokens()
+	if err = setUberTraceIdToken(); err != nil {
+		clientlog.Warn("failed generating a trace ID token:", err.Error())
+	}
+	_, err = batch.ExecCommand(app, args, false)
 	logTraceIdOnFailure(err)
 	return err
 }
 
+// Replaces the default logger with the JSON logger, if requested by the JFROG_CLI_LOG_FORMAT environment variable.
+func setLogFormat() {
+	switch logFormat := os.Getenv(jsonlog.LogFormatEnv); strings.ToLower(logFormat) {
+	case "", jsonlog.TextFormat:
+	case jsonlog.JsonFormat:
+		clientlog.SetLogger(jsonlog.NewLogger(log.GetCliLogLevel()))
+	default:
+		clientlog.Warn(fmt.Sprintf("Unsupported %s value '%s'. Acceptable values are: %s, %s", jsonlog.LogFormatEnv, logFormat, jsonlog.TextFormat, jsonlog.JsonFormat))
+	}
+}
+
 // This command generates and sets an Uber Trace ID token which will be attached as a header to every request.
 // This allows users to easily identify which logs on the server side are related to the command executed by the CLI.
 func setUberTraceIdToken() error {
@@ -143,6 +222,9 @@ func setUberTraceIdToken() error {
 		return err
 	}
 	httpclient.SetUberTraceIdToken(traceID)
+	if jsonLogger := jsonlog.GetLogger(); jsonLogger != nil {
+		jsonLogger.SetTraceId(traceID)
+	}
 	clientlog.Debug(traceIdLogMsg, traceID)
 	return nil
 }
@@ -166,44 +248,18 @@ func logTraceIdOnFailure(err error) {
 	clientlog.Info(traceIdLogMsg, traceID)
 }
 
-// Detects typos and can identify one or more valid commands similar to the error command.
-// In Addition, if a subcommand is found with exact match, preferred it over similar commands, for example:
-// "jf bp" -> return "jf rt bp"
-func searchSimilarCmds(cmds []cli.Command, toCompare string) (bestSimilarity []string) {
-	// Set min diff between two commands.
-	minDistance := 2
-	for _, cmd := range cmds {
-		// Check if we have an exact match with the next level.
-		for _, subCmd := range cmd.Subcommands {
-			for _, subCmdName := range subCmd.Names() {
-				// Found exact match, return it.
-				distance := levenshtein.ComputeDistance(subCmdName, toCompare)
-				if distance == 0 {
-					return []string{cmd.Name + " " + subCmdName}
-				}
-			}
-		}
-		// Search similar commands with max diff of 'minDistance'.
-		for _, cmdName := range cmd.Names() {
-			distance := levenshtein.ComputeDistance(cmdName, toCompare)
-			if distance == minDistance {
-				// In the case of an alias, we don't want to show the full command name, but the alias.
-				// Therefore, we trim the end of the full name and concat the actual matched (alias/full command name)
-				bestSimilarity = append(bestSimilarity, strings.Replace(cmd.FullName(), cmd.Name, cmdName, 1))
-			}
-			if distance < minDistance {
-				// Found a cmd with a smaller distance.
-				minDistance = distance
-				bestSimilarity = []string{strings.Replace(cmd.FullName(), cmd.Name, cmdName, 1)}
-			}
-		}
-	}
-	return
-}
-
 const otherCategory = "Other"
 const commandNamespacesCategory = "Command Namespaces"
 
+// Applies the per-project overlay found in the working directory or one of its parents, if any.
+func applyOverlay() error {
+	cliOverlay, err := overlay.Load()
+	if err != nil {
+		return err
+	}
+	return overlay.ApplyEnv(cliOverlay)
+}
+
 func getCommands() ([]cli.Command, error) {
 	cliNameSpaces := []cli.Command{
 		{
@@ -240,6 +296,7 @@ func getCommands() ([]cli.Command, error) {
 			Name:        cliutils.CmdPlugin,
 			Usage:       "Plugins handling commands.",
 			Subcommands: plugins.GetCommands(),
+			Before:      plugins.RegisterArchitectures,
 			Category:    commandNamespacesCategory,
 		},
 		{
@@ -249,6 +306,12 @@ func getCommands() ([]cli.Command, error) {
 			Subcommands: config.GetCommands(),
 			Category:    commandNamespacesCategory,
 		},
+		{
+			Name:        cliutils.CmdDaemon,
+			Usage:       "Background daemon commands.",
+			Subcommands: daemon.GetCommands(runCommandInProcess),
+			Category:    otherCategory,
+		},
 		{
 			Name:   "intro",
 			Hidden: true,
@@ -265,12 +328,52 @@ func getCommands() ([]cli.Command, error) {
 		},
 		{
 			Name:         "login",
+			Flags:        cliutils.GetCommandFlags(cliutils.Login),
 			Usage:        loginDocs.GetDescription(),
 			HelpName:     corecommon.CreateUsage("login", loginDocs.GetDescription(), loginDocs.Usage),
 			BashComplete: corecommon.CreateBashCompletionFunc(),
 			Category:     otherCategory,
 			Action:       login.LoginCmd,
 		},
+		{
+			Name:         "logout",
+			Flags:        cliutils.GetCommandFlags(cliutils.Logout),
+			Usage:        logoutDocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("logout", logoutDocs.GetDescription(), logoutDocs.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     otherCategory,
+			Action:       login.LogoutCmd,
+		},
+		{
+			Name:         cliutils.RunBatch,
+			Flags:        cliutils.GetCommandFlags(cliutils.RunBatch),
+			Usage:        runBatchDocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage(cliutils.RunBatch, runBatchDocs.GetDescription(), runBatchDocs.Usage),
+			UsageText:    runBatchDocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     otherCategory,
+			Action:       batch.RunBatchCmd,
+		},
+		{
+			Name:         cliutils.CmdShell,
+			Usage:        shellDocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage(cliutils.CmdShell, shellDocs.GetDescription(), shellDocs.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     otherCategory,
+			Action:       shell.ShellCmd,
+		},
+		{
+			Name:         cliutils.SelfUpdate,
+			Flags:        cliutils.GetCommandFlags(cliutils.SelfUpdate),
+			Usage:        selfUpdateDocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage(cliutils.SelfUpdate, selfUpdateDocs.GetDescription(), selfUpdateDocs.Usage),
+			ArgsUsage:    common.CreateEnvVars(common.JfrogCliReleasesRepo, common.JfrogCliSelfUpdatePublicKey),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     otherCategory,
+			Action:       selfupdate.SelfUpdateCmd,
+		},
 		{
 			Hidden:       true,
 			Name:         "how",
@@ -292,6 +395,41 @@ func getCommands() ([]cli.Command, error) {
 			Category:     otherCategory,
 			Action:       token.AccessTokenCreateCmd,
 		},
+		{
+			Name:         "access-token-list",
+			Aliases:      []string{"atl"},
+			Flags:        cliutils.GetCommandFlags(cliutils.AccessTokenList),
+			Usage:        tokenListDocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("atl", tokenListDocs.GetDescription(), tokenListDocs.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     otherCategory,
+			Action:       token.AccessTokenListCmd,
+		},
+		{
+			Name:         "access-token-inspect",
+			Aliases:      []string{"ati"},
+			Flags:        cliutils.GetCommandFlags(cliutils.AccessTokenInspect),
+			Usage:        tokenInspectDocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("ati", tokenInspectDocs.GetDescription(), tokenInspectDocs.Usage),
+			UsageText:    tokenInspectDocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     otherCategory,
+			Action:       token.AccessTokenInspectCmd,
+		},
+		{
+			Name:         "access-token-revoke",
+			Aliases:      []string{"atr"},
+			Flags:        cliutils.GetCommandFlags(cliutils.AccessTokenRevoke),
+			Usage:        tokenRevokeDocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("atr", tokenRevokeDocs.GetDescription(), tokenRevokeDocs.Usage),
+			UsageText:    tokenRevokeDocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     otherCategory,
+			Action:       token.AccessTokenRevokeCmd,
+		},
 	}
 
 	securityCmds, err := ConvertEmbeddedPlugin(securityCLI.GetJfrogCliSecurityApp())
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -22,6 +24,8 @@ import (
 	coreTests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
 	"github.com/jfrog/jfrog-cli/artifactory"
 	"github.com/jfrog/jfrog-cli/inttestutils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/httprecord"
 	"github.com/jfrog/jfrog-cli/utils/tests"
 	"github.com/jfrog/jfrog-client-go/utils"
 	clientlog "github.com/jfrog/jfrog-client-go/utils/log"
@@ -311,7 +315,7 @@ func TestSearchSimilarCmds(t *testing.T) {
 		{"ownload", artifactory.GetCommands(), []string{"download"}},
 	}
 	for _, testCase := range testData {
-		actualRes := searchSimilarCmds(testCase.searchIn, testCase.badCmdSyntax)
+		actualRes := cliutils.SearchSimilarCmds(testCase.searchIn, testCase.badCmdSyntax)
 		assert.ElementsMatch(t, actualRes, testCase.expectedRes)
 	}
 }
@@ -363,3 +367,34 @@ func TestGenerateAndLogTraceIdToken(t *testing.T) {
 	_, err = strconv.ParseUint(traceIdToken, 16, 64)
 	assert.NoError(t, err, "unexpected: trace ID token contains non-hex characters")
 }
+
+// Set in the process which runs the command of TestReplayedPing.
+const replayedPingEnv = "JFROG_CLI_TEST_REPLAYED_PING"
+
+// The HTTP clients read the proxy environment variables once per process, so the command runs in a new process of the test binary.
+func TestReplayedPing(t *testing.T) {
+	if os.Getenv(replayedPingEnv) == "true" {
+		os.Args = []string{"jf", "rt", "ping", "--url", "https://acme.jfrog.io/artifactory"}
+		if err := execMain(); err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+	cassette := &httprecord.Cassette{Version: 1, Interactions: []*httprecord.Interaction{{
+		Request:  httprecord.Request{Method: http.MethodGet, Url: "https://acme.jfrog.io/artifactory/api/system/ping"},
+		Response: httprecord.Response{StatusCode: http.StatusOK, Body: httprecord.Body{Body: "Replayed OK"}},
+	}}}
+	assert.NoError(t, cassette.Save(cassettePath))
+
+	// Other tests replace os.Args.
+	testBinary, err := os.Executable()
+	assert.NoError(t, err)
+	command := exec.Command(testBinary, "-test.run=^TestReplayedPing$")
+	command.Env = append(os.Environ(), replayedPingEnv+"=true", httprecord.ReplayEnv+"="+cassettePath,
+		coreutils.HomeDir+"="+t.TempDir(), "JFROG_CLI_DAEMON=false", "HTTPS_PROXY=", "NO_PROXY=")
+	output, err := command.CombinedOutput()
+	assert.NoError(t, err, string(output))
+	assert.Contains(t, string(output), "Replayed OK")
+}
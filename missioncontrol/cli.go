@@ -15,6 +15,7 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/missioncontrol/licensedeploy"
 	"github.com/jfrog/jfrog-cli/docs/missioncontrol/licenserelease"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/credentialhelper"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
@@ -155,7 +156,11 @@ func offerConfig(c *cli.Context) (*config.ServerDetails, error) {
 		return nil, err
 	}
 
-	return configCmd.ServerDetails()
+	details, err = configCmd.ServerDetails()
+	if err != nil {
+		return nil, err
+	}
+	return details, credentialhelper.Resolve(details)
 }
 
 func createLicenseDeployFlags(c *cli.Context) (flags *commands.LicenseDeployFlags, err error) {
@@ -211,7 +216,7 @@ func createMissionControlDetails(c *cli.Context) (*config.ServerDetails, error)
 	}
 
 	// Else, use details from config for requested serverId, or for default server if empty.
-	confDetails, err := coreCommonCommands.GetConfig(details.ServerId, true)
+	confDetails, err := credentialhelper.GetSpecificConfig(details.ServerId, true, true)
 	if err != nil {
 		return nil, err
 	}
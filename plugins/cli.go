@@ -3,19 +3,30 @@ package plugins
 import (
 	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
 	"github.com/jfrog/jfrog-cli/docs/common"
+	exportdocs "github.com/jfrog/jfrog-cli/docs/plugin/export"
 	installdocs "github.com/jfrog/jfrog-cli/docs/plugin/install"
+	listdocs "github.com/jfrog/jfrog-cli/docs/plugin/list"
 	publishdocs "github.com/jfrog/jfrog-cli/docs/plugin/publish"
+	syncdocs "github.com/jfrog/jfrog-cli/docs/plugin/sync"
 	uninstalldocs "github.com/jfrog/jfrog-cli/docs/plugin/uninstall"
+	updatedocs "github.com/jfrog/jfrog-cli/docs/plugin/update"
 	"github.com/jfrog/jfrog-cli/plugins/commands"
+	commandsUtils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	"github.com/urfave/cli"
 )
 
+// Registers the plugin architectures provided by env, before running any of the plugin commands.
+func RegisterArchitectures(*cli.Context) error {
+	return commandsUtils.RegisterArchitecturesFromEnv()
+}
+
 func GetCommands() []cli.Command {
 	return cliutils.GetSortedCommands(cli.CommandsByName{
 		{
 			Name:         "install",
 			Aliases:      []string{"i"},
+			Flags:        cliutils.GetCommandFlags(cliutils.PluginInstall),
 			Usage:        installdocs.GetDescription(),
 			HelpName:     corecommon.CreateUsage("plugin install", installdocs.GetDescription(), installdocs.Usage),
 			UsageText:    installdocs.GetArguments(),
@@ -36,6 +47,7 @@ func GetCommands() []cli.Command {
 		{
 			Name:         "publish",
 			Aliases:      []string{"p"},
+			Flags:        cliutils.GetCommandFlags(cliutils.PluginPublish),
 			Usage:        publishdocs.GetDescription(),
 			HelpName:     corecommon.CreateUsage("plugin publish", publishdocs.GetDescription(), publishdocs.Usage),
 			UsageText:    publishdocs.GetArguments(),
@@ -43,5 +55,44 @@ func GetCommands() []cli.Command {
 			BashComplete: corecommon.CreateBashCompletionFunc(),
 			Action:       commands.PublishCmd,
 		},
+		{
+			Name:         "list",
+			Aliases:      []string{"ls"},
+			Usage:        listdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("plugin list", listdocs.GetDescription(), listdocs.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       commands.ListCmd,
+		},
+		{
+			Name:         "update",
+			Aliases:      []string{"up"},
+			Flags:        cliutils.GetCommandFlags(cliutils.PluginUpdate),
+			Usage:        updatedocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("plugin update", updatedocs.GetDescription(), updatedocs.Usage),
+			UsageText:    updatedocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(updatedocs.EnvVar...),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       commands.UpdateCmd,
+		},
+		{
+			Name:         "export",
+			Flags:        cliutils.GetCommandFlags(cliutils.PluginExport),
+			Usage:        exportdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("plugin export", exportdocs.GetDescription(), exportdocs.Usage),
+			UsageText:    exportdocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(exportdocs.EnvVar...),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       commands.ExportCmd,
+		},
+		{
+			Name:         "sync",
+			Usage:        syncdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("plugin sync", syncdocs.GetDescription(), syncdocs.Usage),
+			UsageText:    syncdocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(common.JfrogCliPluginsPublicKey, common.JfrogCliPluginsRequireSignature),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       commands.SyncCmd,
+		},
 	})
 }
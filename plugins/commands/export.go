@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/archiver/v3"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	commandsUtils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+func ExportCmd(c *cli.Context) error {
+	if c.NArg() < 1 || c.NArg() > 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	err := assertValidEnv(c)
+	if err != nil {
+		return err
+	}
+	pluginName, version, err := getNameAndVersion(c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	bundlePath := c.Args().Get(1)
+	if bundlePath == "" {
+		bundlePath = fmt.Sprintf("%s-%s.zip", pluginName, version)
+	}
+	arcs := cliutils.GetStringsArrFlagValue(c, cliutils.Arch)
+	if len(arcs) == 0 {
+		localArc, err := commandsUtils.GetLocalArchitecture()
+		if err != nil {
+			return err
+		}
+		arcs = []string{localArc}
+	}
+	return runExportCmd(pluginName, version, arcs, bundlePath)
+}
+
+// Downloads the plugin's executables for the provided architectures, with their signatures and resources,
+// and packs them into a single zip, which can be installed without access to the plugins server.
+func runExportCmd(pluginName, version string, arcs []string, bundlePath string) (err error) {
+	url, serverDetails, err := getServerDetails(os.Getenv(commandsUtils.PluginsServerEnv))
+	if err != nil {
+		return
+	}
+	httpDetails := commandsUtils.CreatePluginsHttpDetails(&serverDetails)
+	versionDirUrl := clientUtils.AddTrailingSlashIfNeeded(url) + commandsUtils.GetPluginVersionDirInRepo(commandsUtils.GetPluginsRepo(), pluginName, version) + "/"
+
+	bundleDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if removeErr := fileutils.RemoveTempDir(bundleDir); removeErr != nil {
+			log.Debug("Failed removing the temp dir:", removeErr.Error())
+		}
+	}()
+
+	metadata := &commandsUtils.PluginBundleMetadata{Name: pluginName, Version: version, Sha256: map[string]string{}}
+	for _, requestedArc := range arcs {
+		arcName, arc, ok := commandsUtils.GetArchitecture(requestedArc)
+		if !ok {
+			return errorutils.CheckErrorf("unsupported architecture: '%s'", requestedArc)
+		}
+		// Plugins published by older versions of JFrog CLI may exist under the architecture's legacy names only.
+		registryArc, err := selectPluginArchitecture(versionDirUrl, pluginName, append([]string{arcName}, arc.LegacyNames...), httpDetails)
+		if err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Exporting plugin '%s' for: %s...", pluginName, arcName))
+		var resourcesSha256 string
+		metadata.Sha256[arcName], resourcesSha256, err = exportPluginArchitecture(versionDirUrl+registryArc+"/", pluginName, arcName, filepath.Join(bundleDir, arcName), httpDetails)
+		if err != nil {
+			return err
+		}
+		if resourcesSha256 != "" {
+			if metadata.ResourcesSha256 == nil {
+				metadata.ResourcesSha256 = map[string]string{}
+			}
+			metadata.ResourcesSha256[arcName] = resourcesSha256
+		}
+	}
+	if err = commandsUtils.WritePluginBundleMetadata(bundleDir, metadata); err != nil {
+		return
+	}
+	if err = createPluginBundle(bundleDir, bundlePath); err != nil {
+		return
+	}
+	log.Info("Plugin exported successfully to:", bundlePath)
+	return
+}
+
+// Downloads the plugin's files for a single architecture to the provided directory, and returns the sha256 checksums
+// of the executable and of the resources zip. The resources checksum is empty if the plugin has no resources.
+func exportPluginArchitecture(downloadUrl, pluginName, arc, arcDir string, httpDetails httputils.HttpClientDetails) (execSha256, resourcesSha256 string, err error) {
+	execName := commandsUtils.GetPluginExecutableName(pluginName, arc)
+	log.Debug("Downloading plugin's executable from:", downloadUrl+execName)
+	found, execSha256, err := exportPluginFile(downloadUrl, pluginName, execName, arcDir, "plugin's executable", httpDetails)
+	if err != nil {
+		return
+	}
+	if !found {
+		return "", "", errorutils.CheckErrorf("plugin '%s' was not published for the '%s' architecture", pluginName, arc)
+	}
+
+	resourcesZipName := coreutils.PluginsResourcesDirName + ".zip"
+	log.Debug("Downloading plugin's resources from:", downloadUrl+resourcesZipName)
+	found, resourcesSha256, err = exportPluginFile(downloadUrl, pluginName, resourcesZipName, arcDir, "plugin's resources", httpDetails)
+	if err == nil && !found {
+		log.Debug("No resources were downloaded.")
+	}
+	return
+}
+
+// Downloads and verifies one of the plugin's files, with its detached signature if exists, and returns the file's sha256 checksum.
+// found is false if the file doesn't exist on the plugins server.
+func exportPluginFile(downloadUrl, pluginName, fileName, arcDir, description string, httpDetails httputils.HttpClientDetails) (found bool, sha256 string, err error) {
+	downloadDetails := &httpclient.DownloadFileDetails{
+		FileName:      pluginName,
+		DownloadPath:  downloadUrl + fileName,
+		LocalPath:     arcDir,
+		LocalFileName: fileName,
+		RelativePath:  fileName,
+	}
+	response, err := downloadFromArtifactory(downloadDetails, httpDetails, nil)
+	if err != nil {
+		return
+	}
+	if response.StatusCode == http.StatusNotFound {
+		return
+	}
+	if err = errorutils.CheckResponseStatus(response, http.StatusOK); err != nil {
+		return
+	}
+	filePath := filepath.Join(arcDir, fileName)
+	sha256 = response.Header.Get("X-Checksum-Sha256")
+	if err = verifyPluginFile(downloadDetails.DownloadPath, filePath, description, sha256, httpDetails); err != nil {
+		return
+	}
+	if sha256 == "" {
+		// The checksum was verified against the plugins server, so it can be calculated locally.
+		if sha256, err = commandsUtils.CalcSha256(filePath); err != nil {
+			return
+		}
+	}
+
+	signature, err := downloadPluginExecSignature(downloadDetails.DownloadPath+commandsUtils.SignatureFileExtension, httpDetails)
+	if err != nil {
+		return
+	}
+	if signature != "" {
+		if err = os.WriteFile(filePath+commandsUtils.SignatureFileExtension, []byte(signature), 0644); err != nil {
+			return false, "", errorutils.CheckError(err)
+		}
+	}
+	return true, sha256, nil
+}
+
+// Zips the content of the bundle directory, so that the metadata file is at the root of the bundle.
+func createPluginBundle(bundleDir, bundlePath string) error {
+	content, err := os.ReadDir(bundleDir)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	var sources []string
+	for _, entry := range content {
+		sources = append(sources, filepath.Join(bundleDir, entry.Name()))
+	}
+	zip := archiver.NewZip()
+	zip.OverwriteExisting = true
+	return errorutils.CheckError(zip.Archive(sources, bundlePath))
+}
@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/archiver/v3"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	coreTests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportAndInstallFromBundle(t *testing.T) {
+	localArc, err := utils.GetLocalArchitecture()
+	assert.NoError(t, err)
+	execName := utils.GetPluginExecutableName("hello-frog", localArc)
+	execContent := []byte("executable")
+	execSha256 := sha256.Sum256(execContent)
+	resourcesZipPath := createResourcesZip(t)
+	resourcesSha256, err := utils.CalcSha256(resourcesZipPath)
+	assert.NoError(t, err)
+
+	// Serve the plugin's executable and resources, as published to the plugins server.
+	arcPath := "/repo/hello-frog/v1.0.0/" + localArc + "/"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case arcPath + execName:
+			w.Header().Set("X-Checksum-Sha256", hex.EncodeToString(execSha256[:]))
+			_, err := w.Write(execContent)
+			assert.NoError(t, err)
+		case arcPath + coreutils.PluginsResourcesDirName + ".zip":
+			w.Header().Set("X-Checksum-Sha256", resourcesSha256)
+			http.ServeFile(w, r, resourcesZipPath)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Export the plugin to a bundle.
+	bundleDir := t.TempDir()
+	sha, exportedResourcesSha256, err := exportPluginArchitecture(server.URL+arcPath, "hello-frog", localArc, filepath.Join(bundleDir, localArc), httputils.HttpClientDetails{})
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(execSha256[:]), sha)
+	assert.Equal(t, resourcesSha256, exportedResourcesSha256)
+	metadata := &utils.PluginBundleMetadata{Name: "hello-frog", Version: "v1.0.0", Sha256: map[string]string{localArc: sha}, ResourcesSha256: map[string]string{localArc: exportedResourcesSha256}}
+	assert.NoError(t, utils.WritePluginBundleMetadata(bundleDir, metadata))
+	bundlePath := filepath.Join(t.TempDir(), "hello-frog-v1.0.0.zip")
+	assert.NoError(t, createPluginBundle(bundleDir, bundlePath))
+
+	// Install the plugin from the bundle.
+	cleanUpJfrogHome, err := coreTests.SetJfrogHome()
+	assert.NoError(t, err)
+	defer cleanUpJfrogHome()
+	assert.NoError(t, runInstallFromBundleCmd(bundlePath))
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	assert.NoError(t, err)
+	content, err := os.ReadFile(getPluginExecPath(pluginsDir, "hello-frog"))
+	assert.NoError(t, err)
+	assert.Equal(t, execContent, content)
+	assert.FileExists(t, filepath.Join(pluginsDir, "hello-frog", coreutils.PluginsResourcesDirName, "resource.txt"))
+	details, err := utils.ReadPluginDetails(filepath.Join(pluginsDir, "hello-frog"))
+	assert.NoError(t, err)
+	if assert.NotNil(t, details) {
+		assert.True(t, details.Pinned)
+		assert.Equal(t, bundlePath, details.Bundle)
+	}
+}
+
+func TestInstallFromBundleChecksumMismatch(t *testing.T) {
+	localArc, err := utils.GetLocalArchitecture()
+	assert.NoError(t, err)
+	bundleDir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(bundleDir, localArc), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(bundleDir, localArc, utils.GetPluginExecutableName("hello-frog", localArc)), []byte("tampered"), 0755))
+	assert.NoError(t, utils.WritePluginBundleMetadata(bundleDir, &utils.PluginBundleMetadata{Name: "hello-frog", Version: "v1.0.0", Sha256: map[string]string{localArc: "abc"}}))
+	bundlePath := filepath.Join(t.TempDir(), "hello-frog-v1.0.0.zip")
+	assert.NoError(t, createPluginBundle(bundleDir, bundlePath))
+
+	cleanUpJfrogHome, err := coreTests.SetJfrogHome()
+	assert.NoError(t, err)
+	defer cleanUpJfrogHome()
+	assert.ErrorContains(t, runInstallFromBundleCmd(bundlePath), "checksum mismatch")
+}
+
+func TestInstallFromBundleResourcesChecksumMismatch(t *testing.T) {
+	localArc, err := utils.GetLocalArchitecture()
+	assert.NoError(t, err)
+	execName := utils.GetPluginExecutableName("hello-frog", localArc)
+	execContent := []byte("executable")
+	execSha256 := sha256.Sum256(execContent)
+	bundleDir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(bundleDir, localArc), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(bundleDir, localArc, execName), execContent, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(bundleDir, localArc, coreutils.PluginsResourcesDirName+".zip"), []byte("tampered"), 0644))
+	metadata := &utils.PluginBundleMetadata{Name: "hello-frog", Version: "v1.0.0", Sha256: map[string]string{localArc: hex.EncodeToString(execSha256[:])}}
+	bundlePath := filepath.Join(t.TempDir(), "hello-frog-v1.0.0.zip")
+
+	cleanUpJfrogHome, err := coreTests.SetJfrogHome()
+	assert.NoError(t, err)
+	defer cleanUpJfrogHome()
+	// Bundled resources must have a checksum.
+	assert.NoError(t, utils.WritePluginBundleMetadata(bundleDir, metadata))
+	assert.NoError(t, createPluginBundle(bundleDir, bundlePath))
+	assert.ErrorContains(t, runInstallFromBundleCmd(bundlePath), "no sha256 checksum for the bundled plugin's resources")
+
+	metadata.ResourcesSha256 = map[string]string{localArc: "abc"}
+	assert.NoError(t, utils.WritePluginBundleMetadata(bundleDir, metadata))
+	assert.NoError(t, createPluginBundle(bundleDir, bundlePath))
+	assert.ErrorContains(t, runInstallFromBundleCmd(bundlePath), "checksum mismatch")
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	assert.NoError(t, err)
+	assert.NoDirExists(t, filepath.Join(pluginsDir, "hello-frog"))
+}
+
+func TestInstallFromBundleInvalidName(t *testing.T) {
+	localArc, err := utils.GetLocalArchitecture()
+	assert.NoError(t, err)
+	for _, name := range []string{"../hello-frog", "..", "hello/frog", `hello\frog`} {
+		t.Run(name, func(t *testing.T) {
+			bundleDir := t.TempDir()
+			assert.NoError(t, utils.WritePluginBundleMetadata(bundleDir, &utils.PluginBundleMetadata{Name: name, Version: "v1.0.0", Sha256: map[string]string{localArc: "abc"}}))
+			bundlePath := filepath.Join(t.TempDir(), "hello-frog-v1.0.0.zip")
+			assert.NoError(t, createPluginBundle(bundleDir, bundlePath))
+
+			cleanUpJfrogHome, err := coreTests.SetJfrogHome()
+			assert.NoError(t, err)
+			defer cleanUpJfrogHome()
+			assert.ErrorContains(t, runInstallFromBundleCmd(bundlePath), "invalid plugin name")
+		})
+	}
+}
+
+func TestSelectBundleArchitecture(t *testing.T) {
+	localArc, err := utils.GetLocalArchitecture()
+	assert.NoError(t, err)
+	arc, err := selectBundleArchitecture(&utils.PluginBundleMetadata{Sha256: map[string]string{localArc: "abc", "made-up-arc": "def"}})
+	assert.NoError(t, err)
+	assert.Equal(t, localArc, arc)
+
+	_, err = selectBundleArchitecture(&utils.PluginBundleMetadata{Sha256: map[string]string{"made-up-arc": "def"}})
+	assert.ErrorContains(t, err, "no executable compatible")
+}
+
+func createResourcesZip(t *testing.T) string {
+	resourcesDir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(resourcesDir, "resource.txt"), []byte("resource"), 0644))
+	resourcesZipPath := filepath.Join(t.TempDir(), coreutils.PluginsResourcesDirName+".zip")
+	assert.NoError(t, archiver.NewZip().Archive([]string{filepath.Join(resourcesDir, "resource.txt")}, resourcesZipPath))
+	return resourcesZipPath
+}
@@ -2,12 +2,14 @@ package commands
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/jfrog/archiver/v3"
+	biutils "github.com/jfrog/build-info-go/utils"
 	ioutils "github.com/jfrog/jfrog-client-go/utils/io"
 
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
@@ -18,6 +20,8 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/common/progressbar"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/credentialhelper"
+	"github.com/jfrog/jfrog-cli/utils/httptransport"
 	"github.com/jfrog/jfrog-client-go/http/httpclient"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
@@ -27,7 +31,8 @@ import (
 )
 
 func InstallCmd(c *cli.Context) error {
-	if c.NArg() != 1 {
+	bundlePath := c.String(cliutils.FromFile)
+	if (bundlePath == "" && c.NArg() != 1) || (bundlePath != "" && c.NArg() != 0) {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
 	}
 	err := assertValidEnv(c)
@@ -38,6 +43,9 @@ func InstallCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if bundlePath != "" {
+		return runInstallFromBundleCmd(bundlePath)
+	}
 	return runInstallCmd(c.Args().Get(0))
 }
 
@@ -52,16 +60,20 @@ func runInstallCmd(requestedPlugin string) error {
 		return err
 	}
 
-	url, serverDetails, err := getServerDetails()
+	pluginDetails := &commandsUtils.PluginDetails{
+		ServerId: os.Getenv(commandsUtils.PluginsServerEnv),
+		Repo:     commandsUtils.GetPluginsRepo(),
+		Pinned:   version != commandsUtils.LatestVersionName,
+	}
+	url, serverDetails, err := getServerDetails(pluginDetails.ServerId)
 	if err != nil {
 		return err
 	}
 
-	pluginRtDirPath, err := getRequiredPluginRtDirPath(pluginName, version)
+	execDownloadUrl, err := getExecDownloadUrl(url, pluginDetails.Repo, pluginName, version, commandsUtils.CreatePluginsHttpDetails(&serverDetails))
 	if err != nil {
 		return err
 	}
-	execDownloadUrl := clientUtils.AddTrailingSlashIfNeeded(url) + pluginRtDirPath + "/"
 
 	should, err := shouldDownloadPlugin(pluginsDir, pluginName, execDownloadUrl, commandsUtils.CreatePluginsHttpDetails(&serverDetails))
 	if err != nil {
@@ -71,7 +83,235 @@ func runInstallCmd(requestedPlugin string) error {
 		return errorutils.CheckErrorf("the plugin with the requested version already exists locally")
 	}
 
-	return downloadPlugin(pluginsDir, pluginName, execDownloadUrl, commandsUtils.CreatePluginsHttpDetails(&serverDetails))
+	err = downloadPlugin(pluginsDir, pluginName, execDownloadUrl, commandsUtils.CreatePluginsHttpDetails(&serverDetails))
+	if err != nil {
+		return err
+	}
+	return savePluginDetails(pluginsDir, pluginName, pluginDetails)
+}
+
+// Installs a plugin from a bundle created by the 'export' command, without accessing the plugins server.
+func runInstallFromBundleCmd(bundlePath string) (err error) {
+	bundlePath, err = filepath.Abs(bundlePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	bundleDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if removeErr := fileutils.RemoveTempDir(bundleDir); removeErr != nil {
+			log.Debug("Failed removing the temp dir:", removeErr.Error())
+		}
+	}()
+	log.Info("Extracting the plugin bundle:", bundlePath)
+	if err = errorutils.CheckError(archiver.NewZip().Unarchive(bundlePath, bundleDir)); err != nil {
+		return
+	}
+	metadata, err := commandsUtils.ReadPluginBundleMetadata(bundleDir)
+	if err != nil {
+		return
+	}
+	arc, err := selectBundleArchitecture(metadata)
+	if err != nil {
+		return
+	}
+
+	// Verify the executable before replacing the installed plugin, if exists.
+	arcDir := filepath.Join(bundleDir, arc)
+	bundledExecPath := filepath.Join(arcDir, commandsUtils.GetPluginExecutableName(metadata.Name, arc))
+	log.Debug("Verifying plugin's executable sha256 checksum...")
+	if err = commandsUtils.VerifySha256(bundledExecPath, metadata.Sha256[arc]); err != nil {
+		return
+	}
+	err = verifyPluginExecSignature(bundledExecPath, func() (string, error) {
+		return readBundledSignature(bundledExecPath + commandsUtils.SignatureFileExtension)
+	})
+	if err != nil {
+		return
+	}
+	if err = verifyBundledResources(arcDir, metadata.ResourcesSha256[arc]); err != nil {
+		return
+	}
+
+	pluginsDir, err := createPluginsDirIfNeeded()
+	if err != nil {
+		return
+	}
+	pluginDir := filepath.Join(pluginsDir, metadata.Name)
+	// The plugin name is read from the bundle, so it's verified not to escape the plugins directory.
+	if relPath, relErr := filepath.Rel(pluginsDir, pluginDir); relErr != nil || relPath != metadata.Name {
+		return errorutils.CheckErrorf("invalid plugin name in the plugin bundle: '%s'", metadata.Name)
+	}
+	exists, err := fileutils.IsDirExists(pluginDir, false)
+	if err != nil {
+		return
+	}
+	if !exists {
+		if err = installBundledPlugin(pluginsDir, metadata.Name, bundlePath, arcDir, bundledExecPath); err != nil {
+			err = errors.Join(err, errorutils.CheckError(os.RemoveAll(pluginDir)))
+			return
+		}
+		log.Info("Plugin installed successfully.")
+		return
+	}
+
+	// The installed plugin is restored if the installation fails.
+	backupDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(backupDir))
+	}()
+	log.Debug("Backing up plugin '" + metadata.Name + "' to: " + backupDir)
+	if err = biutils.CopyDir(pluginDir, backupDir, true, nil); err != nil {
+		return
+	}
+	if err = errorutils.CheckError(os.RemoveAll(pluginDir)); err != nil {
+		return
+	}
+	if err = installBundledPlugin(pluginsDir, metadata.Name, bundlePath, arcDir, bundledExecPath); err != nil {
+		log.Warn(fmt.Sprintf("Rolling back plugin '%s' to its previous installation...", metadata.Name))
+		err = errors.Join(err, restorePluginDir(backupDir, pluginDir))
+		return
+	}
+	log.Info("Plugin installed successfully.")
+	return
+}
+
+// Copies the verified executable and resources of the bundled architecture to the plugin's directory.
+func installBundledPlugin(pluginsDir, pluginName, bundlePath, arcDir, bundledExecPath string) error {
+	execPath := getPluginExecPath(pluginsDir, pluginName)
+	if err := biutils.CopyFile(filepath.Dir(execPath), bundledExecPath); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err := errorutils.CheckError(os.Rename(filepath.Join(filepath.Dir(execPath), filepath.Base(bundledExecPath)), execPath)); err != nil {
+		return err
+	}
+	if err := errorutils.CheckError(os.Chmod(execPath, 0777)); err != nil {
+		return err
+	}
+	resourcesZipPath := filepath.Join(arcDir, coreutils.PluginsResourcesDirName+".zip")
+	exists, err := fileutils.IsFileExists(resourcesZipPath, false)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if err = extractPluginsResources(resourcesZipPath, filepath.Join(pluginsDir, pluginName)); err != nil {
+			return err
+		}
+	}
+	// Plugins installed from a bundle are pinned, since they can't be updated from the plugins server.
+	return savePluginDetails(pluginsDir, pluginName, &commandsUtils.PluginDetails{Pinned: true, Bundle: bundlePath})
+}
+
+// Verifies the bundled resources zip of the architecture, if exists, the same way as the executable.
+func verifyBundledResources(arcDir, sha256 string) error {
+	resourcesZipPath := filepath.Join(arcDir, coreutils.PluginsResourcesDirName+".zip")
+	exists, err := fileutils.IsFileExists(resourcesZipPath, false)
+	if err != nil || !exists {
+		return err
+	}
+	if sha256 == "" {
+		return errorutils.CheckErrorf("the plugin bundle metadata has no sha256 checksum for the bundled plugin's resources")
+	}
+	log.Debug("Verifying plugin's resources sha256 checksum...")
+	if err = commandsUtils.VerifySha256(resourcesZipPath, sha256); err != nil {
+		return err
+	}
+	return verifyPluginFileSignature(resourcesZipPath, "plugin's resources", func() (string, error) {
+		return readBundledSignature(resourcesZipPath + commandsUtils.SignatureFileExtension)
+	})
+}
+
+// Returns the bundled architecture to install on the local machine, falling back to a compatible architecture.
+func selectBundleArchitecture(metadata *commandsUtils.PluginBundleMetadata) (string, error) {
+	localArc, err := commandsUtils.GetLocalArchitecture()
+	if err != nil {
+		return "", err
+	}
+	for _, arc := range commandsUtils.GetInstallCandidates(localArc) {
+		if _, ok := metadata.Sha256[arc]; !ok {
+			continue
+		}
+		if arcName, _, _ := commandsUtils.GetArchitecture(arc); arcName != localArc {
+			log.Warn(fmt.Sprintf("The plugin bundle has no executable for the '%s' architecture of this machine. Installing the compatible '%s' executable instead.", localArc, arc))
+		}
+		return arc, nil
+	}
+	return "", errorutils.CheckErrorf("the plugin bundle has no executable compatible with the '%s' architecture of this machine", localArc)
+}
+
+// Returns the bundled detached signature, or an empty string if the file is not signed.
+func readBundledSignature(signaturePath string) (string, error) {
+	exists, err := fileutils.IsFileExists(signaturePath, false)
+	if err != nil || !exists {
+		return "", err
+	}
+	content, err := fileutils.ReadFile(signaturePath)
+	return string(content), err
+}
+
+// Returns the URL of the plugin's directory in registry, corresponding to the local architecture.
+// If the plugin wasn't published for the local architecture, a compatible architecture is used instead.
+func getExecDownloadUrl(url, repo, pluginName, version string, httpDetails httputils.HttpClientDetails) (string, error) {
+	localArc, err := commandsUtils.GetLocalArchitecture()
+	if err != nil {
+		return "", err
+	}
+	versionDirUrl := clientUtils.AddTrailingSlashIfNeeded(url) + commandsUtils.GetPluginVersionDirInRepo(repo, pluginName, version) + "/"
+	arc, err := selectPluginArchitecture(versionDirUrl, pluginName, commandsUtils.GetInstallCandidates(localArc), httpDetails)
+	if err != nil {
+		return "", err
+	}
+	if arc != localArc {
+		if arcName, _, _ := commandsUtils.GetArchitecture(arc); arcName != localArc {
+			log.Warn(fmt.Sprintf("Plugin '%s' was not published for the '%s' architecture of this machine. Installing the compatible '%s' executable instead.", pluginName, localArc, arc))
+		}
+	}
+	return versionDirUrl + arc + "/", nil
+}
+
+// Returns the first architecture the plugin's executable exists for in registry.
+// If it doesn't exist for any of the candidates, the first candidate is returned, so that the download fails with a clear error.
+func selectPluginArchitecture(versionDirUrl, pluginName string, candidates []string, httpDetails httputils.HttpClientDetails) (string, error) {
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+	client, err := httpclient.ClientBuilder().SetHttpClient(httptransport.NewClient()).Build()
+	if err != nil {
+		return "", err
+	}
+	for _, arc := range candidates {
+		execUrl := versionDirUrl + arc + "/" + commandsUtils.GetPluginExecutableName(pluginName, arc)
+		log.Debug("Checking whether the plugin exists at:", execUrl)
+		resp, _, err := client.SendHead(execUrl, httpDetails, "")
+		if err != nil {
+			return "", err
+		}
+		if resp.StatusCode == http.StatusOK {
+			return arc, nil
+		}
+	}
+	return candidates[0], nil
+}
+
+// Saves the details of a downloaded plugin in its directory, to be used by the 'list' and 'update' commands.
+// The installed version is taken from the plugin itself, since the 'latest' version may have been requested.
+func savePluginDetails(pluginsDir, pluginName string, pluginDetails *commandsUtils.PluginDetails) error {
+	version, err := getPluginExecVersion(getPluginExecPath(pluginsDir, pluginName))
+	if err != nil {
+		// Plugins are not obligated to support the version command, so the version is left empty.
+		log.Debug("Couldn't get the version of plugin '" + pluginName + "': " + err.Error())
+	}
+	pluginDetails.Version = version
+	return commandsUtils.WritePluginDetails(filepath.Join(pluginsDir, pluginName), pluginDetails)
+}
+
+func getPluginExecPath(pluginsDir, pluginName string) string {
+	return filepath.Join(pluginsDir, pluginName, coreutils.PluginsExecDirName, plugins.GetLocalPluginExecutableName(pluginName))
 }
 
 // Assert repo env is not passed without server env.
@@ -107,13 +347,12 @@ func createPluginsDirIfNeeded() (string, error) {
 }
 
 // Use the server ID if provided, else use the official registry.
-func getServerDetails() (string, config.ServerDetails, error) {
-	serverId := os.Getenv(commandsUtils.PluginsServerEnv)
+func getServerDetails(serverId string) (string, config.ServerDetails, error) {
 	if serverId == "" {
 		return commandsUtils.PluginsOfficialRegistryUrl, config.ServerDetails{ArtifactoryUrl: commandsUtils.PluginsOfficialRegistryUrl}, nil
 	}
 
-	rtDetails, err := config.GetSpecificConfig(serverId, false, true)
+	rtDetails, err := credentialhelper.GetSpecificConfig(serverId, false, true)
 	if err != nil {
 		return "", config.ServerDetails{}, err
 	}
@@ -130,7 +369,7 @@ func shouldDownloadPlugin(pluginsDir, pluginName, downloadUrl string, httpDetail
 		return true, nil
 	}
 	log.Debug("Verifying plugin download is needed...")
-	client, err := httpclient.ClientBuilder().Build()
+	client, err := httpclient.ClientBuilder().SetHttpClient(httptransport.NewClient()).Build()
 	if err != nil {
 		return false, err
 	}
@@ -140,20 +379,10 @@ func shouldDownloadPlugin(pluginsDir, pluginName, downloadUrl string, httpDetail
 	if err != nil {
 		return false, err
 	}
-	equal, err := fileutils.IsEqualToLocalFile(filepath.Join(pluginsDir, pluginName, coreutils.PluginsExecDirName, plugins.GetLocalPluginExecutableName(pluginName)), details.Checksum.Md5, details.Checksum.Sha1)
+	equal, err := fileutils.IsEqualToLocalFile(getPluginExecPath(pluginsDir, pluginName), details.Checksum.Md5, details.Checksum.Sha1)
 	return !equal, err
 }
 
-// Returns the path of the JFrog CLI plugin's directory in registry, corresponding to the local architecture.
-func getRequiredPluginRtDirPath(pluginName, version string) (pluginDirRtPath string, err error) {
-	arc, err := commandsUtils.GetLocalArchitecture()
-	if err != nil {
-		return
-	}
-	pluginDirRtPath = commandsUtils.GetPluginDirPath(pluginName, version, arc)
-	return
-}
-
 func createPluginsDir(pluginsDir string) error {
 	err := os.MkdirAll(pluginsDir, 0777)
 	if err != nil {
@@ -219,7 +448,12 @@ func downloadPluginExec(downloadUrl, pluginName, pluginsDir string, httpDetails
 	if err != nil {
 		return
 	}
-	err = os.Chmod(filepath.Join(downloadDetails.LocalPath, downloadDetails.LocalFileName), 0777)
+	execPath := filepath.Join(downloadDetails.LocalPath, downloadDetails.LocalFileName)
+	if err = verifyPluginExec(downloadDetails.DownloadPath, execPath, response.Header.Get("X-Checksum-Sha256"), httpDetails); err != nil {
+		// Never leave an unverified executable in the plugins directory.
+		return errors.Join(err, errorutils.CheckError(os.Remove(execPath)))
+	}
+	err = os.Chmod(execPath, 0777)
 	if errorutils.CheckError(err) != nil {
 		return
 	}
@@ -227,6 +461,91 @@ func downloadPluginExec(downloadUrl, pluginName, pluginsDir string, httpDetails
 	return
 }
 
+// Verifies the downloaded executable's sha256 checksum against the checksum reported by Artifactory, and its signature if required.
+func verifyPluginExec(execDownloadUrl, execPath, sha256 string, httpDetails httputils.HttpClientDetails) error {
+	return verifyPluginFile(execDownloadUrl, execPath, "plugin's executable", sha256, httpDetails)
+}
+
+// Verifies the downloaded resources zip the same way as the executable, since the plugin reads its resources.
+func verifyPluginResources(resourcesDownloadUrl, resourcesZipPath, sha256 string, httpDetails httputils.HttpClientDetails) error {
+	return verifyPluginFile(resourcesDownloadUrl, resourcesZipPath, "plugin's resources", sha256, httpDetails)
+}
+
+func verifyPluginFile(downloadUrl, filePath, description, sha256 string, httpDetails httputils.HttpClientDetails) error {
+	if sha256 == "" {
+		client, err := httpclient.ClientBuilder().SetHttpClient(httptransport.NewClient()).Build()
+		if err != nil {
+			return err
+		}
+		details, _, err := client.GetRemoteFileDetails(downloadUrl, httpDetails)
+		if err != nil {
+			return err
+		}
+		sha256 = details.Checksum.Sha256
+	}
+	log.Debug(fmt.Sprintf("Verifying %s sha256 checksum...", description))
+	if err := commandsUtils.VerifySha256(filePath, sha256); err != nil {
+		return err
+	}
+	return verifyPluginFileSignature(filePath, description, func() (string, error) {
+		return downloadPluginExecSignature(downloadUrl+commandsUtils.SignatureFileExtension, httpDetails)
+	})
+}
+
+// Verifies the executable's detached signature with the public key provided by env.
+// If no public key is provided, the signature is verified only if the unsigned plugins policy is enforced, which fails the installation.
+// getSignature returns the detached signature, or an empty string if the plugin is not signed.
+func verifyPluginExecSignature(execPath string, getSignature func() (string, error)) error {
+	return verifyPluginFileSignature(execPath, "plugin's executable", getSignature)
+}
+
+func verifyPluginFileSignature(filePath, description string, getSignature func() (string, error)) error {
+	required, err := commandsUtils.IsSignatureRequired()
+	if err != nil {
+		return err
+	}
+	publicKeyPath := os.Getenv(commandsUtils.PluginsPublicKeyEnv)
+	if publicKeyPath == "" {
+		if required {
+			return errorutils.CheckErrorf("the %s env var must be provided when %s is set to true", commandsUtils.PluginsPublicKeyEnv, commandsUtils.PluginsRequireSignatureEnv)
+		}
+		return nil
+	}
+	signature, err := getSignature()
+	if err != nil {
+		return err
+	}
+	if signature == "" {
+		if required {
+			return errorutils.CheckErrorf("the %s is not signed, and %s is set to true", description, commandsUtils.PluginsRequireSignatureEnv)
+		}
+		log.Warn(fmt.Sprintf("The %s is not signed. Skipping signature verification.", description))
+		return nil
+	}
+	log.Debug(fmt.Sprintf("Verifying %s signature...", description))
+	return commandsUtils.VerifyFileSignature(filePath, signature, publicKeyPath)
+}
+
+// Returns the plugin's executable detached signature, or an empty string if the plugin is not signed.
+func downloadPluginExecSignature(signatureUrl string, httpDetails httputils.HttpClientDetails) (string, error) {
+	client, err := httpclient.ClientBuilder().SetHttpClient(httptransport.NewClient()).Build()
+	if err != nil {
+		return "", err
+	}
+	log.Debug("Downloading plugin's executable signature from:", signatureUrl)
+	resp, body, _, err := client.SendGet(signatureUrl, true, httpDetails, "")
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
 func downloadPluginsResources(downloadUrl, pluginName, pluginsDir string, httpDetails httputils.HttpClientDetails, progressMgr ioutils.ProgressMgr) (err error) {
 	downloadDetails := &httpclient.DownloadFileDetails{
 		FileName:      pluginName,
@@ -248,24 +567,34 @@ func downloadPluginsResources(downloadUrl, pluginName, pluginsDir string, httpDe
 	if err != nil {
 		return
 	}
-	err = archiver.Unarchive(filepath.Join(downloadDetails.LocalPath, downloadDetails.LocalFileName), filepath.Join(downloadDetails.LocalPath, coreutils.PluginsResourcesDirName)+string(os.PathSeparator))
-	if errorutils.CheckError(err) != nil {
-		return
+	resourcesZipPath := filepath.Join(downloadDetails.LocalPath, downloadDetails.LocalFileName)
+	if err = verifyPluginResources(downloadDetails.DownloadPath, resourcesZipPath, response.Header.Get("X-Checksum-Sha256"), httpDetails); err != nil {
+		// Never extract unverified resources.
+		return errors.Join(err, errorutils.CheckError(os.Remove(resourcesZipPath)))
 	}
-	err = os.Remove(filepath.Join(downloadDetails.LocalPath, downloadDetails.LocalFileName))
+	err = extractPluginsResources(resourcesZipPath, downloadDetails.LocalPath)
 	if err != nil {
 		return
 	}
-	err = coreutils.ChmodPluginsDirectoryContent()
-	if errorutils.CheckError(err) != nil {
+	err = os.Remove(resourcesZipPath)
+	if err != nil {
 		return
 	}
 	log.Debug("Plugin's resources downloaded successfully.")
 	return
 }
 
+// Extracts the plugin's resources zip to the resources directory in the plugin's directory.
+func extractPluginsResources(resourcesZipPath, pluginDir string) error {
+	err := archiver.Unarchive(resourcesZipPath, filepath.Join(pluginDir, coreutils.PluginsResourcesDirName)+string(os.PathSeparator))
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	return errorutils.CheckError(coreutils.ChmodPluginsDirectoryContent())
+}
+
 func downloadFromArtifactory(downloadDetails *httpclient.DownloadFileDetails, httpDetails httputils.HttpClientDetails, progressMgr ioutils.ProgressMgr) (response *http.Response, err error) {
-	client, err := httpclient.ClientBuilder().Build()
+	client, err := httpclient.ClientBuilder().SetHttpClient(httptransport.NewClient()).Build()
 	if err != nil {
 		return
 	}
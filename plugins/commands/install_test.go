@@ -1,8 +1,17 @@
 package commands
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -34,3 +43,47 @@ func TestGetNameAndVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectPluginArchitecture(t *testing.T) {
+	// The registry has the plugin for windows-amd64 only.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repo/hello-frog/v1.0.0/windows-amd64/hello-frog.exe" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	versionDirUrl := server.URL + "/repo/hello-frog/v1.0.0/"
+
+	// Falls back to a compatible architecture.
+	arc, err := selectPluginArchitecture(versionDirUrl, "hello-frog", utils.GetInstallCandidates("windows-arm64"), httputils.HttpClientDetails{})
+	assert.NoError(t, err)
+	assert.Equal(t, "windows-amd64", arc)
+
+	// If no candidate exists, the first candidate is returned.
+	arc, err = selectPluginArchitecture(versionDirUrl, "hello-frog", utils.GetInstallCandidates("mac-arm64"), httputils.HttpClientDetails{})
+	assert.NoError(t, err)
+	assert.Equal(t, "mac-arm64", arc)
+}
+
+func TestDownloadPluginsResources(t *testing.T) {
+	resourcesZip := []byte("resources")
+	sha256Sum := sha256.Sum256(resourcesZip)
+	checksum := hex.EncodeToString(sha256Sum[:])
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Checksum-Sha256", checksum)
+		_, err := w.Write(resourcesZip)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	// Resources which don't match the checksum reported by Artifactory aren't extracted, and aren't left behind.
+	pluginsDir := t.TempDir()
+	checksum = strings.Repeat("0", 64)
+	err := downloadPluginsResources(server.URL+"/repo/hello-frog/v1.0.0/linux-amd64/", "hello-frog", pluginsDir, httputils.HttpClientDetails{}, nil)
+	assert.ErrorContains(t, err, "sha256 checksum mismatch")
+	entries, err := os.ReadDir(filepath.Join(pluginsDir, "hello-frog"))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/plugins"
+	commandsUtils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	pluginsutils "github.com/jfrog/jfrog-cli/plugins/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+const (
+	officialRegistrySource = "official registry"
+	unknownValue           = "unknown"
+)
+
+type installedPluginRow struct {
+	Name            string `json:"name" yaml:"name" col-name:"Name"`
+	Version         string `json:"version" yaml:"version" col-name:"Version"`
+	Source          string `json:"source" yaml:"source" col-name:"Source"`
+	Pinned          string `json:"pinned" yaml:"pinned" col-name:"Pinned"`
+	UpdateAvailable string `json:"updateAvailable" yaml:"updateAvailable" col-name:"Update Available"`
+}
+
+func ListCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	err := plugins.CheckPluginsVersionAndConvertIfNeeded()
+	if err != nil {
+		return err
+	}
+	format, err := cliutils.GetOutputFormat(c, cliutils.Table)
+	if err != nil {
+		return err
+	}
+	return runListCmd(format)
+}
+
+func runListCmd(format cliutils.OutputFormat) error {
+	rows, err := getInstalledPluginsRows()
+	if err != nil {
+		return err
+	}
+	return cliutils.PrintCommandOutput(cliutils.CommandOutput{Data: rows, Title: "Installed Plugins", EmptyMessage: "No plugins are installed"}, format)
+}
+
+func getInstalledPluginsRows() ([]installedPluginRow, error) {
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	if err != nil {
+		return nil, err
+	}
+	signatures, err := pluginsutils.GetPluginsSignatures()
+	if err != nil {
+		// Plugins with invalid signatures are skipped, so the rest of the plugins are still listed.
+		log.Warn("failed getting the signatures of some of the installed plugins. Last error: " + err.Error())
+	}
+	var rows []installedPluginRow
+	for _, signature := range signatures {
+		// The executable is located at 'plugins/<plugin-name>/bin/<executable>'.
+		pluginDir := filepath.Dir(filepath.Dir(signature.ExecutablePath))
+		pluginName := filepath.Base(pluginDir)
+		details, err := commandsUtils.ReadPluginDetails(pluginDir)
+		if err != nil {
+			log.Warn("failed reading the details of plugin '" + pluginName + "': " + err.Error())
+		}
+		rows = append(rows, installedPluginRow{
+			Name:            signature.Name,
+			Version:         getInstalledVersion(signature.ExecutablePath, details),
+			Source:          getPluginSource(details),
+			Pinned:          getPinnedValue(details),
+			UpdateAvailable: getUpdateAvailableValue(pluginsDir, pluginName, details),
+		})
+	}
+	return rows, nil
+}
+
+// The version is taken from the plugin's executable, as it may have been replaced since it was installed.
+func getInstalledVersion(execPath string, details *commandsUtils.PluginDetails) string {
+	version, err := getPluginExecVersion(execPath)
+	if err == nil {
+		return version
+	}
+	log.Debug("Couldn't get the version of the plugin at '" + execPath + "': " + err.Error())
+	if details != nil && details.Version != "" {
+		return details.Version
+	}
+	return unknownValue
+}
+
+func getPluginSource(details *commandsUtils.PluginDetails) string {
+	if details == nil {
+		return unknownValue
+	}
+	if details.Bundle != "" {
+		return details.Bundle
+	}
+	if details.ServerId == "" {
+		return officialRegistrySource
+	}
+	return details.ServerId + "/" + details.Repo
+}
+
+func getPinnedValue(details *commandsUtils.PluginDetails) string {
+	if details != nil && details.Pinned {
+		return "yes"
+	}
+	return "no"
+}
+
+// Compares the installed executable with the latest version in the registry the plugin was installed from.
+func getUpdateAvailableValue(pluginsDir, pluginName string, details *commandsUtils.PluginDetails) string {
+	available, err := isUpdateAvailable(pluginsDir, pluginName, details)
+	if err != nil {
+		log.Debug("Couldn't check for a newer version of plugin '" + pluginName + "': " + err.Error())
+		return unknownValue
+	}
+	if available {
+		return "yes"
+	}
+	return "no"
+}
+
+func isUpdateAvailable(pluginsDir, pluginName string, details *commandsUtils.PluginDetails) (bool, error) {
+	details = getPluginDetailsOrDefault(details)
+	if details.Bundle != "" {
+		return false, errorutils.CheckErrorf("the plugin was installed from a plugin bundle")
+	}
+	url, serverDetails, err := getServerDetails(details.ServerId)
+	if err != nil {
+		return false, err
+	}
+	httpDetails := commandsUtils.CreatePluginsHttpDetails(&serverDetails)
+	execDownloadUrl, err := getExecDownloadUrl(url, details.Repo, pluginName, commandsUtils.LatestVersionName, httpDetails)
+	if err != nil {
+		return false, err
+	}
+	return shouldDownloadPlugin(pluginsDir, pluginName, execDownloadUrl, httpDetails)
+}
@@ -1,6 +1,9 @@
 package commands
 
 import (
+	"errors"
+	"fmt"
+	"github.com/jfrog/archiver/v3"
 	buildinfoutils "github.com/jfrog/build-info-go/utils"
 	"github.com/jfrog/gofrog/io"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
@@ -13,19 +16,42 @@ import (
 	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
 	pluginsutils "github.com/jfrog/jfrog-cli/plugins/utils"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/credentialhelper"
+	"github.com/jfrog/jfrog-cli/utils/httptransport"
 	"github.com/jfrog/jfrog-client-go/http/httpclient"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/urfave/cli"
+	"golang.org/x/exp/slices"
 	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
+	"sync"
 )
 
-const pluginVersionCommandName = "-v"
+const (
+	pluginVersionCommandName = "-v"
+	// Uploaded to the version's staging directory when a publish starts, and deleted with it once the version was copied to latest.
+	// It keeps the staging directory, and so the failed publish, detectable after all architectures were moved to the version's directory.
+	publishInProgressFileName = "publish-in-progress"
+)
+
+// Options for building and publishing the plugin.
+type publishOptions struct {
+	// Names of the architectures to publish. If empty, the plugin is published for all supported architectures.
+	architectures []string
+	ldflags       string
+	tags          string
+	// Number of architectures built concurrently.
+	threads int
+	resume  bool
+	// If provided, the executables are taken from the manifest instead of being built with 'go build'.
+	manifest *utils.PluginManifest
+}
 
 func PublishCmd(c *cli.Context) error {
 	if c.NArg() != 2 {
@@ -37,24 +63,108 @@ func PublishCmd(c *cli.Context) error {
 		return err
 	}
 
-	return runPublishCmd(c.Args().Get(0), c.Args().Get(1), rtDetails)
+	if c.Bool(cliutils.Resume) && c.Bool(cliutils.Rollback) {
+		return cliutils.PrintHelpAndReturnError("the --"+cliutils.Resume+" and --"+cliutils.Rollback+" options can't be used together", c)
+	}
+	if c.Bool(cliutils.Rollback) {
+		return runRollbackCmd(c.Args().Get(0), c.Args().Get(1), rtDetails)
+	}
+
+	threads, err := cliutils.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	manifest, err := getPluginManifest(c.String(cliutils.Manifest))
+	if err != nil {
+		return err
+	}
+	// The manifest's executables are prebuilt or built by its own build commands, which the Go build options can't be applied to.
+	if manifest != nil && (c.IsSet(cliutils.Ldflags) || c.IsSet(cliutils.Tags)) {
+		return cliutils.PrintHelpAndReturnError("the --"+cliutils.Ldflags+" and --"+cliutils.Tags+" options can't be used with a plugin manifest", c)
+	}
+	options := &publishOptions{
+		architectures: cliutils.GetStringsArrFlagValue(c, cliutils.Arch),
+		ldflags:       c.String(cliutils.Ldflags),
+		tags:          c.String(cliutils.Tags),
+		threads:       threads,
+		resume:        c.Bool(cliutils.Resume),
+		manifest:      manifest,
+	}
+	return runPublishCmd(c.Args().Get(0), c.Args().Get(1), rtDetails, options)
+}
+
+// Reads the plugin manifest from the provided path.
+// If no path is provided, the manifest is read from the plugin's directory if exists, and nil is returned otherwise.
+func getPluginManifest(manifestPath string) (*utils.PluginManifest, error) {
+	if manifestPath == "" {
+		exists, err := fileutils.IsFileExists(utils.PluginManifestFileName, false)
+		if err != nil || !exists {
+			return nil, err
+		}
+		manifestPath = utils.PluginManifestFileName
+	}
+	log.Info("Using the plugin manifest at: " + manifestPath)
+	return utils.ReadPluginManifest(manifestPath)
 }
 
-func runPublishCmd(pluginName, pluginVersion string, rtDetails *config.ServerDetails) error {
-	err := verifyUniqueVersion(pluginName, pluginVersion, rtDetails)
+func runPublishCmd(pluginName, pluginVersion string, rtDetails *config.ServerDetails, options *publishOptions) error {
+	staged, err := isExistsInArtifactory(utils.GetPluginStagingDirInArtifactory(pluginName, pluginVersion), rtDetails)
 	if err != nil {
 		return err
 	}
+	if staged && !options.resume {
+		return errorutils.CheckErrorf("a previous publish of this plugin version failed. Run the command again with --%s to complete it, or with --%s to delete it", cliutils.Resume, cliutils.Rollback)
+	}
+	// A resumed publish may have moved some of the architectures to the version's directory before it failed.
+	if !staged {
+		err = verifyUniqueVersion(pluginName, pluginVersion, rtDetails)
+		if err != nil {
+			return err
+		}
+	}
 
-	return doPublish(pluginName, pluginVersion, rtDetails)
+	return doPublish(pluginName, pluginVersion, rtDetails, options)
 }
 
-// Build and upload the plugin for every supported architecture.
-func doPublish(pluginName, pluginVersion string, rtDetails *config.ServerDetails) error {
+// Delete the architectures staged by a failed publish, and the architectures it already moved to the version's directory.
+// A failed publish never copied the version to latest, so the version's directory holds only the moved architectures.
+// The staging directory is deleted last, so that a failed rollback can be run again.
+func runRollbackCmd(pluginName, pluginVersion string, rtDetails *config.ServerDetails) error {
+	staged, err := isExistsInArtifactory(utils.GetPluginStagingDirInArtifactory(pluginName, pluginVersion), rtDetails)
+	if err != nil {
+		return err
+	}
+	if !staged {
+		return errorutils.CheckErrorf("no failed publish of version '%s' of plugin '%s' was found to roll back", pluginVersion, pluginName)
+	}
+	log.Info("Rolling back the staged plugin version...")
+	err = deleteFromArtifactory(createDeleteVersionSpec(pluginName, pluginVersion), rtDetails)
+	if err != nil {
+		return err
+	}
+	err = deleteStagedVersion(pluginName, pluginVersion, rtDetails)
+	if err != nil {
+		return err
+	}
+	log.Info("Rollback completed successfully.")
+	return nil
+}
+
+// Build and upload the plugin for every requested architecture.
+// The architectures are uploaded to a staging directory, which is moved to the version's directory only when all uploads succeeded.
+// This allows resuming or rolling back a failed publish, before the version becomes available and latest is overridden.
+// The staging directory is deleted only after the version was copied to latest, so that a publish which failed while moving
+// the architectures or copying to latest is resumed or rolled back too.
+func doPublish(pluginName, pluginVersion string, rtDetails *config.ServerDetails, options *publishOptions) error {
 	tmpDir, err := fileutils.CreateTempDir()
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if removeErr := fileutils.RemoveTempDir(tmpDir); removeErr != nil {
+			log.Debug("Failed removing the temp dir:", removeErr.Error())
+		}
+	}()
 
 	localArc, err := utils.GetLocalArchitecture()
 	if err != nil {
@@ -65,27 +175,160 @@ func doPublish(pluginName, pluginVersion string, rtDetails *config.ServerDetails
 	if err != nil {
 		return err
 	}
+	if options.manifest != nil {
+		arcs = getManifestArchitectures(arcs, options.manifest)
+	}
+	arcs, err = filterArchitectures(arcs, options.architectures)
+	if err != nil {
+		return err
+	}
+	if options.resume {
+		arcs, err = getUnstagedArchitectures(pluginName, pluginVersion, arcs, rtDetails)
+		if err != nil {
+			return err
+		}
+	}
 
-	// Build and upload the plugin for all architectures.
-	// Start with the local architecture, to assert versions match before uploading.
-	for _, arc := range arcs {
-		pluginPath, err := buildPlugin(pluginName, tmpDir, utils.ArchitecturesMap[arc])
+	// Build the plugin for all architectures concurrently.
+	// The local architecture is always built, to assert versions match before uploading.
+	// A manifest may not support the local architecture, in which case the versions can't be verified.
+	verifyVersion := options.manifest == nil || options.manifest.IsSupported(localArc)
+	toBuild := arcs
+	if verifyVersion && !slices.Contains(toBuild, localArc) {
+		toBuild = append([]string{localArc}, toBuild...)
+	}
+	pluginPaths, err := buildPlugins(pluginName, tmpDir, toBuild, options)
+	if err != nil {
+		return err
+	}
+	if verifyVersion {
+		err = verifyMatchingVersion(pluginPaths[localArc], pluginVersion)
 		if err != nil {
 			return err
 		}
-		if arc == localArc {
-			err = verifyMatchingVersion(pluginPath, pluginVersion)
+	} else {
+		log.Warn("The plugin manifest doesn't support the local architecture (" + localArc + "). Skipping the versions matching verification.")
+	}
+
+	err = markPublishInProgress(pluginName, pluginVersion, tmpDir, rtDetails)
+	if err != nil {
+		return err
+	}
+	// Upload all architectures, even if some of them fail, so that only the failed architectures need to be resumed.
+	var failedArcs []string
+	for _, arc := range arcs {
+		// The plugin is uploaded under the architecture's legacy names too, to allow older versions of JFrog CLI to install it.
+		for _, arcName := range append([]string{arc}, utils.ArchitecturesMap[arc].LegacyNames...) {
+			err = uploadPlugin(pluginPaths[arc], pluginName, pluginVersion, arcName, rtDetails)
 			if err != nil {
-				return err
+				log.Error(fmt.Sprintf("failed uploading the plugin for '%s': %s", arcName, err.Error()))
+				failedArcs = append(failedArcs, arc)
+				break
 			}
 		}
-		err = uploadPlugin(pluginPath, pluginName, pluginVersion, arc, rtDetails)
-		if err != nil {
-			return err
+	}
+	if len(failedArcs) > 0 {
+		return errorutils.CheckErrorf("failed publishing the plugin for the following architectures: %s. "+
+			"The other architectures are staged. Run the command again with --%s to retry the failed architectures, or with --%s to delete the staged architectures",
+			strings.Join(failedArcs, ", "), cliutils.Resume, cliutils.Rollback)
+	}
+
+	err = moveStagedVersion(pluginName, pluginVersion, rtDetails)
+	if err != nil {
+		return err
+	}
+	err = copyToLatestDir(pluginName, pluginVersion, rtDetails)
+	if err != nil {
+		return errors.Join(err, errorutils.CheckErrorf("failed copying the version to latest. Run the command again with --%s to complete the publish", cliutils.Resume))
+	}
+	// The publish is complete.
+	return deleteStagedVersion(pluginName, pluginVersion, rtDetails)
+}
+
+// Uploads the in-progress marker to the version's staging directory. A resumed publish uploads it again, which is harmless.
+func markPublishInProgress(pluginName, pluginVersion, tmpDir string, rtDetails *config.ServerDetails) error {
+	markerPath := filepath.Join(tmpDir, publishInProgressFileName)
+	if err := os.WriteFile(markerPath, []byte(pluginVersion), 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, err := createAndRunPluginsExecUploadCommand(markerPath, path.Join(utils.GetPluginStagingDirInArtifactory(pluginName, pluginVersion), publishInProgressFileName), rtDetails)
+	return err
+}
+
+// Builds the plugin for the provided architectures concurrently, and returns the built executables' paths by architecture.
+func buildPlugins(pluginName, tmpDir string, arcs []string, options *publishOptions) (map[string]string, error) {
+	threads := options.threads
+	if threads < 1 {
+		threads = 1
+	}
+	var (
+		mutex       sync.Mutex
+		wg          sync.WaitGroup
+		buildErr    error
+		pluginPaths = make(map[string]string, len(arcs))
+		semaphore   = make(chan struct{}, threads)
+	)
+	for _, arc := range arcs {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(arc string) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			var pluginPath string
+			var err error
+			if options.manifest != nil {
+				pluginPath, err = buildPluginFromManifest(pluginName, filepath.Join(tmpDir, arc), arc, options.manifest)
+			} else {
+				pluginPath, err = buildPlugin(pluginName, filepath.Join(tmpDir, arc), utils.ArchitecturesMap[arc], options)
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				buildErr = errors.Join(buildErr, fmt.Errorf("failed building the plugin for '%s': %w", arc, err))
+				return
+			}
+			pluginPaths[arc] = pluginPath
+		}(arc)
+	}
+	wg.Wait()
+	return pluginPaths, buildErr
+}
+
+// Returns the requested architectures, keeping the order of the provided architectures.
+// If no architectures are requested, all the provided architectures are returned.
+func filterArchitectures(arcs, requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return arcs, nil
+	}
+	var requestedNames []string
+	for _, arc := range requested {
+		// Architectures may be requested by their legacy names.
+		arcName, _, _ := utils.GetArchitecture(arc)
+		if !slices.Contains(arcs, arcName) {
+			return nil, errorutils.CheckErrorf("unsupported architecture: '%s'", arc)
+		}
+		requestedNames = append(requestedNames, arcName)
+	}
+	var filtered []string
+	for _, arc := range arcs {
+		if slices.Contains(requestedNames, arc) {
+			filtered = append(filtered, arc)
 		}
 	}
+	return filtered, nil
+}
 
-	return copyToLatestDir(pluginName, pluginVersion, rtDetails)
+// Returns the provided architectures which are supported by the plugin manifest, keeping their order.
+func getManifestArchitectures(arcs []string, manifest *utils.PluginManifest) []string {
+	var supported []string
+	for _, arc := range arcs {
+		if manifest.IsSupported(arc) {
+			supported = append(supported, arc)
+		}
+	}
+	return supported
 }
 
 // Returns a slice of all supported architectures names, starting with the local architecture.
@@ -113,26 +356,41 @@ func verifyMatchingVersion(pluginFullPath, pluginVersion string) error {
 	if err != nil {
 		return err
 	}
-	pluginCmd := pluginsutils.PluginExecCmd{
-		ExecPath: pluginFullPath,
-		Command:  []string{pluginVersionCommandName},
-	}
-	output, err := io.RunCmdOutput(&pluginCmd)
+	output, err := runPluginVersionCmd(pluginFullPath)
 	if err != nil {
 		return err
 	}
 	return utils.AssertPluginVersion(output, pluginVersion)
 }
 
-func buildPlugin(pluginName, tmpDir string, arc utils.Architecture) (string, error) {
+// Returns the version reported by the plugin's executable.
+func getPluginExecVersion(pluginFullPath string) (string, error) {
+	output, err := runPluginVersionCmd(pluginFullPath)
+	if err != nil {
+		return "", err
+	}
+	return utils.GetPluginVersion(output)
+}
+
+func runPluginVersionCmd(pluginFullPath string) (string, error) {
+	pluginCmd := pluginsutils.PluginExecCmd{
+		ExecPath: pluginFullPath,
+		Command:  []string{pluginVersionCommandName},
+	}
+	return io.RunCmdOutput(&pluginCmd)
+}
+
+func buildPlugin(pluginName, outputDir string, arc utils.Architecture, options *publishOptions) (string, error) {
 	log.Info("Building plugin for: " + arc.Goos + "-" + arc.Goarch + "...")
-	outputPath := filepath.Join(tmpDir, pluginName+arc.FileExtension)
+	outputPath := filepath.Join(outputDir, pluginName+arc.FileExtension)
 	buildCmd := utils.PluginBuildCmd{
 		OutputFullPath: outputPath,
 		Env: map[string]string{
 			"GOOS":   arc.Goos,
 			"GOARCH": arc.Goarch,
 		},
+		Ldflags: options.ldflags,
+		Tags:    options.tags,
 	}
 	err := io.RunCmd(&buildCmd)
 	if err != nil {
@@ -141,6 +399,51 @@ func buildPlugin(pluginName, tmpDir string, arc utils.Architecture) (string, err
 	return outputPath, nil
 }
 
+// Returns the path of the plugin's executable for the provided architecture, as described by the manifest.
+// Prebuilt executables are copied to the output directory, so that they are uploaded under the plugin's name.
+func buildPluginFromManifest(pluginName, outputDir, arcName string, manifest *utils.PluginManifest) (string, error) {
+	arc := utils.ArchitecturesMap[arcName]
+	outputPath := filepath.Join(outputDir, pluginName+arc.FileExtension)
+	manifestArc := manifest.GetArchitecture(arcName)
+	if manifestArc.Binary != "" {
+		log.Info("Using prebuilt plugin for: " + arcName + "...")
+		err := buildinfoutils.CopyFile(outputDir, manifestArc.Binary)
+		if err != nil {
+			return "", errorutils.CheckError(err)
+		}
+		return outputPath, errorutils.CheckError(os.Rename(filepath.Join(outputDir, filepath.Base(manifestArc.Binary)), outputPath))
+	}
+
+	log.Info("Building plugin for: " + arcName + "...")
+	err := os.MkdirAll(outputDir, 0755)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	buildCmd, err := utils.NewPluginCustomBuildCmd(manifestArc.Build, utils.BuildTemplateData{
+		Output:        outputPath,
+		Arch:          arcName,
+		Goos:          arc.Goos,
+		Goarch:        arc.Goarch,
+		FileExtension: arc.FileExtension,
+	})
+	if err != nil {
+		return "", err
+	}
+	log.Debug("Running build command:", buildCmd.Command())
+	err = io.RunCmd(buildCmd)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	exists, err := fileutils.IsFileExists(outputPath, false)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", errorutils.CheckErrorf("the build command of '%s' didn't create the plugin's executable at '%s'", arcName, outputPath)
+	}
+	return outputPath, nil
+}
+
 // Get the Artifactory details corresponding to the server ID provided by env.
 func getRtDetails(c *cli.Context) (*config.ServerDetails, error) {
 	serverId := os.Getenv(utils.PluginsServerEnv)
@@ -148,7 +451,7 @@ func getRtDetails(c *cli.Context) (*config.ServerDetails, error) {
 		return nil, cliutils.PrintHelpAndReturnError("the "+utils.PluginsServerEnv+" env var is mandatory for the 'publish' command", c)
 	}
 
-	confDetails, err := config.GetSpecificConfig(serverId, false, true)
+	confDetails, err := credentialhelper.GetSpecificConfig(serverId, false, true)
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +463,7 @@ func getRtDetails(c *cli.Context) (*config.ServerDetails, error) {
 // Verify the plugin's provided version does not exist on the plugins server.
 func verifyUniqueVersion(pluginName, pluginVersion string, rtDetails *config.ServerDetails) error {
 	log.Info("Verifying version uniqueness...")
-	client, err := httpclient.ClientBuilder().Build()
+	client, err := httpclient.ClientBuilder().SetHttpClient(httptransport.NewClient()).Build()
 	if err != nil {
 		return err
 	}
@@ -178,8 +481,9 @@ func verifyUniqueVersion(pluginName, pluginVersion string, rtDetails *config.Ser
 	return errorutils.CheckResponseStatus(resp, http.StatusUnauthorized, http.StatusNotFound)
 }
 
+// Uploads the plugin for the provided architecture to the version's staging directory.
 func uploadPlugin(pluginLocalPath, pluginName, pluginVersion, arc string, rtDetails *config.ServerDetails) error {
-	pluginDirRtPath := utils.GetPluginDirPath(pluginName, pluginVersion, arc)
+	pluginDirRtPath := path.Join(utils.GetPluginStagingDirInArtifactory(pluginName, pluginVersion), arc)
 	log.Info("Upload plugin to: " + pluginDirRtPath + "...")
 	// First uploading resources directory (this is the complex part). If the upload is successful, upload the executable file.
 	// Upload plugin's resources directory if exists
@@ -193,16 +497,22 @@ func uploadPlugin(pluginLocalPath, pluginName, pluginVersion, arc string, rtDeta
 			return err
 		}
 		if !empty {
-			resourcesPattern := filepath.Join(coreutils.PluginsResourcesDirName, "(*)")
 			resourcesTargetPath := path.Join(pluginDirRtPath, coreutils.PluginsResourcesDirName+".zip")
-			err = uploadPluginsResources(resourcesPattern, resourcesTargetPath, rtDetails)
+			err = uploadPluginsResources(coreutils.PluginsResourcesDirName, resourcesTargetPath, rtDetails)
 			if err != nil {
 				return err
 			}
 		}
 	}
-	// Upload plugin's executable
 	execTargetPath := path.Join(pluginDirRtPath, utils.GetPluginExecutableName(pluginName, arc))
+	// Upload plugin's executable signature if a signing key is provided, before the executable itself.
+	if signingKeyPath := os.Getenv(utils.PluginsSigningKeyEnv); signingKeyPath != "" {
+		err = signAndUploadPluginFile(pluginLocalPath, execTargetPath+utils.SignatureFileExtension, signingKeyPath, rtDetails)
+		if err != nil {
+			return err
+		}
+	}
+	// Upload plugin's executable
 	err = uploadPluginsExec(pluginLocalPath, execTargetPath, rtDetails)
 	if err != nil {
 		return err
@@ -225,21 +535,71 @@ func uploadPluginsExec(pattern, target string, rtDetails *config.ServerDetails)
 	return nil
 }
 
-func uploadPluginsResources(pattern, target string, rtDetails *config.ServerDetails) error {
-	log.Debug("Upload plugin's resources to: " + target + "...")
-	result, err := createAndRunPluginsResourcesUploadCommand(pattern, target, rtDetails)
+// Signs the plugin's executable or resources zip, and uploads the detached signature.
+func signAndUploadPluginFile(fileLocalPath, target, signingKeyPath string, rtDetails *config.ServerDetails) error {
+	log.Debug("Signing " + filepath.Base(fileLocalPath) + " with the key at: " + signingKeyPath + "...")
+	signature, err := utils.SignFile(fileLocalPath, signingKeyPath)
 	if err != nil {
 		return err
 	}
-	if result.SuccessCount() == 0 {
-		return errorutils.CheckErrorf("plugin's resources upload failed as no files were affected. Verify source path is valid")
+	signaturePath := fileLocalPath + utils.SignatureFileExtension
+	err = os.WriteFile(signaturePath, []byte(signature), 0644)
+	if err != nil {
+		return errorutils.CheckError(err)
 	}
-	if result.SuccessCount() > 1 {
-		return errorutils.CheckErrorf("while zipping and uploading plugin's resources directory more than one file was uploaded. Unexpected behaviour, aborting")
+	log.Debug("Upload the signature to: " + target + "...")
+	result, err := createAndRunPluginsExecUploadCommand(signaturePath, target, rtDetails)
+	if err != nil {
+		return err
+	}
+	if result.SuccessCount() != 1 {
+		return errorutils.CheckErrorf("the signature upload failed. Expected a single file to be uploaded, but %d files were uploaded", result.SuccessCount())
 	}
 	return nil
 }
 
+// The resources directory is zipped locally, so the zip can be signed like the executable, and the signature is uploaded before the zip.
+func uploadPluginsResources(resourcesDir, target string, rtDetails *config.ServerDetails) (err error) {
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	resourcesZipPath := filepath.Join(tempDir, path.Base(target))
+	if err = zipPluginsResources(resourcesDir, resourcesZipPath); err != nil {
+		return err
+	}
+	if signingKeyPath := os.Getenv(utils.PluginsSigningKeyEnv); signingKeyPath != "" {
+		if err = signAndUploadPluginFile(resourcesZipPath, target+utils.SignatureFileExtension, signingKeyPath, rtDetails); err != nil {
+			return err
+		}
+	}
+	log.Debug("Upload plugin's resources to: " + target + "...")
+	result, err := createAndRunPluginsExecUploadCommand(resourcesZipPath, target, rtDetails)
+	if err != nil {
+		return err
+	}
+	if result.SuccessCount() != 1 {
+		return errorutils.CheckErrorf("plugin's resources upload failed. Expected a single file to be uploaded, but %d files were uploaded", result.SuccessCount())
+	}
+	return nil
+}
+
+// The zip contains the content of the resources directory, which is extracted to the resources directory on install.
+func zipPluginsResources(resourcesDir, resourcesZipPath string) error {
+	entries, err := os.ReadDir(resourcesDir)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	var sources []string
+	for _, entry := range entries {
+		sources = append(sources, filepath.Join(resourcesDir, entry.Name()))
+	}
+	return errorutils.CheckError(archiver.NewZip().Archive(sources, resourcesZipPath))
+}
+
 func createAndRunPluginsExecUploadCommand(pattern, target string, rtDetails *config.ServerDetails) (*commandsutils.Result, error) {
 	uploadCmd := generic.NewUploadCommand()
 	uploadCmd.SetUploadConfiguration(createUploadConfiguration()).
@@ -252,16 +612,117 @@ func createAndRunPluginsExecUploadCommand(pattern, target string, rtDetails *con
 	return uploadCmd.Result(), nil
 }
 
-func createAndRunPluginsResourcesUploadCommand(pattern, target string, rtDetails *config.ServerDetails) (*commandsutils.Result, error) {
-	uploadCmd := generic.NewUploadCommand()
-	uploadCmd.SetUploadConfiguration(createUploadConfiguration()).
-		SetServerDetails(rtDetails).
-		SetSpec(createResourcesUploadSpec(pattern, target))
-	err := uploadCmd.Run()
+// Returns the provided architectures, except for the architectures which were fully uploaded, or moved to the version's directory, by a previous publish.
+func getUnstagedArchitectures(pluginName, pluginVersion string, arcs []string, rtDetails *config.ServerDetails) ([]string, error) {
+	var unstaged []string
+	for _, arc := range arcs {
+		staged, err := isArchitectureInDir(utils.GetPluginStagingDirInArtifactory(pluginName, pluginVersion), pluginName, arc, rtDetails)
+		if err != nil {
+			return nil, err
+		}
+		if staged {
+			log.Info("The plugin is already staged for: " + arc + ". Skipping...")
+			continue
+		}
+		moved, err := isArchitectureInDir(utils.GetPluginVersionDirInArtifactory(pluginName, pluginVersion), pluginName, arc, rtDetails)
+		if err != nil {
+			return nil, err
+		}
+		if moved {
+			log.Info("The plugin is already published for: " + arc + ". Skipping...")
+			continue
+		}
+		unstaged = append(unstaged, arc)
+	}
+	return unstaged, nil
+}
+
+// An architecture is in the staging or the version's directory once it was uploaded or moved under its name and all of its legacy names.
+func isArchitectureInDir(dirRtPath, pluginName, arc string, rtDetails *config.ServerDetails) (bool, error) {
+	for _, arcName := range append([]string{arc}, utils.ArchitecturesMap[arc].LegacyNames...) {
+		// The executable is uploaded and moved last, so its existence indicates the architecture is complete.
+		exists, err := isExistsInArtifactory(path.Join(dirRtPath, arcName, utils.GetPluginExecutableName(pluginName, arcName)), rtDetails)
+		if err != nil || !exists {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func isExistsInArtifactory(rtPath string, rtDetails *config.ServerDetails) (bool, error) {
+	client, err := httpclient.ClientBuilder().SetHttpClient(httptransport.NewClient()).Build()
 	if err != nil {
-		return nil, err
+		return false, err
 	}
-	return uploadCmd.Result(), nil
+	resp, _, err := client.SendHead(clientutils.AddTrailingSlashIfNeeded(rtDetails.ArtifactoryUrl)+rtPath, utils.CreatePluginsHttpDetails(rtDetails), "")
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return true, nil
+	}
+	return false, errorutils.CheckResponseStatus(resp, http.StatusNotFound)
+}
+
+// Move the staged architectures to the version's directory, once all of them were uploaded successfully.
+// Each architecture is moved separately, and its executable is moved after its other files, so that a failed move leaves the
+// architecture staged, and it is moved again when the publish is resumed.
+func moveStagedVersion(pluginName, pluginVersion string, rtDetails *config.ServerDetails) error {
+	log.Info("Moving the staged version to its directory...")
+	stagingDir := utils.GetPluginStagingDirInArtifactory(pluginName, pluginVersion)
+	for _, arcName := range getAllArchitectureNames() {
+		staged, err := isExistsInArtifactory(path.Join(stagingDir, arcName, utils.GetPluginExecutableName(pluginName, arcName)), rtDetails)
+		if err != nil {
+			return err
+		}
+		if !staged {
+			continue
+		}
+		err = moveStaged(createMoveStagedResourcesSpec(pluginName, pluginVersion, arcName), rtDetails)
+		if err != nil {
+			return err
+		}
+		err = moveStaged(createMoveStagedExecSpec(pluginName, pluginVersion, arcName), rtDetails)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The move command only logs the artifacts which failed moving, so its result is verified too.
+func moveStaged(moveSpec *spec.SpecFiles, rtDetails *config.ServerDetails) error {
+	moveCmd := generic.NewMoveCommand()
+	moveCmd.SetServerDetails(rtDetails).SetSpec(moveSpec)
+	err := moveCmd.Run()
+	if err != nil {
+		return err
+	}
+	if failed := moveCmd.Result().FailCount(); failed > 0 {
+		return errorutils.CheckErrorf("failed moving %d staged files. Run the command again with --%s to complete the publish", failed, cliutils.Resume)
+	}
+	return nil
+}
+
+// Returns the names of all architectures, including their legacy names, sorted.
+func getAllArchitectureNames() []string {
+	var arcNames []string
+	for arc, arcDetails := range utils.ArchitecturesMap {
+		arcNames = append(arcNames, arc)
+		arcNames = append(arcNames, arcDetails.LegacyNames...)
+	}
+	slices.Sort(arcNames)
+	return arcNames
+}
+
+func deleteStagedVersion(pluginName, pluginVersion string, rtDetails *config.ServerDetails) error {
+	return deleteFromArtifactory(createDeleteStagedSpec(pluginName, pluginVersion), rtDetails)
+}
+
+func deleteFromArtifactory(deleteSpec *spec.SpecFiles, rtDetails *config.ServerDetails) error {
+	deleteCmd := generic.NewDeleteCommand()
+	deleteCmd.SetThreads(commonCliUtils.Threads).SetQuiet(true).SetServerDetails(rtDetails).SetSpec(deleteSpec)
+	return deleteCmd.Run()
 }
 
 // Copy the uploaded version to override latest dir.
@@ -284,21 +745,44 @@ func createCopySpec(pluginName, pluginVersion string) *spec.SpecFiles {
 		BuildSpec()
 }
 
-func createExecUploadSpec(source, target string) *spec.SpecFiles {
+func createMoveStagedResourcesSpec(pluginName, pluginVersion, arcName string) *spec.SpecFiles {
+	stagedArcDir := path.Join(utils.GetPluginStagingDirInArtifactory(pluginName, pluginVersion), arcName)
 	return spec.NewBuilder().
-		Pattern(source).
-		Target(target).
+		Pattern(path.Join(stagedArcDir, "(*)")).
+		Exclusions([]string{path.Join(stagedArcDir, utils.GetPluginExecutableName(pluginName, arcName))}).
+		Target(path.Join(utils.GetPluginVersionDirInArtifactory(pluginName, pluginVersion), arcName, "{1}")).
+		Flat(true).
+		Recursive(true).
 		BuildSpec()
 }
 
-// Resources directory is being uploaded to Artifactory in a zip file.
-func createResourcesUploadSpec(source, target string) *spec.SpecFiles {
+func createMoveStagedExecSpec(pluginName, pluginVersion, arcName string) *spec.SpecFiles {
+	execName := utils.GetPluginExecutableName(pluginName, arcName)
+	return spec.NewBuilder().
+		Pattern(path.Join(utils.GetPluginStagingDirInArtifactory(pluginName, pluginVersion), arcName, execName)).
+		Target(path.Join(utils.GetPluginVersionDirInArtifactory(pluginName, pluginVersion), arcName, execName)).
+		Flat(true).
+		BuildSpec()
+}
+
+func createDeleteStagedSpec(pluginName, pluginVersion string) *spec.SpecFiles {
+	return spec.NewBuilder().
+		Pattern(utils.GetPluginStagingDirInArtifactory(pluginName, pluginVersion) + "/").
+		Recursive(true).
+		BuildSpec()
+}
+
+func createDeleteVersionSpec(pluginName, pluginVersion string) *spec.SpecFiles {
+	return spec.NewBuilder().
+		Pattern(utils.GetPluginVersionDirInArtifactory(pluginName, pluginVersion) + "/").
+		Recursive(true).
+		BuildSpec()
+}
+
+func createExecUploadSpec(source, target string) *spec.SpecFiles {
 	return spec.NewBuilder().
 		Pattern(source).
 		Target(target).
-		Archive("zip").
-		Recursive(true).
-		TargetPathInArchive("{1}").
 		BuildSpec()
 }
 
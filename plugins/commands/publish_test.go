@@ -1,8 +1,14 @@
 package commands
 
 import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 )
 
@@ -27,3 +33,93 @@ func TestGetOrderedArchitectures(t *testing.T) {
 	assert.Error(t, err)
 
 }
+
+func TestFilterArchitectures(t *testing.T) {
+	arcs := []string{"linux-amd64", "mac-arm64", "windows-amd64"}
+
+	// All architectures are returned if none is requested.
+	filtered, err := filterArchitectures(arcs, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, arcs, filtered)
+
+	// The order of the provided architectures is kept.
+	filtered, err = filterArchitectures(arcs, []string{"windows-amd64", "linux-amd64"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"linux-amd64", "windows-amd64"}, filtered)
+
+	// Unsupported architectures are rejected.
+	_, err = filterArchitectures(arcs, []string{"made-up-arc"})
+	assert.Error(t, err)
+}
+
+func TestPluginBuildCmd(t *testing.T) {
+	buildCmd := utils.PluginBuildCmd{
+		OutputFullPath: "out",
+		Env:            map[string]string{"GOOS": "linux", "GOARCH": "arm64"},
+		Ldflags:        "-s -w",
+		Tags:           "netgo",
+	}
+	cmd := buildCmd.GetCmd()
+	assert.Equal(t, []string{"go", "build", "-ldflags", "-s -w", "-tags", "netgo", "-o", "out"}, cmd.Args)
+	assert.Contains(t, cmd.Env, "GOOS=linux")
+	assert.Contains(t, cmd.Env, "GOARCH=arm64")
+	assert.Contains(t, cmd.Env, "CGO_ENABLED=0")
+	// The env is set on the command only, to allow concurrent builds.
+	assert.Empty(t, buildCmd.GetEnv())
+}
+
+func TestGetManifestArchitectures(t *testing.T) {
+	manifest := &utils.PluginManifest{Architectures: map[string]utils.ManifestArchitecture{
+		"windows-amd64": {Binary: "dist/windows"},
+		"linux-amd64":   {Binary: "dist/linux"},
+	}}
+	arcs := []string{"mac-arm64", "linux-amd64", "windows-amd64"}
+	assert.Equal(t, []string{"linux-amd64", "windows-amd64"}, getManifestArchitectures(arcs, manifest))
+}
+
+func TestBuildPluginFromManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	binaryPath := filepath.Join(tmpDir, "prebuilt-binary")
+	assert.NoError(t, os.WriteFile(binaryPath, []byte("prebuilt"), 0755))
+	manifest := &utils.PluginManifest{
+		Build:         "echo built > {{.Output}}",
+		Architectures: map[string]utils.ManifestArchitecture{"windows-amd64": {Binary: binaryPath}},
+	}
+
+	// Prebuilt executables are copied under the plugin's name.
+	pluginPath, err := buildPluginFromManifest("my-plugin", filepath.Join(tmpDir, "windows-amd64"), "windows-amd64", manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(tmpDir, "windows-amd64", "my-plugin.exe"), pluginPath)
+	content, err := os.ReadFile(pluginPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "prebuilt", string(content))
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	// Other architectures are built with the default build command template.
+	pluginPath, err = buildPluginFromManifest("my-plugin", filepath.Join(tmpDir, "linux-amd64"), "linux-amd64", manifest)
+	assert.NoError(t, err)
+	assert.FileExists(t, pluginPath)
+
+	// A build command which doesn't create the executable fails.
+	manifest.Build = "true"
+	_, err = buildPluginFromManifest("my-plugin", filepath.Join(tmpDir, "mac-arm64"), "mac-arm64", manifest)
+	assert.ErrorContains(t, err, "didn't create the plugin's executable")
+}
+
+// A rollback never deletes a version which has no failed publish, since it may be published already.
+func TestRollbackWithoutFailedPublish(t *testing.T) {
+	var deleted bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete || r.Method == http.MethodPost {
+			deleted = true
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	err := runRollbackCmd("hello-frog", "v1.0.0", &config.ServerDetails{ArtifactoryUrl: server.URL + "/"})
+	assert.ErrorContains(t, err, "no failed publish")
+	assert.False(t, deleted)
+}
@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/plugins"
+	commandsUtils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+func SyncCmd(c *cli.Context) error {
+	if c.NArg() > 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	lockFilePath := commandsUtils.PluginsLockFileName
+	if c.NArg() == 1 {
+		lockFilePath = c.Args().Get(0)
+	}
+	err := plugins.CheckPluginsVersionAndConvertIfNeeded()
+	if err != nil {
+		return err
+	}
+	return runSyncCmd(lockFilePath)
+}
+
+// Installs, upgrades and removes plugins, so that the local plugins directory matches the plugins lock file exactly.
+// A failure to sync one plugin doesn't prevent syncing the others.
+func runSyncCmd(lockFilePath string) (err error) {
+	lockFile, err := commandsUtils.ReadPluginsLockFile(lockFilePath)
+	if err != nil {
+		return
+	}
+	pluginsDir, err := createPluginsDirIfNeeded()
+	if err != nil {
+		return
+	}
+	arc, err := commandsUtils.GetLocalArchitecture()
+	if err != nil {
+		return
+	}
+	lockedPlugins := make(map[string]bool, len(lockFile.Plugins))
+	for _, plugin := range lockFile.Plugins {
+		lockedPlugins[plugin.Name] = true
+		if syncErr := syncPlugin(pluginsDir, arc, plugin); syncErr != nil {
+			log.Error(fmt.Sprintf("failed syncing plugin '%s': %s", plugin.Name, syncErr.Error()))
+			err = errors.Join(err, syncErr)
+		}
+	}
+	return errors.Join(err, removeUnlockedPlugins(pluginsDir, lockedPlugins))
+}
+
+func syncPlugin(pluginsDir, arc string, plugin commandsUtils.LockedPlugin) error {
+	lockedArc, expectedSha256 := plugin.GetLockedArchitecture(arc)
+	synced, err := isPluginSynced(pluginsDir, plugin, expectedSha256)
+	if err != nil {
+		return err
+	}
+	if synced {
+		log.Info(fmt.Sprintf("Plugin '%s' is up to date with version '%s'.", plugin.Name, plugin.Version))
+		return nil
+	}
+	if expectedSha256 == "" {
+		log.Warn(fmt.Sprintf("The plugins lock file has no sha256 checksum of plugin '%s' for the '%s' architecture. Relying on the checksum reported by the plugins server.", plugin.Name, arc))
+	}
+
+	url, serverDetails, err := getServerDetails(plugin.ServerId)
+	if err != nil {
+		return err
+	}
+	httpDetails := commandsUtils.CreatePluginsHttpDetails(&serverDetails)
+	execDownloadUrl, err := getLockedExecDownloadUrl(url, plugin, arc, lockedArc, httpDetails)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Syncing plugin '%s' to version '%s'...", plugin.Name, plugin.Version))
+	_, err = replacePlugin(pluginsDir, plugin.Name, execDownloadUrl, httpDetails, func(execPath string) (string, error) {
+		if expectedSha256 != "" {
+			if err := commandsUtils.VerifySha256(execPath, expectedSha256); err != nil {
+				return "", err
+			}
+		}
+		output, err := runPluginVersionCmd(execPath)
+		if err != nil {
+			return "", err
+		}
+		return plugin.Version, commandsUtils.AssertPluginVersion(output, plugin.Version)
+	})
+	if err != nil {
+		return err
+	}
+	// Locked plugins are pinned, so that 'jf plugin update --all' doesn't drift from the lock file.
+	return commandsUtils.WritePluginDetails(filepath.Join(pluginsDir, plugin.Name), &commandsUtils.PluginDetails{
+		Version:  plugin.Version,
+		ServerId: plugin.ServerId,
+		Repo:     plugin.GetRepo(),
+		Pinned:   true,
+	})
+}
+
+// Returns the URL of the plugin's directory in registry for the locked architecture, so that the downloaded executable
+// is verified against its own checksum. If the plugin has no locked checksum for this machine, a compatible architecture is searched for.
+func getLockedExecDownloadUrl(url string, plugin commandsUtils.LockedPlugin, arc, lockedArc string, httpDetails httputils.HttpClientDetails) (string, error) {
+	if lockedArc == "" {
+		return getExecDownloadUrl(url, plugin.GetRepo(), plugin.Name, plugin.Version, httpDetails)
+	}
+	if arcName, _, _ := commandsUtils.GetArchitecture(lockedArc); arcName != arc {
+		log.Warn(fmt.Sprintf("The plugins lock file has no sha256 checksum of plugin '%s' for the '%s' architecture of this machine. Installing the compatible '%s' executable instead.", plugin.Name, arc, lockedArc))
+	}
+	return clientUtils.AddTrailingSlashIfNeeded(url) + commandsUtils.GetPluginVersionDirInRepo(plugin.GetRepo(), plugin.Name, plugin.Version) + "/" + lockedArc + "/", nil
+}
+
+// A plugin is synced if it was installed from the locked source and version, and its executable matches the locked checksum.
+func isPluginSynced(pluginsDir string, plugin commandsUtils.LockedPlugin, expectedSha256 string) (bool, error) {
+	details, err := commandsUtils.ReadPluginDetails(filepath.Join(pluginsDir, plugin.Name))
+	if err != nil || details == nil {
+		return false, err
+	}
+	if details.Version != plugin.Version || details.ServerId != plugin.ServerId || details.Repo != plugin.GetRepo() {
+		return false, nil
+	}
+	execPath := getPluginExecPath(pluginsDir, plugin.Name)
+	exists, err := fileutils.IsFileExists(execPath, false)
+	if err != nil || !exists {
+		return false, err
+	}
+	if expectedSha256 == "" {
+		return true, nil
+	}
+	if err = commandsUtils.VerifySha256(execPath, expectedSha256); err != nil {
+		log.Debug(err.Error())
+		return false, nil
+	}
+	return true, nil
+}
+
+func removeUnlockedPlugins(pluginsDir string, lockedPlugins map[string]bool) error {
+	pluginsDirContent, err := coreutils.GetPluginsDirContent()
+	if err != nil {
+		return err
+	}
+	for _, p := range pluginsDirContent {
+		if !p.IsDir() || lockedPlugins[p.Name()] {
+			continue
+		}
+		log.Info(fmt.Sprintf("Removing plugin '%s' which is not in the plugins lock file...", p.Name()))
+		if err = os.RemoveAll(filepath.Join(pluginsDir, p.Name())); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
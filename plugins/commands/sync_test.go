@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	biutils "github.com/jfrog/build-info-go/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/plugins"
+	coreTests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncInstalledPlugins(t *testing.T) {
+	// Create temp jfrog home
+	cleanUpJfrogHome, err := coreTests.SetJfrogHome()
+	if err != nil {
+		return
+	}
+	defer cleanUpJfrogHome()
+
+	pluginsDir, err := createPluginsDirIfNeeded()
+	assert.NoError(t, err)
+	pluginName := filepath.Base(pluginMockPath)
+	assert.NoError(t, biutils.CopyDir(pluginMockPath, filepath.Join(pluginsDir, pluginName), true, nil))
+	assert.NoError(t, biutils.CopyDir(pluginMockPath, filepath.Join(pluginsDir, "unlocked-plugin"), true, nil))
+	// Fix path for windows.
+	assert.NoError(t, os.Rename(filepath.Join(pluginsDir, pluginName, coreutils.PluginsExecDirName, pluginName), getPluginExecPath(pluginsDir, pluginName)))
+	execSha256, err := getFileSha256(getPluginExecPath(pluginsDir, pluginName))
+	assert.NoError(t, err)
+
+	lockedPlugin := utils.LockedPlugin{Name: pluginName, Version: "v1.0.0", ServerId: "my-server"}
+	// Plugins installed without details are not synced.
+	synced, err := isPluginSynced(pluginsDir, lockedPlugin, execSha256)
+	assert.NoError(t, err)
+	assert.False(t, synced)
+
+	// Plugins installed from the locked source, version and checksum are synced.
+	assert.NoError(t, utils.WritePluginDetails(filepath.Join(pluginsDir, pluginName), &utils.PluginDetails{Version: "v1.0.0", ServerId: "my-server", Repo: utils.DefaultPluginsRepo, Pinned: true}))
+	synced, err = isPluginSynced(pluginsDir, lockedPlugin, execSha256)
+	assert.NoError(t, err)
+	assert.True(t, synced)
+
+	// Plugins with a different checksum are not synced.
+	synced, err = isPluginSynced(pluginsDir, lockedPlugin, "0000")
+	assert.NoError(t, err)
+	assert.False(t, synced)
+
+	// Plugins with a different version are not synced.
+	lockedPlugin.Version = "v1.0.1"
+	synced, err = isPluginSynced(pluginsDir, lockedPlugin, execSha256)
+	assert.NoError(t, err)
+	assert.False(t, synced)
+
+	// Plugins which are not locked are removed.
+	assert.NoError(t, removeUnlockedPlugins(pluginsDir, map[string]bool{pluginName: true}))
+	exists, err := fileutils.IsDirExists(filepath.Join(pluginsDir, "unlocked-plugin"), false)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = fileutils.IsFileExists(filepath.Join(pluginsDir, pluginName, coreutils.PluginsExecDirName, plugins.GetLocalPluginExecutableName(pluginName)), false)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func getFileSha256(filePath string) (string, error) {
+	details, err := fileutils.GetFileDetails(filePath, true)
+	if err != nil {
+		return "", err
+	}
+	return details.Checksum.Sha256, nil
+}
@@ -0,0 +1,195 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	biutils "github.com/jfrog/build-info-go/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/plugins"
+	commandsUtils "github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+func UpdateCmd(c *cli.Context) error {
+	all := c.Bool(cliutils.All)
+	if (all && c.NArg() != 0) || (!all && c.NArg() != 1) {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	err := assertValidEnv(c)
+	if err != nil {
+		return err
+	}
+	err = plugins.CheckPluginsVersionAndConvertIfNeeded()
+	if err != nil {
+		return err
+	}
+	if all {
+		return runUpdateAllCmd()
+	}
+	return runUpdateCmd(c.Args().Get(0))
+}
+
+func runUpdateCmd(pluginName string) error {
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	if err != nil {
+		return err
+	}
+	pluginDir := filepath.Join(pluginsDir, pluginName)
+	exists, err := fileutils.IsDirExists(pluginDir, false)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return generateNoPluginFoundError(pluginName)
+	}
+	details, err := commandsUtils.ReadPluginDetails(pluginDir)
+	if err != nil {
+		return err
+	}
+	if details != nil && details.Pinned {
+		return errorutils.CheckErrorf("plugin '%s' is pinned to version '%s'. To unpin it and install the latest version, run 'jf plugin install %s'", pluginName, details.Version, pluginName)
+	}
+	return updatePlugin(pluginsDir, pluginName, getPluginDetailsOrDefault(details))
+}
+
+// Updates all the installed plugins, except for pinned plugins.
+// A failure to update one plugin doesn't prevent updating the others.
+func runUpdateAllCmd() (err error) {
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	if err != nil {
+		return
+	}
+	pluginsDirContent, err := coreutils.GetPluginsDirContent()
+	if err != nil {
+		return
+	}
+	for _, p := range pluginsDirContent {
+		if !p.IsDir() {
+			continue
+		}
+		details, readErr := commandsUtils.ReadPluginDetails(filepath.Join(pluginsDir, p.Name()))
+		if readErr != nil {
+			err = errors.Join(err, readErr)
+			continue
+		}
+		if details != nil && details.Pinned {
+			log.Info(fmt.Sprintf("Skipping plugin '%s' which is pinned to version '%s'.", p.Name(), details.Version))
+			continue
+		}
+		if updateErr := updatePlugin(pluginsDir, p.Name(), getPluginDetailsOrDefault(details)); updateErr != nil {
+			log.Error(fmt.Sprintf("failed updating plugin '%s': %s", p.Name(), updateErr.Error()))
+			err = errors.Join(err, updateErr)
+		}
+	}
+	return
+}
+
+// Plugins installed by older versions of JFrog CLI have no details, so they are updated from the plugins server and repo set by the environment.
+func getPluginDetailsOrDefault(details *commandsUtils.PluginDetails) *commandsUtils.PluginDetails {
+	if details != nil {
+		return details
+	}
+	return &commandsUtils.PluginDetails{
+		ServerId: os.Getenv(commandsUtils.PluginsServerEnv),
+		Repo:     commandsUtils.GetPluginsRepo(),
+	}
+}
+
+// Replaces the installed plugin with the latest version from the registry it was installed from.
+// The previous version is restored if the download fails, or if the new executable fails the version check.
+func updatePlugin(pluginsDir, pluginName string, details *commandsUtils.PluginDetails) (err error) {
+	url, serverDetails, err := getServerDetails(details.ServerId)
+	if err != nil {
+		return
+	}
+	httpDetails := commandsUtils.CreatePluginsHttpDetails(&serverDetails)
+	execDownloadUrl, err := getExecDownloadUrl(url, details.Repo, pluginName, commandsUtils.LatestVersionName, httpDetails)
+	if err != nil {
+		return
+	}
+	should, err := shouldDownloadPlugin(pluginsDir, pluginName, execDownloadUrl, httpDetails)
+	if err != nil {
+		return
+	}
+	if !should {
+		log.Info(fmt.Sprintf("Plugin '%s' is already up to date.", pluginName))
+		return
+	}
+
+	newVersion, err := replacePlugin(pluginsDir, pluginName, execDownloadUrl, httpDetails, getPluginExecVersion)
+	if err != nil {
+		return
+	}
+	log.Info(fmt.Sprintf("Plugin '%s' was updated to version '%s'.", pluginName, newVersion))
+	details.Version = newVersion
+	return commandsUtils.WritePluginDetails(filepath.Join(pluginsDir, pluginName), details)
+}
+
+// Downloads the plugin from the provided URL, replacing the installed plugin if exists.
+// The verify function receives the new executable's path and returns its version.
+// The previous installation is restored if the download fails, or if the new executable fails the verification.
+func replacePlugin(pluginsDir, pluginName, execDownloadUrl string, httpDetails httputils.HttpClientDetails, verify func(execPath string) (string, error)) (version string, err error) {
+	pluginDir := filepath.Join(pluginsDir, pluginName)
+	// The plugin's directory is removed below, so it's verified not to escape the plugins directory.
+	if relPath, relErr := filepath.Rel(pluginsDir, pluginDir); relErr != nil || relPath != pluginName {
+		return "", errorutils.CheckErrorf("invalid plugin name: '%s'", pluginName)
+	}
+	exists, err := fileutils.IsDirExists(pluginDir, false)
+	if err != nil {
+		return
+	}
+	if !exists {
+		if version, err = downloadAndVerifyPlugin(pluginsDir, pluginName, execDownloadUrl, httpDetails, verify); err != nil {
+			err = errors.Join(err, errorutils.CheckError(os.RemoveAll(pluginDir)))
+		}
+		return
+	}
+
+	backupDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(backupDir))
+	}()
+	log.Debug("Backing up plugin '" + pluginName + "' to: " + backupDir)
+	if err = biutils.CopyDir(pluginDir, backupDir, true, nil); err != nil {
+		return
+	}
+	if err = errorutils.CheckError(os.RemoveAll(pluginDir)); err != nil {
+		return
+	}
+
+	version, err = downloadAndVerifyPlugin(pluginsDir, pluginName, execDownloadUrl, httpDetails, verify)
+	if err != nil {
+		log.Warn(fmt.Sprintf("Rolling back plugin '%s' to its previous version...", pluginName))
+		err = errors.Join(err, restorePluginDir(backupDir, pluginDir))
+	}
+	return
+}
+
+func downloadAndVerifyPlugin(pluginsDir, pluginName, execDownloadUrl string, httpDetails httputils.HttpClientDetails, verify func(execPath string) (string, error)) (string, error) {
+	if err := downloadPlugin(pluginsDir, pluginName, execDownloadUrl, httpDetails); err != nil {
+		return "", err
+	}
+	version, err := verify(getPluginExecPath(pluginsDir, pluginName))
+	if err != nil {
+		return "", errors.Join(errorutils.CheckErrorf("the new version of plugin '%s' failed verification", pluginName), err)
+	}
+	return version, nil
+}
+
+func restorePluginDir(backupDir, pluginDir string) error {
+	if err := os.RemoveAll(pluginDir); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return biutils.CopyDir(backupDir, pluginDir, true, nil)
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+
+	biutils "github.com/jfrog/build-info-go/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	coreTests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	clientTestUtils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunUpdateCmdPinnedPlugin(t *testing.T) {
+	// Create temp jfrog home
+	cleanUpJfrogHome, err := coreTests.SetJfrogHome()
+	if err != nil {
+		return
+	}
+	defer cleanUpJfrogHome()
+
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	assert.NoError(t, err)
+	pluginName := filepath.Base(pluginMockPath)
+	pluginDir := filepath.Join(pluginsDir, pluginName)
+	assert.NoError(t, biutils.CopyDir(pluginMockPath, pluginDir, true, nil))
+
+	// Try updating a plugin that doesn't exist.
+	err = runUpdateCmd("non-existing-plugin")
+	assert.EqualError(t, err, generateNoPluginFoundError("non-existing-plugin").Error())
+
+	// Try updating a pinned plugin.
+	assert.NoError(t, utils.WritePluginDetails(pluginDir, &utils.PluginDetails{Version: "v1.0.0", Pinned: true}))
+	err = runUpdateCmd(pluginName)
+	assert.ErrorContains(t, err, "is pinned to version 'v1.0.0'")
+}
+
+func TestGetPluginDetailsOrDefault(t *testing.T) {
+	defer clientTestUtils.SetEnvWithCallbackAndAssert(t, utils.PluginsServerEnv, "my-server")()
+	defer clientTestUtils.SetEnvWithCallbackAndAssert(t, utils.PluginsRepoEnv, "my-repo")()
+
+	// Plugins installed without details are updated according to the environment.
+	details := getPluginDetailsOrDefault(nil)
+	assert.Equal(t, &utils.PluginDetails{ServerId: "my-server", Repo: "my-repo"}, details)
+
+	// Plugins installed with details are updated from the registry they were installed from.
+	installedDetails := &utils.PluginDetails{Version: "v1.0.0", ServerId: "other-server", Repo: "other-repo"}
+	assert.Equal(t, installedDetails, getPluginDetailsOrDefault(installedDetails))
+}
+
+func TestRestorePluginDir(t *testing.T) {
+	backupDir := t.TempDir()
+	pluginDir := filepath.Join(t.TempDir(), "plugin")
+	assert.NoError(t, biutils.CopyDir(pluginMockPath, backupDir, true, nil))
+
+	// Mock a partially downloaded plugin.
+	assert.NoError(t, biutils.CopyDir(filepath.Join(pluginMockPath, coreutils.PluginsExecDirName), filepath.Join(pluginDir, coreutils.PluginsExecDirName), true, nil))
+
+	assert.NoError(t, restorePluginDir(backupDir, pluginDir))
+	exists, err := fileutils.IsFileExists(filepath.Join(pluginDir, coreutils.PluginsResourcesDirName, "dir", "resource"), false)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
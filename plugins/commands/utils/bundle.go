@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Stored at the root of a plugin bundle, created by the 'export' command.
+// The bundle has a directory per architecture, containing the plugin's executable,
+// and optionally its detached signature and resources zip, as published to the plugins server.
+const PluginBundleMetadataFileName = "bundle.json"
+
+type PluginBundleMetadata struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// The sha256 checksum of the plugin's executable, per architecture name (as in ArchitecturesMap).
+	Sha256 map[string]string `json:"sha256"`
+	// The sha256 checksum of the plugin's resources zip, per architecture name, for architectures with resources.
+	ResourcesSha256 map[string]string `json:"resourcesSha256,omitempty"`
+}
+
+func ReadPluginBundleMetadata(bundleDir string) (*PluginBundleMetadata, error) {
+	content, err := fileutils.ReadFile(filepath.Join(bundleDir, PluginBundleMetadataFileName))
+	if err != nil {
+		return nil, err
+	}
+	metadata := new(PluginBundleMetadata)
+	if err = json.Unmarshal(content, metadata); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the plugin bundle metadata: %s", err.Error())
+	}
+	if metadata.Name == "" || len(metadata.Sha256) == 0 {
+		return nil, errorutils.CheckErrorf("the plugin bundle metadata must have a plugin name and at least one architecture")
+	}
+	// The plugin name is used as the name of the plugin's directory.
+	if strings.ContainsAny(metadata.Name, `/\`) || strings.Contains(metadata.Name, "..") {
+		return nil, errorutils.CheckErrorf("invalid plugin name in the plugin bundle metadata: '%s'", metadata.Name)
+	}
+	return metadata, nil
+}
+
+func WritePluginBundleMetadata(bundleDir string, metadata *PluginBundleMetadata) error {
+	content, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(filepath.Join(bundleDir, PluginBundleMetadataFileName), content, 0644))
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v2"
+)
+
+const PluginsLockFileName = "jfrog-plugins.yaml"
+
+// Declares the exact set of plugins that should be installed locally.
+type PluginsLockFile struct {
+	Plugins []LockedPlugin `yaml:"plugins"`
+}
+
+type LockedPlugin struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+	// An empty server ID means the plugin is installed from the official registry.
+	ServerId string `yaml:"serverId,omitempty"`
+	Repo     string `yaml:"repo,omitempty"`
+	// The expected sha256 checksum of the plugin's executable, per architecture name (as in ArchitecturesMap).
+	Sha256 map[string]string `yaml:"sha256,omitempty"`
+}
+
+// Returns the locked architecture to download for the provided architecture, with its expected sha256 checksum.
+// The architecture's legacy names and fallback architectures are used if the architecture has no checksum,
+// so that the downloaded executable is always the one the checksum was locked for.
+// Returns empty strings if the plugin has no checksum for any of them.
+func (lp *LockedPlugin) GetLockedArchitecture(arc string) (lockedArc, sha256 string) {
+	for _, candidate := range GetInstallCandidates(arc) {
+		if sha256, ok := lp.Sha256[candidate]; ok {
+			return candidate, sha256
+		}
+	}
+	return "", ""
+}
+
+// Returns the plugins repo, falling back to the default plugins repo.
+// Unlike GetPluginsRepo, the JFROG_CLI_PLUGINS_REPO env var is ignored, to keep installations reproducible.
+func (lp *LockedPlugin) GetRepo() string {
+	if lp.Repo != "" {
+		return lp.Repo
+	}
+	return DefaultPluginsRepo
+}
+
+func ReadPluginsLockFile(lockFilePath string) (*PluginsLockFile, error) {
+	content, err := fileutils.ReadFile(lockFilePath)
+	if err != nil {
+		return nil, err
+	}
+	lockFile := new(PluginsLockFile)
+	if err = yaml.Unmarshal(content, lockFile); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the plugins lock file at '%s': %s", lockFilePath, err.Error())
+	}
+	return lockFile, lockFile.validate()
+}
+
+func (lf *PluginsLockFile) validate() error {
+	names := make(map[string]bool, len(lf.Plugins))
+	for _, plugin := range lf.Plugins {
+		if plugin.Name == "" {
+			return errorutils.CheckErrorf("a plugin in the plugins lock file is missing a name")
+		}
+		// The plugin name is used as the name of the plugin's directory.
+		if strings.ContainsAny(plugin.Name, `/\`) || strings.Contains(plugin.Name, "..") {
+			return errorutils.CheckErrorf("invalid plugin name in the plugins lock file: '%s'", plugin.Name)
+		}
+		if names[plugin.Name] {
+			return errorutils.CheckErrorf("plugin '%s' appears more than once in the plugins lock file", plugin.Name)
+		}
+		names[plugin.Name] = true
+		if plugin.Version == "" || plugin.Version == LatestVersionName {
+			return errorutils.CheckErrorf("plugin '%s' in the plugins lock file must have an explicit version", plugin.Name)
+		}
+		for arc := range plugin.Sha256 {
+			if _, _, ok := GetArchitecture(arc); !ok {
+				return errorutils.CheckErrorf("plugin '%s' in the plugins lock file has a checksum for an unknown architecture: '%s'", plugin.Name, arc)
+			}
+		}
+	}
+	return nil
+}
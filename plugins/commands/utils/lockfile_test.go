@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPluginsLockFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{"valid", "plugins:\n  - name: hello-frog\n    version: v1.0.0\n    sha256:\n      linux-amd64: abc\n", ""},
+		{"missingName", "plugins:\n  - version: v1.0.0\n", "missing a name"},
+		{"pathTraversal", "plugins:\n  - name: ../hello-frog\n    version: v1.0.0\n", "invalid plugin name"},
+		{"separator", "plugins:\n  - name: hello/frog\n    version: v1.0.0\n", "invalid plugin name"},
+		{"duplicateName", "plugins:\n  - name: hello-frog\n    version: v1.0.0\n  - name: hello-frog\n    version: v1.0.1\n", "appears more than once"},
+		{"latestVersion", "plugins:\n  - name: hello-frog\n    version: latest\n", "must have an explicit version"},
+		{"unknownArchitecture", "plugins:\n  - name: hello-frog\n    version: v1.0.0\n    sha256:\n      made-up-arc: abc\n", "unknown architecture"},
+		{"invalidYaml", "plugins: [", "failed parsing"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lockFilePath := filepath.Join(t.TempDir(), PluginsLockFileName)
+			assert.NoError(t, os.WriteFile(lockFilePath, []byte(test.content), 0644))
+			lockFile, err := ReadPluginsLockFile(lockFilePath)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, lockFile.Plugins, 1)
+			assert.Equal(t, "hello-frog", lockFile.Plugins[0].Name)
+			assert.Equal(t, DefaultPluginsRepo, lockFile.Plugins[0].GetRepo())
+			assert.Equal(t, "abc", lockFile.Plugins[0].Sha256["linux-amd64"])
+		})
+	}
+}
+
+func TestLockedPluginGetLockedArchitecture(t *testing.T) {
+	plugin := LockedPlugin{Sha256: map[string]string{"mac-386": "mac", "windows-amd64": "windows"}}
+	tests := []struct {
+		arc            string
+		expectedArc    string
+		expectedSha256 string
+	}{
+		{"mac-amd64", "mac-386", "mac"},
+		{"mac-arm64", "mac-386", "mac"},
+		{"windows-arm64", "windows-amd64", "windows"},
+		{"linux-amd64", "", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.arc, func(t *testing.T) {
+			lockedArc, sha256 := plugin.GetLockedArchitecture(test.arc)
+			assert.Equal(t, test.expectedArc, lockedArc)
+			assert.Equal(t, test.expectedSha256, sha256)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package utils
+
+import (
+	"bytes"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v2"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"text/template"
+)
+
+// If exists in the plugin's directory, the 'publish' command uses this manifest instead of building the plugin with 'go build'.
+const PluginManifestFileName = "jfrog-plugin.yaml"
+
+// Describes how to get the plugin's executable for each architecture, allowing to publish plugins written in any language.
+// Example:
+//
+//	build: "./build.sh {{.Goos}} {{.Goarch}} {{.Output}}"
+//	architectures:
+//	  linux-amd64:
+//	    binary: dist/linux-amd64/my-plugin
+//	  windows-amd64:
+//	    build: "make windows OUTPUT={{.Output}}"
+type PluginManifest struct {
+	// Build command template, used for all the supported architectures which have no specific entry.
+	Build         string                          `yaml:"build,omitempty"`
+	Architectures map[string]ManifestArchitecture `yaml:"architectures,omitempty"`
+}
+
+// Either a path to a prebuilt executable, relative to the manifest's directory or absolute, or a build command template.
+type ManifestArchitecture struct {
+	Binary string `yaml:"binary,omitempty"`
+	Build  string `yaml:"build,omitempty"`
+}
+
+// The data available to the build command templates.
+type BuildTemplateData struct {
+	// The path to which the build command must write the executable.
+	Output string
+	// The architecture name, such as 'linux-amd64'.
+	Arch          string
+	Goos          string
+	Goarch        string
+	FileExtension string
+}
+
+func ReadPluginManifest(manifestPath string) (*PluginManifest, error) {
+	content, err := fileutils.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	manifest := new(PluginManifest)
+	if err = yaml.Unmarshal(content, manifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the plugin manifest at '%s': %s", manifestPath, err.Error())
+	}
+	if err = manifest.validate(); err != nil {
+		return nil, err
+	}
+	// Relative binary paths are relative to the manifest's directory, rather than to the working directory.
+	for arc, manifestArc := range manifest.Architectures {
+		if manifestArc.Binary != "" && !filepath.IsAbs(manifestArc.Binary) {
+			manifestArc.Binary = filepath.Join(filepath.Dir(manifestPath), manifestArc.Binary)
+			manifest.Architectures[arc] = manifestArc
+		}
+	}
+	return manifest, nil
+}
+
+func (pm *PluginManifest) validate() error {
+	if pm.Build == "" && len(pm.Architectures) == 0 {
+		return errorutils.CheckErrorf("the plugin manifest must have a build command template or at least one architecture")
+	}
+	if _, err := parseBuildTemplate(pm.Build); err != nil {
+		return err
+	}
+	architectures := make(map[string]ManifestArchitecture, len(pm.Architectures))
+	for arc, manifestArc := range pm.Architectures {
+		arcName, _, ok := GetArchitecture(arc)
+		if !ok {
+			return errorutils.CheckErrorf("the plugin manifest has an unknown architecture: '%s'", arc)
+		}
+		if _, exists := architectures[arcName]; exists {
+			return errorutils.CheckErrorf("architecture '%s' appears more than once in the plugin manifest", arcName)
+		}
+		// Architectures may be referred to by their legacy names.
+		architectures[arcName] = manifestArc
+		if (manifestArc.Binary == "") == (manifestArc.Build == "") {
+			return errorutils.CheckErrorf("architecture '%s' in the plugin manifest must have either a binary or a build command template", arc)
+		}
+		if _, err := parseBuildTemplate(manifestArc.Build); err != nil {
+			return err
+		}
+	}
+	pm.Architectures = architectures
+	return nil
+}
+
+// Returns the sorted names of the architectures the plugin can be published for.
+func (pm *PluginManifest) GetArchitectures() []string {
+	var arcs []string
+	for arc := range ArchitecturesMap {
+		if pm.IsSupported(arc) {
+			arcs = append(arcs, arc)
+		}
+	}
+	sort.Strings(arcs)
+	return arcs
+}
+
+func (pm *PluginManifest) IsSupported(arc string) bool {
+	_, ok := pm.Architectures[arc]
+	return ok || pm.Build != ""
+}
+
+// Returns the architecture's entry, falling back to the default build command template.
+func (pm *PluginManifest) GetArchitecture(arc string) ManifestArchitecture {
+	if manifestArc, ok := pm.Architectures[arc]; ok {
+		return manifestArc
+	}
+	return ManifestArchitecture{Build: pm.Build}
+}
+
+func parseBuildTemplate(buildTemplate string) (*template.Template, error) {
+	parsed, err := template.New("build").Option("missingkey=error").Parse(buildTemplate)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the build command template '%s': %s", buildTemplate, err.Error())
+	}
+	return parsed, nil
+}
+
+// Command used to build non-Go plugins, by running the manifest's build command template in a shell.
+type PluginCustomBuildCmd struct {
+	BuildTemplate string
+	Data          BuildTemplateData
+	command       string
+}
+
+func NewPluginCustomBuildCmd(buildTemplate string, data BuildTemplateData) (*PluginCustomBuildCmd, error) {
+	parsed, err := parseBuildTemplate(buildTemplate)
+	if err != nil {
+		return nil, err
+	}
+	var command bytes.Buffer
+	if err = parsed.Execute(&command, data); err != nil {
+		return nil, errorutils.CheckErrorf("failed rendering the build command template '%s': %s", buildTemplate, err.Error())
+	}
+	return &PluginCustomBuildCmd{BuildTemplate: buildTemplate, Data: data, command: command.String()}, nil
+}
+
+// Returns the rendered build command.
+func (buildCmd *PluginCustomBuildCmd) Command() string {
+	return buildCmd.command
+}
+
+func (buildCmd *PluginCustomBuildCmd) GetCmd() *exec.Cmd {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", buildCmd.command)
+	} else {
+		cmd = exec.Command("sh", "-c", buildCmd.command)
+	}
+	// The env is set on the command itself rather than on the process, to allow building several architectures concurrently.
+	cmd.Env = append(os.Environ(), "GOOS="+buildCmd.Data.Goos, "GOARCH="+buildCmd.Data.Goarch)
+	return cmd
+}
+
+func (buildCmd *PluginCustomBuildCmd) GetEnv() map[string]string {
+	return map[string]string{}
+}
+
+func (buildCmd *PluginCustomBuildCmd) GetStdWriter() io.WriteCloser {
+	return nil
+}
+
+func (buildCmd *PluginCustomBuildCmd) GetErrWriter() io.WriteCloser {
+	return nil
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPluginManifest(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectedArcs  []string
+		expectedError string
+	}{
+		{"binaries", "architectures:\n  linux-amd64:\n    binary: dist/linux\n  mac-arm64:\n    binary: dist/mac\n", []string{"linux-amd64", "mac-arm64"}, ""},
+		{"buildTemplate", "build: make OUTPUT={{.Output}}\n", nil, ""},
+		{"empty", "", nil, "must have a build command template or at least one architecture"},
+		{"unknownArchitecture", "architectures:\n  made-up-arc:\n    binary: dist/made-up\n", nil, "unknown architecture"},
+		{"binaryAndBuild", "architectures:\n  linux-amd64:\n    binary: dist/linux\n    build: make\n", nil, "either a binary or a build command template"},
+		{"invalidTemplate", "build: make OUTPUT={{.Output\n", nil, "failed parsing the build command template"},
+		{"invalidYaml", "architectures: [", nil, "failed parsing"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manifestPath := filepath.Join(t.TempDir(), PluginManifestFileName)
+			assert.NoError(t, os.WriteFile(manifestPath, []byte(test.content), 0644))
+			manifest, err := ReadPluginManifest(manifestPath)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			if test.expectedArcs != nil {
+				assert.Equal(t, test.expectedArcs, manifest.GetArchitectures())
+			} else {
+				// A default build command template supports all architectures.
+				assert.Len(t, manifest.GetArchitectures(), len(ArchitecturesMap))
+			}
+		})
+	}
+}
+
+func TestReadPluginManifestBinaryPaths(t *testing.T) {
+	manifestDir := t.TempDir()
+	absolutePath := filepath.Join(t.TempDir(), "my-plugin")
+	content := "architectures:\n  linux-amd64:\n    binary: dist/linux\n  mac-arm64:\n    binary: " + absolutePath + "\n  windows-amd64:\n    build: make\n"
+	manifestPath := filepath.Join(manifestDir, PluginManifestFileName)
+	assert.NoError(t, os.WriteFile(manifestPath, []byte(content), 0644))
+	manifest, err := ReadPluginManifest(manifestPath)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(manifestDir, "dist", "linux"), manifest.GetArchitecture("linux-amd64").Binary)
+	assert.Equal(t, absolutePath, manifest.GetArchitecture("mac-arm64").Binary)
+	assert.Equal(t, ManifestArchitecture{Build: "make"}, manifest.GetArchitecture("windows-amd64"))
+}
+
+func TestPluginManifestGetArchitecture(t *testing.T) {
+	manifest := &PluginManifest{
+		Build:         "make {{.Arch}}",
+		Architectures: map[string]ManifestArchitecture{"linux-amd64": {Binary: "dist/linux"}},
+	}
+	assert.Equal(t, ManifestArchitecture{Binary: "dist/linux"}, manifest.GetArchitecture("linux-amd64"))
+	assert.Equal(t, ManifestArchitecture{Build: "make {{.Arch}}"}, manifest.GetArchitecture("mac-arm64"))
+}
+
+func TestNewPluginCustomBuildCmd(t *testing.T) {
+	data := BuildTemplateData{Output: "out/my-plugin.exe", Arch: "windows-amd64", Goos: "windows", Goarch: "amd64", FileExtension: ".exe"}
+	buildCmd, err := NewPluginCustomBuildCmd("cargo build --target {{.Goarch}}-{{.Goos}} && cp target/my-plugin{{.FileExtension}} {{.Output}}", data)
+	assert.NoError(t, err)
+	assert.Equal(t, "cargo build --target amd64-windows && cp target/my-plugin.exe out/my-plugin.exe", buildCmd.Command())
+	assert.Contains(t, buildCmd.GetCmd().Env, "GOOS=windows")
+
+	// Unknown template fields fail the build.
+	_, err = NewPluginCustomBuildCmd("make {{.Target}}", data)
+	assert.ErrorContains(t, err, "failed rendering the build command template")
+}
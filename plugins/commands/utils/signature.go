@@ -0,0 +1,192 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+
+	gofrogcrypto "github.com/jfrog/gofrog/crypto"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+const (
+	// Path to a PEM encoded private key, used by the 'publish' command to sign the plugin's executables.
+	// If not provided, the plugin is published unsigned.
+	PluginsSigningKeyEnv = "JFROG_CLI_PLUGINS_SIGNING_KEY"
+	// Path to a PEM encoded public key, used by the 'install' command to verify the plugin's executable signature.
+	PluginsPublicKeyEnv = "JFROG_CLI_PLUGINS_PUBLIC_KEY"
+	// If true, the 'install' command refuses to install plugins without a valid signature.
+	PluginsRequireSignatureEnv = "JFROG_CLI_PLUGINS_REQUIRE_SIGNATURE"
+
+	// The detached signature is uploaded next to the executable, with the executable's name and this extension.
+	SignatureFileExtension = ".sig"
+)
+
+// Returns true if the unsigned plugins installation policy is enforced.
+func IsSignatureRequired() (bool, error) {
+	return clientutils.GetBoolEnvValue(PluginsRequireSignatureEnv, false)
+}
+
+// Asserts the file's sha256 checksum matches the expected checksum, as reported by Artifactory.
+func VerifySha256(filePath, expectedSha256 string) error {
+	if expectedSha256 == "" {
+		return errorutils.CheckErrorf("the plugins server did not report a sha256 checksum for '%s'", filePath)
+	}
+	actualSha256, err := CalcSha256(filePath)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(actualSha256, expectedSha256) {
+		return errorutils.CheckErrorf("sha256 checksum mismatch for '%s'. Expected: '%s', Actual: '%s'", filePath, expectedSha256, actualSha256)
+	}
+	return nil
+}
+
+func CalcSha256(filePath string) (string, error) {
+	checksums, err := gofrogcrypto.GetFileChecksums(filePath, gofrogcrypto.SHA256)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return checksums[gofrogcrypto.SHA256], nil
+}
+
+// Signs the file with the provided private key, and returns the base64 encoded signature, as produced by 'cosign sign-blob'.
+// Both ECDSA and Ed25519 keys are supported. ECDSA keys sign the file's sha256 digest, and their signatures are ASN.1 encoded.
+// Ed25519 keys sign the file's content.
+func SignFile(filePath, privateKeyPath string) (string, error) {
+	privateKey, err := readPrivateKey(privateKeyPath)
+	if err != nil {
+		return "", err
+	}
+	var signature []byte
+	switch key := privateKey.(type) {
+	case *ecdsa.PrivateKey:
+		var digest []byte
+		if digest, err = calcFileDigest(filePath); err != nil {
+			return "", err
+		}
+		signature, err = ecdsa.SignASN1(rand.Reader, key, digest)
+	case ed25519.PrivateKey:
+		var content []byte
+		if content, err = fileutils.ReadFile(filePath); err != nil {
+			return "", err
+		}
+		signature = ed25519.Sign(key, content)
+	default:
+		return "", errorutils.CheckErrorf("unsupported private key type at '%s'. Only ECDSA and Ed25519 keys are supported", privateKeyPath)
+	}
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// Verifies the base64 encoded signature of the file, as created by SignFile, using the provided public key.
+func VerifyFileSignature(filePath, signature, publicKeyPath string) error {
+	content, err := fileutils.ReadFile(publicKeyPath)
+	if err != nil {
+		return err
+	}
+	return VerifyFileSignatureWithKey(filePath, signature, content, fmt.Sprintf("'%s'", publicKeyPath))
+}
+
+// Verifies the base64 encoded signature of the file, as created by SignFile, using the PEM encoded public key.
+// The key's name is used in the error messages.
+func VerifyFileSignatureWithKey(filePath, signature string, publicKeyPem []byte, keyName string) error {
+	publicKey, err := parsePublicKey(publicKeyPem, keyName)
+	if err != nil {
+		return err
+	}
+	decodedSignature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(signature))
+	if err != nil {
+		return errorutils.CheckErrorf("failed decoding the signature of '%s': %s", filePath, err.Error())
+	}
+	var valid bool
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		digest, err := calcFileDigest(filePath)
+		if err != nil {
+			return err
+		}
+		valid = ecdsa.VerifyASN1(key, digest, decodedSignature)
+	case ed25519.PublicKey:
+		content, err := fileutils.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		valid = ed25519.Verify(key, content, decodedSignature)
+	default:
+		return errorutils.CheckErrorf("unsupported public key type at %s. Only ECDSA and Ed25519 keys are supported", keyName)
+	}
+	if !valid {
+		return errorutils.CheckErrorf("the signature of '%s' is invalid", filePath)
+	}
+	return nil
+}
+
+func calcFileDigest(filePath string) ([]byte, error) {
+	content, err := os.Open(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = content.Close()
+	}()
+	checksums, err := gofrogcrypto.CalcChecksumsBytes(content, gofrogcrypto.SHA256)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	digest := checksums[gofrogcrypto.SHA256]
+	if len(digest) != sha256.Size {
+		return nil, errorutils.CheckErrorf("failed calculating the sha256 digest of '%s'", filePath)
+	}
+	return digest, nil
+}
+
+func readPemBlock(keyPath string) (*pem.Block, error) {
+	content, err := fileutils.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	return decodePemBlock(content, fmt.Sprintf("'%s'", keyPath))
+}
+
+func decodePemBlock(content []byte, keyName string) (*pem.Block, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errorutils.CheckErrorf("no PEM encoded key was found at %s", keyName)
+	}
+	return block, nil
+}
+
+func readPrivateKey(keyPath string) (crypto.PrivateKey, error) {
+	block, err := readPemBlock(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type == "EC PRIVATE KEY" {
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		return key, errorutils.CheckError(err)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	return key, errorutils.CheckError(err)
+}
+
+func parsePublicKey(content []byte, keyName string) (crypto.PublicKey, error) {
+	block, err := decodePemBlock(content, keyName)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	return key, errorutils.CheckError(err)
+}
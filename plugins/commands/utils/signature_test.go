@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySha256(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "plugin")
+	assert.NoError(t, os.WriteFile(filePath, []byte("plugin"), 0644))
+
+	sha256Sum := sha256.Sum256([]byte("plugin"))
+	assert.NoError(t, VerifySha256(filePath, hex.EncodeToString(sha256Sum[:])))
+	assert.ErrorContains(t, VerifySha256(filePath, "0000"), "sha256 checksum mismatch")
+	assert.ErrorContains(t, VerifySha256(filePath, ""), "did not report a sha256 checksum")
+}
+
+func TestSignAndVerifyFile(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		privateKey crypto.Signer
+	}{
+		{"ecdsa", ecdsaKey},
+		{"ed25519", ed25519Key},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			filePath := filepath.Join(tmpDir, "plugin")
+			assert.NoError(t, os.WriteFile(filePath, []byte("plugin"), 0644))
+			privateKeyPath, publicKeyPath := writeKeyPair(t, tmpDir, test.privateKey)
+
+			signature, err := SignFile(filePath, privateKeyPath)
+			assert.NoError(t, err)
+			assert.NoError(t, VerifyFileSignature(filePath, signature, publicKeyPath))
+
+			// Tamper the file and make sure the verification fails.
+			assert.NoError(t, os.WriteFile(filePath, []byte("tampered"), 0644))
+			assert.ErrorContains(t, VerifyFileSignature(filePath, signature, publicKeyPath), "is invalid")
+		})
+	}
+}
+
+func writeKeyPair(t *testing.T, dir string, privateKey crypto.Signer) (privateKeyPath, publicKeyPath string) {
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	assert.NoError(t, err)
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	assert.NoError(t, err)
+	privateKeyPath = filepath.Join(dir, "private.pem")
+	publicKeyPath = filepath.Join(dir, "public.pem")
+	assert.NoError(t, os.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyBytes}), 0600))
+	assert.NoError(t, os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}), 0644))
+	return
+}
+
+// Ed25519 signatures are over the file's content, as created by 'cosign sign-blob'.
+func TestEd25519SignatureOverContent(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "plugin")
+	assert.NoError(t, os.WriteFile(filePath, []byte("plugin"), 0644))
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	_, publicKeyPath := writeKeyPair(t, tmpDir, privateKey)
+
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte("plugin")))
+	assert.NoError(t, VerifyFileSignature(filePath, signature, publicKeyPath))
+
+	createdSignature, err := SignFile(filePath, filepath.Join(tmpDir, "private.pem"))
+	assert.NoError(t, err)
+	decodedSignature, err := base64.StdEncoding.DecodeString(createdSignature)
+	assert.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey, []byte("plugin"), decodedSignature))
+}
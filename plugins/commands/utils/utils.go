@@ -1,13 +1,17 @@
 package utils
 
 import (
+	"encoding/json"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"golang.org/x/exp/slices"
 	"io"
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
 	"runtime"
 	"strings"
 )
@@ -20,25 +24,42 @@ const (
 	//#nosec G101
 	PluginsServerEnv = "JFROG_CLI_PLUGINS_SERVER"
 	// Used to set a custom plugins repo for the 'publish' & 'install' commands.
-	PluginsRepoEnv     = "JFROG_CLI_PLUGINS_REPO"
-	DefaultPluginsRepo = "jfrog-cli-plugins"
+	PluginsRepoEnv = "JFROG_CLI_PLUGINS_REPO"
+	// Used to add architectures to ArchitecturesMap, as comma-separated <name>=<goos>/<goarch> pairs.
+	PluginsArchitecturesEnv = "JFROG_CLI_PLUGINS_ARCHITECTURES"
+	DefaultPluginsRepo      = "jfrog-cli-plugins"
 
 	PluginsOfficialRegistryUrl = "https://releases.jfrog.io/artifactory/"
 
 	LatestVersionName = "latest"
+
+	// Architectures are uploaded to this directory under the plugin's directory, before being moved to the version's directory.
+	StagingDirName = ".staging"
+
+	// Stored in the plugin's local directory, and describes where the plugin was installed from.
+	PluginDetailsFileName = "details.json"
 )
 
+// The architectures plugins are published for, by their names in the plugins registry.
+// To support a new architecture, add it to this table (or provide it by the JFROG_CLI_PLUGINS_ARCHITECTURES env var) - it is then built by 'publish', and selected by 'install' on matching machines.
 var ArchitecturesMap = map[string]Architecture{
-	"linux-386":     {"linux", "386", ""},
-	"linux-amd64":   {"linux", "amd64", ""},
-	"linux-s390x":   {"linux", "s390x", ""},
-	"linux-arm64":   {"linux", "arm64", ""},
-	"linux-arm":     {"linux", "arm", ""},
-	"linux-ppc64":   {"linux", "ppc64", ""},
-	"linux-ppc64le": {"linux", "ppc64le", ""},
-	"mac-arm64":     {"darwin", "arm64", ""},
-	"mac-386":       {"darwin", "amd64", ""},
-	"windows-amd64": {"windows", "amd64", ".exe"},
+	"linux-386":     {Goos: "linux", Goarch: "386"},
+	"linux-amd64":   {Goos: "linux", Goarch: "amd64"},
+	"linux-s390x":   {Goos: "linux", Goarch: "s390x"},
+	"linux-arm64":   {Goos: "linux", Goarch: "arm64"},
+	"linux-arm":     {Goos: "linux", Goarch: "arm"},
+	"linux-ppc64":   {Goos: "linux", Goarch: "ppc64"},
+	"linux-ppc64le": {Goos: "linux", Goarch: "ppc64le"},
+	"linux-riscv64": {Goos: "linux", Goarch: "riscv64"},
+	"freebsd-amd64": {Goos: "freebsd", Goarch: "amd64"},
+	"freebsd-arm64": {Goos: "freebsd", Goarch: "arm64"},
+	// Apple silicon can run amd64 executables using Rosetta.
+	"mac-arm64": {Goos: "darwin", Goarch: "arm64", Fallbacks: []string{"mac-amd64"}},
+	// Older versions of JFrog CLI published and installed mac amd64 executables as 'mac-386'.
+	"mac-amd64":     {Goos: "darwin", Goarch: "amd64", LegacyNames: []string{"mac-386"}},
+	"windows-amd64": {Goos: "windows", Goarch: "amd64", FileExtension: ".exe"},
+	// Windows on ARM can run amd64 executables using emulation.
+	"windows-arm64": {Goos: "windows", Goarch: "arm64", FileExtension: ".exe", Fallbacks: []string{"windows-amd64"}},
 }
 
 // Returns plugin's directory path in Artifactory, corresponding to the local architecture.
@@ -50,12 +71,23 @@ func GetPluginDirPath(pluginName, pluginVersion, architecture string) (pluginDir
 
 // Returns plugin's executable name in Artifactory.
 func GetPluginExecutableName(pluginName, architecture string) string {
-	return pluginName + ArchitecturesMap[architecture].FileExtension
+	_, arc, _ := GetArchitecture(architecture)
+	return pluginName + arc.FileExtension
 }
 
 // Example path: "repo-name/plugin-name/v1.0.0/"
 func GetPluginVersionDirInArtifactory(pluginName, pluginVersion string) string {
-	return path.Join(GetPluginsRepo(), pluginName, pluginVersion)
+	return GetPluginVersionDirInRepo(GetPluginsRepo(), pluginName, pluginVersion)
+}
+
+// Example path: "repo-name/plugin-name/.staging/v1.0.0"
+func GetPluginStagingDirInArtifactory(pluginName, pluginVersion string) string {
+	return path.Join(GetPluginsRepo(), pluginName, StagingDirName, pluginVersion)
+}
+
+// Same as GetPluginVersionDirInArtifactory, but for an explicitly provided plugins repo.
+func GetPluginVersionDirInRepo(repo, pluginName, pluginVersion string) string {
+	return path.Join(repo, pluginName, pluginVersion)
 }
 
 // Returns a custom plugins repo if provided, default otherwise.
@@ -71,40 +103,95 @@ type Architecture struct {
 	Goos          string
 	Goarch        string
 	FileExtension string
+	// Names this architecture was published under by older versions of JFrog CLI.
+	// The plugin is published under these names too, and installed from them if missing under the architecture's name.
+	LegacyNames []string
+	// Architectures whose executables can also run on this architecture, by order of preference.
+	// Used for installing plugins which were not published for this architecture.
+	Fallbacks []string
+}
+
+// Adds an architecture to the supported architectures.
+func RegisterArchitecture(name string, arc Architecture) error {
+	if _, _, exists := GetArchitecture(name); exists {
+		return errorutils.CheckErrorf("architecture '%s' already exists", name)
+	}
+	for existingName, existing := range ArchitecturesMap {
+		if existing.Goos == arc.Goos && existing.Goarch == arc.Goarch {
+			return errorutils.CheckErrorf("architecture '%s' is already registered for %s/%s", existingName, arc.Goos, arc.Goarch)
+		}
+	}
+	ArchitecturesMap[name] = arc
+	return nil
+}
+
+// Registers the architectures provided by the JFROG_CLI_PLUGINS_ARCHITECTURES env var.
+// Architectures which were already registered by a previous command running in the same process are skipped.
+func RegisterArchitecturesFromEnv() error {
+	envValue := os.Getenv(PluginsArchitecturesEnv)
+	if envValue == "" {
+		return nil
+	}
+	for _, entry := range strings.Split(envValue, ",") {
+		name, platform, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		goos, goarch, _ := strings.Cut(platform, "/")
+		if name == "" || goos == "" || goarch == "" {
+			return errorutils.CheckErrorf("invalid architecture in the %s env var: '%s'. Expected <name>=<goos>/<goarch>", PluginsArchitecturesEnv, entry)
+		}
+		arc := Architecture{Goos: goos, Goarch: goarch}
+		if goos == "windows" {
+			arc.FileExtension = ".exe"
+		}
+		if existing, ok := ArchitecturesMap[name]; ok && existing.Goos == goos && existing.Goarch == goarch {
+			continue
+		}
+		if err := RegisterArchitecture(name, arc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the architecture by its name or one of its legacy names, together with its current name.
+func GetArchitecture(name string) (string, Architecture, bool) {
+	if arc, ok := ArchitecturesMap[name]; ok {
+		return name, arc, true
+	}
+	for arcName, arc := range ArchitecturesMap {
+		if slices.Contains(arc.LegacyNames, name) {
+			return arcName, arc, true
+		}
+	}
+	return "", Architecture{}, false
 }
 
 // Get the local architecture name corresponding to the architectures that exist in registry.
 func GetLocalArchitecture() (string, error) {
-	switch runtime.GOOS {
-	case "windows":
-		return "windows-amd64", nil
-	case "darwin":
-		if runtime.GOARCH == "arm64" {
-			return "mac-arm64", nil
-		} else {
-			return "mac-386", nil
+	for name, arc := range ArchitecturesMap {
+		if arc.Goos == runtime.GOOS && arc.Goarch == runtime.GOARCH {
+			return name, nil
 		}
 	}
-	// Assuming linux.
-	switch runtime.GOARCH {
-	case "amd64":
-		return "linux-amd64", nil
-	case "arm64":
-		return "linux-arm64", nil
-	case "arm":
-		return "linux-arm", nil
-	case "386":
-		return "linux-386", nil
-	case "s390x":
-		return "linux-s390x", nil
-	case "ppc64":
-		return "linux-ppc64", nil
-	case "ppc64le":
-		return "linux-ppc64le", nil
-	}
 	return "", errorutils.CheckErrorf("no compatible plugin architecture was found for the architecture of this machine")
 }
 
+// Returns the names under which a plugin for the provided architecture may be found in registry, by order of preference.
+// The architecture's own names come first, followed by the names of its fallback architectures.
+func GetInstallCandidates(name string) []string {
+	arcName, arc, ok := GetArchitecture(name)
+	if !ok {
+		return []string{name}
+	}
+	candidates := append([]string{arcName}, arc.LegacyNames...)
+	for _, fallback := range arc.Fallbacks {
+		if fallbackName, fallbackArc, ok := GetArchitecture(fallback); ok && !slices.Contains(candidates, fallbackName) {
+			candidates = append(candidates, fallbackName)
+			candidates = append(candidates, fallbackArc.LegacyNames...)
+		}
+	}
+	return candidates
+}
+
 func CreatePluginsHttpDetails(rtDetails *config.ServerDetails) httputils.HttpClientDetails {
 	if rtDetails.AccessToken != "" && rtDetails.ArtifactoryRefreshToken == "" {
 		return httputils.HttpClientDetails{AccessToken: rtDetails.AccessToken}
@@ -116,34 +203,92 @@ func CreatePluginsHttpDetails(rtDetails *config.ServerDetails) httputils.HttpCli
 
 // Asserts a plugin's version is as expected, by parsing the output of the version command.
 func AssertPluginVersion(versionCmdOut string, expectedPluginVersion string) error {
+	actualVersion, err := GetPluginVersion(versionCmdOut)
+	if err != nil {
+		return err
+	}
+	if actualVersion != expectedPluginVersion {
+		return errorutils.CheckErrorf("provided version does not match the plugin's actual version. " +
+			"Provided: '" + expectedPluginVersion + "', Actual: '" + actualVersion + "'")
+	}
+	return nil
+}
+
+// Returns a plugin's version, by parsing the output of the version command.
+func GetPluginVersion(versionCmdOut string) (string, error) {
 	// Get the actual version which is after the last space. (expected output to -v for example: "plugin-name version v1.0.0")
 	split := strings.Split(strings.TrimSpace(versionCmdOut), " ")
 	if len(split) != 3 {
-		return errorutils.CheckErrorf("failed verifying plugin version. Unexpected plugin output for version command: '" + versionCmdOut + "'")
+		return "", errorutils.CheckErrorf("failed verifying plugin version. Unexpected plugin output for version command: '" + versionCmdOut + "'")
 	}
-	if split[2] != expectedPluginVersion {
-		return errorutils.CheckErrorf("provided version does not match the plugin's actual version. " +
-			"Provided: '" + expectedPluginVersion + "', Actual: '" + split[2] + "'")
+	return split[2], nil
+}
+
+// Describes the origin of an installed plugin.
+type PluginDetails struct {
+	Version string `json:"version,omitempty"`
+	// An empty server ID means the plugin was installed from the official registry.
+	ServerId string `json:"serverId,omitempty"`
+	Repo     string `json:"repo,omitempty"`
+	// A pinned plugin was installed with an explicit version, and is skipped when updating all plugins.
+	Pinned bool `json:"pinned,omitempty"`
+	// The path of the plugin bundle the plugin was installed from, if it was installed offline.
+	Bundle string `json:"bundle,omitempty"`
+}
+
+// Reads the details of an installed plugin. Plugins installed by older versions of JFrog CLI have no details file, in which case nil is returned.
+func ReadPluginDetails(pluginDir string) (*PluginDetails, error) {
+	detailsPath := filepath.Join(pluginDir, PluginDetailsFileName)
+	exists, err := fileutils.IsFileExists(detailsPath, false)
+	if err != nil || !exists {
+		return nil, err
 	}
-	return nil
+	content, err := fileutils.ReadFile(detailsPath)
+	if err != nil {
+		return nil, err
+	}
+	details := new(PluginDetails)
+	return details, errorutils.CheckError(json.Unmarshal(content, details))
+}
+
+func WritePluginDetails(pluginDir string, details *PluginDetails) error {
+	content, err := json.Marshal(details)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(filepath.Join(pluginDir, PluginDetailsFileName), content, 0600))
 }
 
 // Command used to build plugins.
 type PluginBuildCmd struct {
 	OutputFullPath string
 	Env            map[string]string
+	Ldflags        string
+	Tags           string
 }
 
 func (buildCmd *PluginBuildCmd) GetCmd() *exec.Cmd {
 	var cmd []string
-	cmd = append(cmd, []string{"go", "build", "-o"}...)
-	cmd = append(cmd, buildCmd.OutputFullPath)
-	return exec.Command(cmd[0], cmd[1:]...)
+	cmd = append(cmd, []string{"go", "build"}...)
+	if buildCmd.Ldflags != "" {
+		cmd = append(cmd, "-ldflags", buildCmd.Ldflags)
+	}
+	if buildCmd.Tags != "" {
+		cmd = append(cmd, "-tags", buildCmd.Tags)
+	}
+	cmd = append(cmd, "-o", buildCmd.OutputFullPath)
+	execCmd := exec.Command(cmd[0], cmd[1:]...)
+	// The env is set on the command itself rather than on the process, to allow building several architectures concurrently.
+	execCmd.Env = os.Environ()
+	for key, value := range buildCmd.Env {
+		execCmd.Env = append(execCmd.Env, key+"="+value)
+	}
+	execCmd.Env = append(execCmd.Env, "CGO_ENABLED=0")
+	return execCmd
 }
 
 func (buildCmd *PluginBuildCmd) GetEnv() map[string]string {
-	buildCmd.Env["CGO_ENABLED"] = "0"
-	return buildCmd.Env
+	return map[string]string{}
 }
 
 func (buildCmd *PluginBuildCmd) GetStdWriter() io.WriteCloser {
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPluginVersion(t *testing.T) {
+	version, err := GetPluginVersion("hello-frog version v1.0.0\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0", version)
+
+	_, err = GetPluginVersion("v1.0.0")
+	assert.Error(t, err)
+}
+
+func TestReadWritePluginDetails(t *testing.T) {
+	pluginDir := t.TempDir()
+
+	// Plugins installed by older versions of JFrog CLI have no details.
+	details, err := ReadPluginDetails(pluginDir)
+	assert.NoError(t, err)
+	assert.Nil(t, details)
+
+	expected := &PluginDetails{Version: "v1.0.0", ServerId: "my-server", Repo: "my-repo", Pinned: true}
+	assert.NoError(t, WritePluginDetails(pluginDir, expected))
+	details, err = ReadPluginDetails(pluginDir)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, details)
+}
+
+func TestGetArchitecture(t *testing.T) {
+	// Legacy names are resolved to the architecture's current name.
+	name, arc, ok := GetArchitecture("mac-386")
+	assert.True(t, ok)
+	assert.Equal(t, "mac-amd64", name)
+	assert.Equal(t, "darwin", arc.Goos)
+	assert.Equal(t, "amd64", arc.Goarch)
+
+	name, _, ok = GetArchitecture("windows-arm64")
+	assert.True(t, ok)
+	assert.Equal(t, "windows-arm64", name)
+
+	_, _, ok = GetArchitecture("made-up-arc")
+	assert.False(t, ok)
+}
+
+func TestGetInstallCandidates(t *testing.T) {
+	assert.Equal(t, []string{"linux-amd64"}, GetInstallCandidates("linux-amd64"))
+	assert.Equal(t, []string{"mac-amd64", "mac-386"}, GetInstallCandidates("mac-amd64"))
+	assert.Equal(t, []string{"mac-arm64", "mac-amd64", "mac-386"}, GetInstallCandidates("mac-arm64"))
+	assert.Equal(t, []string{"windows-arm64", "windows-amd64"}, GetInstallCandidates("windows-arm64"))
+}
+
+func TestRegisterArchitecture(t *testing.T) {
+	defer delete(ArchitecturesMap, "linux-loong64")
+	assert.NoError(t, RegisterArchitecture("linux-loong64", Architecture{Goos: "linux", Goarch: "loong64"}))
+	assert.Equal(t, "linux-loong64", GetInstallCandidates("linux-loong64")[0])
+
+	// Names and platforms must be unique.
+	assert.ErrorContains(t, RegisterArchitecture("mac-386", Architecture{Goos: "darwin", Goarch: "386"}), "already exists")
+	assert.ErrorContains(t, RegisterArchitecture("linux-x64", Architecture{Goos: "linux", Goarch: "amd64"}), "already registered")
+}
+
+func TestRegisterArchitecturesFromEnv(t *testing.T) {
+	defer delete(ArchitecturesMap, "linux-loong64")
+	defer delete(ArchitecturesMap, "windows-386")
+	t.Setenv(PluginsArchitecturesEnv, "linux-loong64=linux/loong64, windows-386=windows/386")
+	assert.NoError(t, RegisterArchitecturesFromEnv())
+	assert.Equal(t, Architecture{Goos: "linux", Goarch: "loong64"}, ArchitecturesMap["linux-loong64"])
+	assert.Equal(t, ".exe", ArchitecturesMap["windows-386"].FileExtension)
+	// Commands running in the same process register the same architectures again.
+	assert.NoError(t, RegisterArchitecturesFromEnv())
+
+	t.Setenv(PluginsArchitecturesEnv, "linux-loong64")
+	assert.ErrorContains(t, RegisterArchitecturesFromEnv(), "invalid architecture")
+	t.Setenv(PluginsArchitecturesEnv, "linux-mips=linux/amd64")
+	assert.ErrorContains(t, RegisterArchitecturesFromEnv(), "already registered")
+}
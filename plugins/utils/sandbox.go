@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	generic "github.com/jfrog/jfrog-cli-core/v2/general/token"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/credentialhelper"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/slices"
+	"os"
+	"strings"
+)
+
+const (
+	// Set to true to run plugins in a sandbox, isolated from JFrog CLI's home directory and credentials. Plugins which declare
+	// access token scopes in their signature get a scoped short-lived access token, instead of reading the credentials from JFrog CLI's config.
+	PluginsSandboxEnv = "JFROG_CLI_PLUGINS_SANDBOX"
+
+	// Injected to the environment of plugins run in the sandbox.
+	PluginUrlEnv         = "JFROG_CLI_PLUGIN_URL"
+	PluginAccessTokenEnv = "JFROG_CLI_PLUGIN_ACCESS_TOKEN"
+
+	pluginTokenExpirySeconds uint = 3600
+)
+
+// Creates an access token with the provided scope, for the provided server.
+type scopedTokenCreator func(serverDetails *config.ServerDetails, scope string) (string, error)
+
+func isSandboxEnabled() (bool, error) {
+	return clientutils.GetBoolEnvValue(PluginsSandboxEnv, false)
+}
+
+// The JFrog env vars which plugins inherit in the sandbox. Other JFrog env vars may hold credentials or point at them, and are removed.
+var sandboxInheritedEnv = []string{coreutils.LogLevel, coreutils.LogTimestamp, coreutils.TempDir, coreutils.BuildName, coreutils.BuildNumber, coreutils.Project}
+
+// Returns the environment to run the plugin with, and a function which removes the resources created for the plugin's run.
+// Outside the sandbox, the plugin inherits the CLI's environment as is.
+// In the sandbox, the plugin gets an empty JFrog CLI home directory instead of the CLI's home, which holds its config and credentials,
+// and the JFrog env vars are removed. If the plugin declares access token scopes, a token with these scopes is created for the default server,
+// and passed to the plugin together with the server's URL.
+func getPluginEnv(sig *PluginSignature, createToken scopedTokenCreator) (env []string, cleanup func(), err error) {
+	cleanup = func() {}
+	env = os.Environ()
+	sandbox, err := isSandboxEnabled()
+	if err != nil || !sandbox {
+		return env, cleanup, err
+	}
+	var serverDetails *config.ServerDetails
+	if len(sig.Scopes) > 0 {
+		// The server is read before the env vars which configure it are removed.
+		serverDetails, err = credentialhelper.GetSpecificConfig(os.Getenv(coreutils.ServerID), true, false)
+		if err != nil {
+			return nil, cleanup, err
+		}
+	}
+	sandboxHomeDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return nil, cleanup, err
+	}
+	cleanup = func() {
+		if removeErr := fileutils.RemoveTempDir(sandboxHomeDir); removeErr != nil {
+			log.Debug("Failed removing the plugin's home dir:", removeErr.Error())
+		}
+	}
+	env = append(removeJfrogEnv(env), coreutils.HomeDir+"="+sandboxHomeDir)
+	if serverDetails == nil {
+		log.Debug(fmt.Sprintf("Plugin '%s' declares no access token scopes. Running it without credentials.", sig.Name))
+		return env, cleanup, nil
+	}
+	if serverDetails.Url == "" {
+		cleanup()
+		return nil, func() {}, errorutils.CheckErrorf("plugin '%s' requires a JFrog Platform URL, but no server is configured", sig.Name)
+	}
+	log.Debug(fmt.Sprintf("Creating an access token for plugin '%s' with the scopes: %s", sig.Name, strings.Join(sig.Scopes, ", ")))
+	token, err := createToken(serverDetails, strings.Join(sig.Scopes, " "))
+	if err != nil {
+		cleanup()
+		return nil, func() {}, err
+	}
+	return append(env,
+		PluginUrlEnv+"="+clientutils.AddTrailingSlashIfNeeded(serverDetails.Url),
+		PluginAccessTokenEnv+"="+token), cleanup, nil
+}
+
+// Creates a non-refreshable access token, which expires shortly after the plugin's run.
+func createScopedAccessToken(serverDetails *config.ServerDetails, scope string) (string, error) {
+	if serverDetails.AccessToken == "" {
+		return "", errorutils.CheckErrorf("authenticating with access token is currently mandatory for running plugins in the sandbox")
+	}
+	expiry := pluginTokenExpirySeconds
+	tokenCreateCmd := generic.NewAccessTokenCreateCommand()
+	tokenCreateCmd.
+		SetServerDetails(serverDetails).
+		SetUsername(serverDetails.GetUser()).
+		SetScope(scope).
+		SetExpiry(&expiry).
+		SetDescription("Short-lived token created by JFrog CLI for running a plugin")
+	if err := commands.Exec(tokenCreateCmd); err != nil {
+		return "", err
+	}
+	content, err := tokenCreateCmd.Response()
+	if err != nil {
+		return "", err
+	}
+	response := struct {
+		AccessToken string `json:"access_token"`
+	}{}
+	if err = json.Unmarshal(content, &response); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return response.AccessToken, nil
+}
+
+// Removes the JFrog env vars from the environment, except for the ones plugins inherit in the sandbox.
+func removeJfrogEnv(env []string) []string {
+	var filtered []string
+	for _, keyValue := range env {
+		key, _, _ := strings.Cut(keyValue, "=")
+		isJfrogEnv := strings.HasPrefix(key, "JFROG_") || strings.HasPrefix(key, "JF_")
+		if !isJfrogEnv || slices.Contains(sandboxInheritedEnv, key) {
+			filtered = append(filtered, keyValue)
+		}
+	}
+	return filtered
+}
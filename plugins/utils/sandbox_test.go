@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	coreTests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	clientTestUtils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPluginEnv(t *testing.T) {
+	cleanUpJfrogHome, err := coreTests.SetJfrogHome()
+	assert.NoError(t, err)
+	defer cleanUpJfrogHome()
+	assert.NoError(t, config.SaveServersConf([]*config.ServerDetails{{ServerId: "my-server", Url: "https://my-server.jfrog.io", AccessToken: "master-token", IsDefault: true}}))
+	defer clientTestUtils.SetEnvWithCallbackAndAssert(t, coreutils.EncryptionKey, "0123456789abcdef0123456789abcdef")()
+	defer clientTestUtils.SetEnvWithCallbackAndAssert(t, "JF_ACCESS_TOKEN", "env-token")()
+	defer clientTestUtils.SetEnvWithCallbackAndAssert(t, coreutils.LogLevel, "DEBUG")()
+	jfrogHome := os.Getenv(coreutils.HomeDir)
+
+	var requestedScope string
+	createToken := func(serverDetails *config.ServerDetails, scope string) (string, error) {
+		requestedScope = scope
+		return "scoped-token", nil
+	}
+	sig := &PluginSignature{PluginSignature: components.PluginSignature{Name: "plugin-mock"}, Scopes: []string{"applied-permissions/groups:readers", "applied-permissions/user"}}
+
+	// Outside the sandbox, the plugin inherits the environment as is.
+	env, cleanup, err := getPluginEnv(sig, createToken)
+	assert.NoError(t, err)
+	cleanup()
+	assert.Contains(t, env, coreutils.EncryptionKey+"=0123456789abcdef0123456789abcdef")
+	assert.Contains(t, env, coreutils.HomeDir+"="+jfrogHome)
+	assert.Empty(t, requestedScope)
+
+	// In the sandbox, the plugin gets a scoped token and an empty home dir, instead of the CLI's home dir and credentials.
+	defer clientTestUtils.SetEnvWithCallbackAndAssert(t, PluginsSandboxEnv, "true")()
+	env, cleanup, err = getPluginEnv(sig, createToken)
+	assert.NoError(t, err)
+	assert.NotContains(t, env, coreutils.EncryptionKey+"=0123456789abcdef0123456789abcdef")
+	assert.NotContains(t, env, "JF_ACCESS_TOKEN=env-token")
+	assert.NotContains(t, env, coreutils.HomeDir+"="+jfrogHome)
+	assert.Contains(t, env, coreutils.LogLevel+"=DEBUG")
+	sandboxHomeDir := getEnvValue(env, coreutils.HomeDir)
+	assert.NotEmpty(t, sandboxHomeDir)
+	assert.DirExists(t, sandboxHomeDir)
+	cleanup()
+	assert.NoDirExists(t, sandboxHomeDir)
+	assert.Contains(t, env, PluginUrlEnv+"=https://my-server.jfrog.io/")
+	assert.Contains(t, env, PluginAccessTokenEnv+"=scoped-token")
+	assert.Equal(t, "applied-permissions/groups:readers applied-permissions/user", requestedScope)
+
+	// Plugins which declare no scopes get no credentials.
+	sig.Scopes = nil
+	env, cleanup, err = getPluginEnv(sig, createToken)
+	assert.NoError(t, err)
+	defer cleanup()
+	assert.NotContains(t, env, PluginAccessTokenEnv+"=scoped-token")
+}
+
+// Returns the value of the last occurrence of the env var, as it takes precedence.
+func getEnvValue(env []string, key string) (value string) {
+	for _, keyValue := range env {
+		if envKey, envValue, _ := strings.Cut(keyValue, "="); envKey == key {
+			value = envValue
+		}
+	}
+	return
+}
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	gofrogcrypto "github.com/jfrog/gofrog/crypto"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// Stored in the plugins directory, to avoid running the plugins' executables on every CLI invocation.
+	signaturesCacheFileName = "signatures-cache.json"
+	signaturesCacheVersion  = 2
+)
+
+// Caches the plugins' signatures, keyed by the executable's path.
+// A cached signature is valid as long as the executable's size and modification time are unchanged.
+// If only the modification time changed, the executable's sha256 checksum is compared, to avoid running it if the content is the same.
+type signaturesCache struct {
+	Version int                             `json:"version"`
+	Entries map[string]*signatureCacheEntry `json:"entries"`
+	// Executables which were looked up in the current run. Entries of other executables are stale, and dropped when saving.
+	used    map[string]bool
+	changed bool
+}
+
+type signatureCacheEntry struct {
+	Size      int64            `json:"size"`
+	ModTime   int64            `json:"modTime"`
+	Sha256    string           `json:"sha256"`
+	Signature *PluginSignature `json:"signature"`
+}
+
+func newSignaturesCache() *signaturesCache {
+	return &signaturesCache{Version: signaturesCacheVersion, Entries: map[string]*signatureCacheEntry{}, used: map[string]bool{}}
+}
+
+// Loads the signatures cache from the plugins directory.
+// A missing, corrupted or outdated cache is replaced by an empty cache, which is rebuilt transparently.
+func loadSignaturesCache(pluginsDir string) *signaturesCache {
+	cache := newSignaturesCache()
+	content, err := os.ReadFile(filepath.Join(pluginsDir, signaturesCacheFileName))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Debug("Failed reading the plugins signatures cache:", err.Error())
+			cache.changed = true
+		}
+		return cache
+	}
+	loaded := newSignaturesCache()
+	if err = json.Unmarshal(content, loaded); err != nil || loaded.Version != signaturesCacheVersion || loaded.Entries == nil {
+		log.Debug("The plugins signatures cache is corrupted or outdated. Rebuilding it...")
+		cache.changed = true
+		return cache
+	}
+	return loaded
+}
+
+func (sc *signaturesCache) get(execPath string, fileInfo os.FileInfo) *PluginSignature {
+	sc.used[execPath] = true
+	entry, ok := sc.Entries[execPath]
+	if !ok || entry == nil || entry.Signature == nil || entry.Size != fileInfo.Size() {
+		return nil
+	}
+	if entry.ModTime != fileInfo.ModTime().UnixNano() {
+		sha256, err := calcSha256(execPath)
+		if err != nil || sha256 != entry.Sha256 {
+			return nil
+		}
+		entry.ModTime = fileInfo.ModTime().UnixNano()
+		sc.changed = true
+	}
+	signature := *entry.Signature
+	signature.ExecutablePath = execPath
+	return &signature
+}
+
+func (sc *signaturesCache) put(execPath string, fileInfo os.FileInfo, signature *PluginSignature) {
+	sc.used[execPath] = true
+	sha256, err := calcSha256(execPath)
+	if err != nil {
+		log.Debug("Failed calculating the checksum of plugin's executable at '"+execPath+"':", err.Error())
+		return
+	}
+	sc.Entries[execPath] = &signatureCacheEntry{
+		Size:      fileInfo.Size(),
+		ModTime:   fileInfo.ModTime().UnixNano(),
+		Sha256:    sha256,
+		Signature: signature,
+	}
+	sc.changed = true
+}
+
+// Saves the cache to the plugins directory, after dropping the entries of executables which no longer exist.
+// Failing to save the cache is not fatal, so errors are only logged.
+func (sc *signaturesCache) saveIfChanged(pluginsDir string) {
+	for execPath := range sc.Entries {
+		if !sc.used[execPath] {
+			delete(sc.Entries, execPath)
+			sc.changed = true
+		}
+	}
+	if !sc.changed {
+		return
+	}
+	content, err := json.Marshal(sc)
+	if err != nil {
+		log.Debug("Failed marshalling the plugins signatures cache:", err.Error())
+		return
+	}
+	// Write to a temporary file and rename it, so that concurrent CLI invocations never read a partially written cache.
+	tmpFile, err := os.CreateTemp(pluginsDir, signaturesCacheFileName+".*.tmp")
+	if err != nil {
+		log.Debug("Failed saving the plugins signatures cache:", err.Error())
+		return
+	}
+	_, err = tmpFile.Write(content)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpFile.Name(), filepath.Join(pluginsDir, signaturesCacheFileName))
+	}
+	if err != nil {
+		log.Debug("Failed saving the plugins signatures cache:", err.Error())
+		_ = os.Remove(tmpFile.Name())
+		return
+	}
+	sc.changed = false
+}
+
+func calcSha256(filePath string) (string, error) {
+	checksums, err := gofrogcrypto.GetFileChecksums(filePath, gofrogcrypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	return checksums[gofrogcrypto.SHA256], nil
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignaturesCache(t *testing.T) {
+	pluginsDir := t.TempDir()
+	execPath := filepath.Join(pluginsDir, "plugin-mock")
+	assert.NoError(t, os.WriteFile(execPath, []byte("executable"), 0755))
+	signature := &PluginSignature{PluginSignature: components.PluginSignature{Name: "plugin-mock", Usage: "Mock plugin."}, Scopes: []string{"applied-permissions/user"}}
+
+	// Cache the signature and load it back.
+	cache := loadSignaturesCache(pluginsDir)
+	assert.Nil(t, cache.get(execPath, statFile(t, execPath)))
+	cache.put(execPath, statFile(t, execPath), signature)
+	cache.saveIfChanged(pluginsDir)
+	cache = loadSignaturesCache(pluginsDir)
+	cached := cache.get(execPath, statFile(t, execPath))
+	if assert.NotNil(t, cached) {
+		assert.Equal(t, signature.Name, cached.Name)
+		assert.Equal(t, execPath, cached.ExecutablePath)
+	}
+
+	// Changing only the modification time doesn't invalidate the cached signature.
+	newModTime := time.Now().Add(time.Hour)
+	assert.NoError(t, os.Chtimes(execPath, newModTime, newModTime))
+	assert.NotNil(t, cache.get(execPath, statFile(t, execPath)))
+
+	// Changing the executable invalidates the cached signature.
+	assert.NoError(t, os.WriteFile(execPath, []byte("new executable"), 0755))
+	assert.Nil(t, cache.get(execPath, statFile(t, execPath)))
+}
+
+func TestSignaturesCacheStaleEntries(t *testing.T) {
+	pluginsDir := t.TempDir()
+	execPath := filepath.Join(pluginsDir, "plugin-mock")
+	assert.NoError(t, os.WriteFile(execPath, []byte("executable"), 0755))
+
+	cache := loadSignaturesCache(pluginsDir)
+	cache.put(execPath, statFile(t, execPath), &PluginSignature{PluginSignature: components.PluginSignature{Name: "plugin-mock"}})
+	cache.saveIfChanged(pluginsDir)
+
+	// Entries of executables which weren't looked up are dropped.
+	cache = loadSignaturesCache(pluginsDir)
+	cache.saveIfChanged(pluginsDir)
+	assert.Empty(t, loadSignaturesCache(pluginsDir).Entries)
+}
+
+func TestSignaturesCacheCorrupted(t *testing.T) {
+	pluginsDir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(pluginsDir, signaturesCacheFileName), []byte("{corrupted"), 0644))
+
+	// A corrupted cache is replaced by an empty cache, which is saved on the next run.
+	cache := loadSignaturesCache(pluginsDir)
+	assert.Empty(t, cache.Entries)
+	cache.saveIfChanged(pluginsDir)
+	content, err := os.ReadFile(filepath.Join(pluginsDir, signaturesCacheFileName))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"version":2,"entries":{}}`, string(content))
+}
+
+func statFile(t *testing.T, filePath string) os.FileInfo {
+	fileInfo, err := os.Stat(filePath)
+	assert.NoError(t, err)
+	return fileInfo
+}
@@ -21,9 +21,17 @@ import (
 const pluginsErrorPrefix = "jfrog cli plugins: "
 const pluginsCategory = "Plugins"
 
+// The signature returned by the plugin's signature command.
+type PluginSignature struct {
+	components.PluginSignature
+	// Access token scopes required by the plugin, such as "applied-permissions/groups:readers".
+	// Used to create a scoped short-lived access token, when running the plugin in the sandbox.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
 // Gets all the installed plugins' signatures by looping over the plugins' dir.
-func getPluginsSignatures() ([]*components.PluginSignature, error) {
-	var signatures []*components.PluginSignature
+func GetPluginsSignatures() ([]*PluginSignature, error) {
+	var signatures []*PluginSignature
 	pluginsDir, err := coreutils.GetJfrogPluginsDir()
 	if err != nil {
 		return signatures, errorutils.CheckError(err)
@@ -32,10 +40,11 @@ func getPluginsSignatures() ([]*components.PluginSignature, error) {
 	if err != nil {
 		return signatures, errorutils.CheckError(err)
 	}
+	cache := loadSignaturesCache(pluginsDir)
 	var finalErr error
 	for _, p := range plugins {
-		// Skip 'plugins.yml'
-		if p.Name() == coreutils.JfrogPluginsFileName {
+		// Skip 'plugins.yml' and the signatures cache files
+		if p.Name() == coreutils.JfrogPluginsFileName || strings.HasPrefix(p.Name(), signaturesCacheFileName) {
 			continue
 		}
 		if !p.IsDir() {
@@ -44,29 +53,49 @@ func getPluginsSignatures() ([]*components.PluginSignature, error) {
 		}
 		pluginName := strings.TrimSuffix(p.Name(), filepath.Ext(p.Name()))
 		execPath := filepath.Join(pluginsDir, pluginName, coreutils.PluginsExecDirName, p.Name())
-		output, err := gofrogcmd.RunCmdOutput(
-			&PluginExecCmd{
-				execPath,
-				[]string{coreplugins.SignatureCommandName},
-			})
+		curSignature, err := getPluginSignature(execPath, pluginName, cache)
 		if err != nil {
 			finalErr = err
-			logSkippablePluginsError("failed getting signature from plugin", pluginName, err)
 			continue
 		}
-		curSignature := new(components.PluginSignature)
-		err = json.Unmarshal([]byte(output), &curSignature)
-		if err != nil {
-			finalErr = err
-			logSkippablePluginsError("failed unmarshalling signature from plugin", pluginName, err)
-			continue
-		}
-		curSignature.ExecutablePath = execPath
 		signatures = append(signatures, curSignature)
 	}
+	cache.saveIfChanged(pluginsDir)
 	return signatures, finalErr
 }
 
+// Returns the plugin's signature from the cache if the executable hasn't changed since it was cached.
+// Otherwise, the signature is taken by running the plugin's executable, and stored in the cache.
+func getPluginSignature(execPath, pluginName string, cache *signaturesCache) (*PluginSignature, error) {
+	fileInfo, err := os.Stat(execPath)
+	if err != nil {
+		err = errorutils.CheckError(err)
+		logSkippablePluginsError("failed getting signature from plugin", pluginName, err)
+		return nil, err
+	}
+	if signature := cache.get(execPath, fileInfo); signature != nil {
+		return signature, nil
+	}
+	output, err := gofrogcmd.RunCmdOutput(
+		&PluginExecCmd{
+			execPath,
+			[]string{coreplugins.SignatureCommandName},
+		})
+	if err != nil {
+		logSkippablePluginsError("failed getting signature from plugin", pluginName, err)
+		return nil, err
+	}
+	curSignature := new(PluginSignature)
+	err = json.Unmarshal([]byte(output), &curSignature)
+	if err != nil {
+		logSkippablePluginsError("failed unmarshalling signature from plugin", pluginName, err)
+		return nil, err
+	}
+	curSignature.ExecutablePath = execPath
+	cache.put(execPath, fileInfo, curSignature)
+	return curSignature, nil
+}
+
 func logSkippablePluginsError(msg, pluginName string, err error) {
 	log.Error(fmt.Sprintf("%s%s: '%s'. Skiping...", pluginsErrorPrefix, msg, pluginName))
 	if err != nil {
@@ -75,7 +104,7 @@ func logSkippablePluginsError(msg, pluginName string, err error) {
 }
 
 // Converts signatures to commands to be appended to the CLI commands.
-func signaturesToCommands(signatures []*components.PluginSignature) []cli.Command {
+func signaturesToCommands(signatures []*PluginSignature) []cli.Command {
 	var commands []cli.Command
 	for _, sig := range signatures {
 		commands = append(commands, cli.Command{
@@ -89,9 +118,15 @@ func signaturesToCommands(signatures []*components.PluginSignature) []cli.Comman
 	return commands
 }
 
-func getAction(sig components.PluginSignature) func(*cli.Context) error {
+func getAction(sig PluginSignature) func(*cli.Context) error {
 	return func(c *cli.Context) error {
+		env, cleanup, err := getPluginEnv(&sig, createScopedAccessToken)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
 		cmd := exec.Command(sig.ExecutablePath, cliutils.ExtractCommand(c)...)
+		cmd.Env = env
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
 		cmd.Stdin = os.Stdin
@@ -105,7 +140,7 @@ func GetPlugins() []cli.Command {
 		log.Error("failed adding certain plugins as commands. Last error: " + err.Error())
 		return []cli.Command{}
 	}
-	signatures, err := getPluginsSignatures()
+	signatures, err := GetPluginsSignatures()
 	if err != nil {
 		// Intentionally ignoring error to avoid failing if running other commands.
 		log.Error("failed adding certain plugins as commands. Last error: " + err.Error())
@@ -0,0 +1,92 @@
+package accesstoken
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The claims of a JFrog access token.
+type Payload struct {
+	Subject  string   `json:"sub,omitempty"`
+	Scope    string   `json:"scp,omitempty"`
+	Audience Audience `json:"aud,omitempty"`
+	Issuer   string   `json:"iss,omitempty"`
+	// The token's ID, used to revoke it.
+	TokenId        string `json:"jti,omitempty"`
+	IssuedAt       int64  `json:"iat,omitempty"`
+	ExpirationTime int64  `json:"exp,omitempty"`
+}
+
+// The audience claim may be either a single string or an array of strings.
+type Audience []string
+
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = strings.Fields(single)
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return errorutils.CheckErrorf("failed parsing the access token's audience: %s", err.Error())
+	}
+	*a = multiple
+	return nil
+}
+
+// Returns the name of the user the token was issued for, if it was issued for a user.
+// The subject of user tokens has the form '<issuer>/users/<username>'.
+func (p *Payload) GetUsername() string {
+	_, username, found := strings.Cut(p.Subject, "/users/")
+	if !found {
+		return ""
+	}
+	return username
+}
+
+// Returns the token's lifetime, or zero if the token never expires.
+func (p *Payload) GetLifetime() time.Duration {
+	if p.ExpirationTime == 0 || p.IssuedAt == 0 {
+		return 0
+	}
+	return time.Duration(p.ExpirationTime-p.IssuedAt) * time.Second
+}
+
+// Returns the token's expiry time, or a zero time if the token never expires.
+func (p *Payload) GetExpiry() time.Time {
+	if p.ExpirationTime == 0 {
+		return time.Time{}
+	}
+	return time.Unix(p.ExpirationTime, 0)
+}
+
+// Returns the expiry time of a JWT access token, by decoding it offline.
+// A zero time is returned for tokens which never expire.
+func GetExpiry(token string) (time.Time, error) {
+	payload, err := DecodePayload(token)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return payload.GetExpiry(), nil
+}
+
+// Decodes the payload of a JWT access token offline, without verifying the token's signature.
+func DecodePayload(token string) (*Payload, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errorutils.CheckErrorf("the access token is not a JWT. Reference tokens can't be decoded offline")
+	}
+	content, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed decoding the access token's payload: %s", err.Error())
+	}
+	payload := new(Payload)
+	if err = json.Unmarshal(content, payload); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the access token's payload: %s", err.Error())
+	}
+	return payload, nil
+}
@@ -0,0 +1,44 @@
+package accesstoken
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExpiry(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user","exp":1700000000}`))
+	expiry, err := GetExpiry("header." + payload + ".signature")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(1700000000, 0), expiry)
+
+	// Tokens without an expiry never expire.
+	payload = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user"}`))
+	expiry, err = GetExpiry("header." + payload + ".signature")
+	assert.NoError(t, err)
+	assert.True(t, expiry.IsZero())
+
+	// Reference tokens can't be decoded offline.
+	_, err = GetExpiry("reference-token")
+	assert.Error(t, err)
+}
+
+func TestDecodePayload(t *testing.T) {
+	content := `{"sub":"jfac@01abc/users/admin","scp":"applied-permissions/user","aud":["jfrt@*","jfxr@*"],"jti":"token-id","iat":1700000000,"exp":1700003600}`
+	payload, err := DecodePayload("header." + base64.RawURLEncoding.EncodeToString([]byte(content)) + ".signature")
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", payload.GetUsername())
+	assert.Equal(t, "token-id", payload.TokenId)
+	assert.Equal(t, Audience{"jfrt@*", "jfxr@*"}, payload.Audience)
+	assert.Equal(t, time.Hour, payload.GetLifetime())
+
+	// The audience may be a single string, and the subject may not be a user.
+	content = `{"sub":"jfrt@01abc","aud":"*@*"}`
+	payload, err = DecodePayload("header." + base64.RawURLEncoding.EncodeToString([]byte(content)) + ".signature")
+	assert.NoError(t, err)
+	assert.Empty(t, payload.GetUsername())
+	assert.Equal(t, Audience{"*@*"}, payload.Audience)
+	assert.Zero(t, payload.GetLifetime())
+}
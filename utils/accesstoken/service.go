@@ -0,0 +1,92 @@
+package accesstoken
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/access"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const tokensApi = "api/v1/tokens"
+
+// Access token management APIs, which aren't covered by the Access services manager.
+type TokensService struct {
+	servicesManager *access.AccessServicesManager
+	serviceDetails  auth.ServiceDetails
+}
+
+func NewTokensService(serverDetails *coreConfig.ServerDetails) (*TokensService, error) {
+	if serverDetails.Url == "" {
+		return nil, errorutils.CheckErrorf("the JFrog Platform URL of server '%s' is mandatory for managing access tokens", serverDetails.ServerId)
+	}
+	servicesManager, err := rtUtils.CreateAccessServiceManager(serverDetails, false)
+	if err != nil {
+		return nil, err
+	}
+	serviceDetails, err := serverDetails.CreateAccessAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &TokensService{servicesManager: servicesManager, serviceDetails: serviceDetails}, nil
+}
+
+func (ts *TokensService) GetServicesManager() *access.AccessServicesManager {
+	return ts.servicesManager
+}
+
+// Revokes the token with the provided ID (the token's 'jti' claim).
+func (ts *TokensService) RevokeToken(tokenId string) error {
+	httpDetails := ts.serviceDetails.CreateHttpClientDetails()
+	resp, body, err := ts.servicesManager.Client().SendDelete(ts.serviceDetails.GetUrl()+tokensApi+"/"+url.PathEscape(tokenId), nil, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNoContent)
+}
+
+// Token details, as returned by the Access tokens API. Tokens' values are never returned.
+type TokenInfo struct {
+	TokenId     string `json:"token_id" yaml:"token_id"`
+	Subject     string `json:"subject" yaml:"subject"`
+	Scope       string `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Issuer      string `json:"issuer,omitempty" yaml:"issuer,omitempty"`
+	// Unix times in seconds. Expiry is zero for tokens which never expire.
+	IssuedAt    int64 `json:"issued_at,omitempty" yaml:"issued_at,omitempty"`
+	Expiry      int64 `json:"expiry,omitempty" yaml:"expiry,omitempty"`
+	Refreshable bool  `json:"refreshable" yaml:"refreshable"`
+}
+
+// Returns the tokens visible to the authenticated user. Administrators get the tokens of all the users.
+func (ts *TokensService) ListTokens() ([]TokenInfo, error) {
+	httpDetails := ts.serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := ts.servicesManager.Client().SendGet(ts.serviceDetails.GetUrl()+tokensApi, true, &httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	tokens := struct {
+		Tokens []TokenInfo `json:"tokens"`
+	}{}
+	if err = json.Unmarshal(body, &tokens); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return tokens.Tokens, nil
+}
+
+// Revokes a token by its value. Used for reference tokens, whose ID can't be decoded offline.
+func (ts *TokensService) RevokeTokenByValue(token string) error {
+	httpDetails := ts.serviceDetails.CreateHttpClientDetails()
+	resp, body, err := ts.servicesManager.Client().SendPostForm(ts.serviceDetails.GetUrl()+tokensApi+"/revoke", url.Values{"token": {token}}, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNoContent)
+}
@@ -18,6 +18,8 @@ const (
 	CmdOptions        = "options"
 	CmdProject        = "project"
 	CmdPipelines      = "pl"
+	CmdDaemon         = "daemon"
+	CmdShell          = "shell"
 
 	// Download
 	DownloadMinSplitKb    = 5120
@@ -36,10 +38,13 @@ const (
 	ArtifactoryTokenExpiry        = 3600
 	DefaultLicenseCount           = 1
 	LatestCliVersionCheckInterval = time.Hour * 6
+	TokenExpiryCheckInterval      = time.Hour * 24
 
 	// Env
 	BuildUrl                       = "JFROG_CLI_BUILD_URL"
 	EnvExclude                     = "JFROG_CLI_ENV_EXCLUDE"
 	UserAgent                      = "JFROG_CLI_USER_AGENT"
 	JfrogCliAvoidNewVersionWarning = "JFROG_CLI_AVOID_NEW_VERSION_WARNING"
+	TokenExpiryWarningDays         = "JFROG_CLI_TOKEN_EXPIRY_WARNING_DAYS"
+	OutputFormatEnv                = "JFROG_CLI_OUTPUT_FORMAT"
 )
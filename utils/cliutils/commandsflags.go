@@ -105,8 +105,17 @@ const (
 	JpdDelete      = "jpd-delete"
 
 	// Config commands keys
-	AddConfig  = "config-add"
-	EditConfig = "config-edit"
+	AddConfig         = "config-add"
+	EditConfig        = "config-edit"
+	TestConfig        = "config-test"
+	RotateTokenConfig = "config-rotate-token"
+	ShowConfig        = "config-show"
+
+	// Plugin commands keys
+	PluginUpdate  = "plugin-update"
+	PluginPublish = "plugin-publish"
+	PluginInstall = "plugin-install"
+	PluginExport  = "plugin-export"
 
 	// Project commands keys
 	InitProject = "project-init"
@@ -126,8 +135,26 @@ const (
 	ReleaseBundleExport       = "release-bundle-export"
 	ReleaseBundleImport       = "release-bundle-import"
 
-	// Access Token Create commands keys
-	AccessTokenCreate = "access-token-create"
+	// Access Token commands keys
+	AccessTokenCreate  = "access-token-create"
+	AccessTokenList    = "access-token-list"
+	AccessTokenInspect = "access-token-inspect"
+	AccessTokenRevoke  = "access-token-revoke"
+
+	// Login command key
+	Login = "login"
+
+	// Logout command key
+	Logout = "logout"
+
+	// Run batch command key
+	RunBatch = "run-batch"
+
+	// Self update command key
+	SelfUpdate = "self-update"
+
+	// Daemon commands keys
+	DaemonStatus = "daemon-status"
 
 	// *** Artifactory Commands' flags ***
 	// Base flags
@@ -424,6 +451,48 @@ const (
 	atcRefreshable          = accessTokenCreatePrefix + Refreshable
 	atcAudience             = accessTokenCreatePrefix + Audience
 
+	// Unique access-token-list flags
+	accessTokenListPrefix = "atl-"
+	Subject               = "subject"
+	atlSubject            = accessTokenListPrefix + Subject
+	atlDescription        = accessTokenListPrefix + Description
+	ExpiresWithin         = "expires-within"
+	atlExpiresWithin      = accessTokenListPrefix + ExpiresWithin
+	atlFormat             = accessTokenListPrefix + Format
+
+	// Unique access-token-inspect flags
+	atiFormat = "ati-" + Format
+
+	// Unique login flags
+	loginPrefix       = "login-"
+	OidcProvider      = "oidc-provider"
+	loginOidcProvider = loginPrefix + OidcProvider
+	OidcTokenEnv      = "oidc-token-env"
+	loginOidcTokenEnv = loginPrefix + OidcTokenEnv
+	OidcAudience      = "oidc-audience"
+	loginOidcAudience = loginPrefix + OidcAudience
+	loginUrl          = loginPrefix + url
+	loginServerId     = loginPrefix + serverId
+
+	// Unique logout flags
+	logoutServerId = "logout-" + serverId
+
+	// Unique run-batch flags
+	runBatchPrefix   = "rb-"
+	runBatchServerId = runBatchPrefix + serverId
+	runBatchVars     = runBatchPrefix + vars
+	runBatchFormat   = runBatchPrefix + Format
+
+	// Unique self-update flags
+	selfUpdatePrefix  = "su-"
+	selfUpdateVersion = selfUpdatePrefix + Version
+	selfUpdateChannel = selfUpdatePrefix + "channel"
+	selfUpdateCheck   = selfUpdatePrefix + "check"
+	selfUpdateFormat  = selfUpdatePrefix + Format
+
+	// Unique daemon status flags
+	daemonStatusFormat = "daemon-status-" + Format
+
 	// Unique Xray Flags for upload/publish commands
 	xrayScan = "scan"
 
@@ -523,10 +592,39 @@ const (
 	configUser        = configPrefix + user
 	configPassword    = configPrefix + password
 	configInsecureTls = configPrefix + InsecureTls
+	CredentialHelper  = "credential-helper"
+	configCredHelper  = configPrefix + CredentialHelper
+	Revoke            = "revoke"
+	configRevoke      = configPrefix + Revoke
+	configTestAll     = configPrefix + "test-" + All
+	Format            = "format"
+	configTestFormat  = configPrefix + "test-" + Format
+	Effective         = "effective"
+	configEffective   = configPrefix + Effective
 
 	// *** Project Commands' flags ***
 	projectPath = "path"
 
+	// *** Plugin Commands' flags ***
+	pluginPrefix     = "plugin-"
+	All              = "all"
+	pluginUpdateAll  = pluginPrefix + "update-" + All
+	Arch             = "arch"
+	pluginArch       = pluginPrefix + Arch
+	Ldflags          = "ldflags"
+	pluginLdflags    = pluginPrefix + Ldflags
+	Tags             = "tags"
+	pluginTags       = pluginPrefix + Tags
+	Resume           = "resume"
+	pluginResume     = pluginPrefix + Resume
+	Rollback         = "rollback"
+	pluginRollback   = pluginPrefix + Rollback
+	Manifest         = "manifest"
+	pluginManifest   = pluginPrefix + Manifest
+	pluginExportArch = pluginPrefix + "export-" + Arch
+	FromFile         = "from-file"
+	pluginFromFile   = pluginPrefix + FromFile
+
 	// *** Completion Commands' flags ***
 	Completion = "completion"
 	Install    = "install"
@@ -1503,6 +1601,14 @@ var flagsMap = map[string]cli.Flag{
 	},
 
 	// Config commands Flags
+	configTestAll: cli.BoolFlag{
+		Name:  All,
+		Usage: "[Default: false] Set to true to test all the configured servers.` `",
+	},
+	configTestFormat: cli.StringFlag{
+		Name:  Format,
+		Usage: "[Default: table] Defines the output format of the command. Acceptable values are: json, yaml, table, csv.` `",
+	},
 	configPlatformUrl: cli.StringFlag{
 		Name:  url,
 		Usage: "[Optional] JFrog platform URL. (example: https://acme.jfrog.io)` `",
@@ -1543,10 +1649,59 @@ var flagsMap = map[string]cli.Flag{
 		Name:  InsecureTls,
 		Usage: "[Default: false] Set to true to skip TLS certificates verification, while encrypting the Artifactory password during the config process.` `",
 	},
+	configRevoke: cli.BoolFlag{
+		Name:  Revoke,
+		Usage: "[Default: false] Set to true to revoke the old access token, after the new token is saved.` `",
+	},
+	configEffective: cli.BoolFlag{
+		Name:  Effective,
+		Usage: "[Default: false] Set to true to show the effective settings, including the per-project overlay read from '.jfrog/cli.yaml', and where each value came from.` `",
+	},
+	configCredHelper: cli.StringFlag{
+		Name:   CredentialHelper,
+		Hidden: true,
+		Usage:  "[Optional] Name of a credential helper to store the server's secrets in, instead of the config file. The helper is an executable named 'jf-credential-<name>' in the PATH, which supports the get, store and erase actions.` `",
+	},
 	projectPath: cli.StringFlag{
 		Name:  projectPath,
 		Usage: "[Default: ./] Full path to the code project.` `",
 	},
+	pluginUpdateAll: cli.BoolFlag{
+		Name:  All,
+		Usage: "[Default: false] Set to true to update all the installed plugins which are not pinned to a specific version.` `",
+	},
+	pluginArch: cli.StringFlag{
+		Name:  Arch,
+		Usage: "[Default: all supported architectures] Semicolon-separated list of architectures to publish the plugin for, such as 'linux-amd64;mac-arm64'.` `",
+	},
+	pluginLdflags: cli.StringFlag{
+		Name:  Ldflags,
+		Usage: "[Optional] Arguments to pass to the 'go build' command's -ldflags option. Can't be used with a plugin manifest.` `",
+	},
+	pluginTags: cli.StringFlag{
+		Name:  Tags,
+		Usage: "[Optional] Comma-separated list of build tags to pass to the 'go build' command's -tags option. Can't be used with a plugin manifest.` `",
+	},
+	pluginResume: cli.BoolFlag{
+		Name:  Resume,
+		Usage: "[Default: false] Set to true to resume a failed publish of the same version, by building and uploading only the architectures which were not staged yet, and completing the move to the version's directory and the copy to latest.` `",
+	},
+	pluginRollback: cli.BoolFlag{
+		Name:  Rollback,
+		Usage: "[Default: false] Set to true to roll back a failed publish of the same version, by deleting its staged architectures, and the architectures it already moved to the version's directory.` `",
+	},
+	pluginExportArch: cli.StringFlag{
+		Name:  Arch,
+		Usage: "[Default: the local architecture] Semicolon-separated list of architectures to export the plugin for, such as 'linux-amd64;windows-amd64'.` `",
+	},
+	pluginFromFile: cli.StringFlag{
+		Name:  FromFile,
+		Usage: "[Optional] Path to a plugin bundle created by the 'jf plugin export' command. If provided, the plugin is installed from the bundle, without accessing the plugins server.` `",
+	},
+	pluginManifest: cli.StringFlag{
+		Name:  Manifest,
+		Usage: "[Default: ./jfrog-plugin.yaml if exists] Path to a plugin manifest, pointing at prebuilt executables or build command templates per architecture. Used to publish plugins which are not written in Go.` `",
+	},
 	Install: cli.BoolFlag{
 		Name:  Install,
 		Usage: "[Default: false] Set to true to install the completion script instead of printing it to the standard output.` `",
@@ -1715,20 +1870,106 @@ var flagsMap = map[string]cli.Flag{
 		Name:  Reference,
 		Usage: "[Default: false] Generate a Reference Token (alias to Access Token) in addition to the full token (available from Artifactory 7.38.10)` `",
 	},
+	atlSubject: cli.StringFlag{
+		Name:  Subject,
+		Usage: "[Optional] Show only the tokens of the provided username.` `",
+	},
+	atlDescription: cli.StringFlag{
+		Name:  Description,
+		Usage: "[Optional] Show only the tokens whose description contains the provided text. The comparison is case-insensitive.` `",
+	},
+	atlExpiresWithin: cli.StringFlag{
+		Name:  ExpiresWithin,
+		Usage: "[Optional] Show only the tokens which expire within the provided number of days, including expired tokens.` `",
+	},
+	atlFormat: cli.StringFlag{
+		Name:  Format,
+		Usage: "[Default: table] Defines the output format of the command. Acceptable values are: json, yaml, table, csv.` `",
+	},
+	atiFormat: cli.StringFlag{
+		Name:  Format,
+		Usage: "[Default: table] Defines the output format of the command. Acceptable values are: json, yaml, table, csv.` `",
+	},
+	loginOidcProvider: cli.StringFlag{
+		Name: OidcProvider,
+		Usage: "[Optional] Name of the OIDC integration configured in the JFrog Platform. If provided, the CI's workload identity token is exchanged for a short-lived access token, " +
+			"without opening a web browser.` `",
+	},
+	loginOidcTokenEnv: cli.StringFlag{
+		Name:  OidcTokenEnv,
+		Usage: "[Optional] Name of the environment variable which holds the OIDC ID token. If not provided, the ID token is detected automatically on GitHub Actions, GitLab, CircleCI and Bitbucket Pipelines.` `",
+	},
+	loginOidcAudience: cli.StringFlag{
+		Name:  OidcAudience,
+		Usage: "[Optional] The audience of the ID token requested from GitHub Actions. If not provided, GitHub's default audience is used.` `",
+	},
+	loginUrl: cli.StringFlag{
+		Name:  url,
+		Usage: "[Mandatory with --oidc-provider] JFrog Platform URL. (example: https://acme.jfrog.io)` `",
+	},
+	loginServerId: cli.StringFlag{
+		Name:  serverId,
+		Usage: "[Optional] ID of the ephemeral server configuration created with --oidc-provider. If not provided, 'oidc-<provider>' is used.` `",
+	},
+	logoutServerId: cli.StringFlag{
+		Name:  serverId,
+		Usage: "[Optional] ID of the ephemeral server configuration to remove. If not provided, all the ephemeral server configurations are removed.` `",
+	},
+	runBatchServerId: cli.StringFlag{
+		Name:  serverId,
+		Usage: "[Optional] Server ID configured using the 'jf config' command, used by the steps which don't set the --server-id option. Overrides the script's server-id.` `",
+	},
+	runBatchVars: cli.StringFlag{
+		Name:  vars,
+		Usage: "[Optional] List of semicolon-separated(;) variables in the form of \"key1=value1;key2=value2;...\" (wrapped by quotes) to be replaced in the steps. Overrides the script's vars. In the steps, the variables should be used as follows: ${key1}.` `",
+	},
+	runBatchFormat: cli.StringFlag{
+		Name:  Format,
+		Usage: "[Default: table] Defines the output format of the batch summary. Acceptable values are: json, yaml, table, csv.` `",
+	},
+	selfUpdateVersion: cli.StringFlag{
+		Name:  Version,
+		Usage: "[Optional] The JFrog CLI version to update to. The version may also be older than the current version. If not provided, the latest version in the channel is used.` `",
+	},
+	selfUpdateChannel: cli.StringFlag{
+		Name:  "channel",
+		Usage: "[Default: stable] The release channel to update from. Acceptable values are: stable, rc. The rc channel includes the release candidates, as well as the stable releases.` `",
+	},
+	selfUpdateCheck: cli.BoolFlag{
+		Name:  "check",
+		Usage: "[Default: false] Set to true to only report whether an update is available, without updating.` `",
+	},
+	selfUpdateFormat: cli.StringFlag{
+		Name:  Format,
+		Usage: "[Default: table] Defines the output format of the --check option. Acceptable values are: json, yaml, table, csv.` `",
+	},
+	daemonStatusFormat: cli.StringFlag{
+		Name:  Format,
+		Usage: "[Default: table] Defines the output format of the command. Acceptable values are: json, yaml, table, csv.` `",
+	},
 }
 
 var commandFlags = map[string][]string{
 	AddConfig: {
 		interactive, EncPassword, configPlatformUrl, configRtUrl, configDistUrl, configXrUrl, configMcUrl, configPlUrl, configUser, configPassword, configAccessToken, sshKeyPath, sshPassphrase, ClientCertPath,
-		ClientCertKeyPath, BasicAuthOnly, configInsecureTls, Overwrite, passwordStdin, accessTokenStdin,
+		ClientCertKeyPath, BasicAuthOnly, configInsecureTls, Overwrite, passwordStdin, accessTokenStdin, configCredHelper,
 	},
 	EditConfig: {
 		interactive, EncPassword, configPlatformUrl, configRtUrl, configDistUrl, configXrUrl, configMcUrl, configPlUrl, configUser, configPassword, configAccessToken, sshKeyPath, sshPassphrase, ClientCertPath,
-		ClientCertKeyPath, BasicAuthOnly, configInsecureTls, passwordStdin, accessTokenStdin,
+		ClientCertKeyPath, BasicAuthOnly, configInsecureTls, passwordStdin, accessTokenStdin, configCredHelper,
 	},
 	DeleteConfig: {
 		deleteQuiet,
 	},
+	TestConfig: {
+		configTestAll, configTestFormat,
+	},
+	RotateTokenConfig: {
+		configRevoke,
+	},
+	ShowConfig: {
+		configEffective,
+	},
 	Upload: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath, uploadTargetProps,
 		ClientCertKeyPath, specFlag, specVars, buildName, buildNumber, module, uploadExclusions, deb,
@@ -1973,6 +2214,31 @@ var commandFlags = map[string][]string{
 		atcProject, atcGrantAdmin, atcGroups, atcScope, atcExpiry,
 		atcRefreshable, atcDescription, atcAudience, atcReference,
 	},
+	AccessTokenList: {
+		platformUrl, user, password, accessToken, serverId, ClientCertPath, ClientCertKeyPath,
+		atlSubject, atlDescription, atlExpiresWithin, atlFormat,
+	},
+	AccessTokenInspect: {
+		atiFormat,
+	},
+	AccessTokenRevoke: {
+		platformUrl, user, password, accessToken, serverId, ClientCertPath, ClientCertKeyPath,
+	},
+	Login: {
+		loginOidcProvider, loginOidcTokenEnv, loginOidcAudience, loginUrl, loginServerId,
+	},
+	Logout: {
+		logoutServerId,
+	},
+	RunBatch: {
+		runBatchServerId, runBatchVars, runBatchFormat,
+	},
+	SelfUpdate: {
+		selfUpdateVersion, selfUpdateChannel, selfUpdateCheck, selfUpdateFormat,
+	},
+	DaemonStatus: {
+		daemonStatusFormat,
+	},
 	UserCreate: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId,
 		UsersGroups, Replace, Admin,
@@ -2049,6 +2315,19 @@ var commandFlags = map[string][]string{
 	InitProject: {
 		projectPath, serverId,
 	},
+	// Plugin commands
+	PluginInstall: {
+		pluginFromFile,
+	},
+	PluginExport: {
+		pluginExportArch,
+	},
+	PluginUpdate: {
+		pluginUpdateAll,
+	},
+	PluginPublish: {
+		pluginArch, pluginLdflags, pluginTags, threads, pluginResume, pluginRollback, pluginManifest,
+	},
 	// Completion commands
 	Completion: {
 		Install,
@@ -0,0 +1,329 @@
+package cliutils
+
+import (
+	encodingCsv "encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	artifactoryUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+)
+
+type OutputFormat string
+
+const (
+	Json  OutputFormat = "json"
+	Yaml  OutputFormat = "yaml"
+	Table OutputFormat = "table"
+	Csv   OutputFormat = "csv"
+)
+
+var OutputFormats = []OutputFormat{Json, Yaml, Table, Csv}
+
+// The commands supporting structured output, as listed in the usage of the global '--format' option.
+// Commands which start printing their output through PrintCommandOutput should be added here.
+var StructuredOutputCommands = []string{
+	"rt search",
+	"the summaries of the upload, download, move, copy and delete commands",
+	"config show --effective",
+	"config test",
+	"plugin list",
+	"access-token-create",
+	"access-token-list",
+	"access-token-inspect",
+	"run-batch",
+	"daemon status",
+	"self-update --check",
+}
+
+// The global '--format' option, which sets the output format of all commands supporting structured output.
+// Usage example: 'jf --format=yaml config test'
+func GetGlobalFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  Format,
+			Usage: fmt.Sprintf("[Optional] Output format of the commands which support structured output: %s. Acceptable values are: %s. Can also be set by the %s environment variable.` `", strings.Join(StructuredOutputCommands, ", "), joinOutputFormats(OutputFormats), OutputFormatEnv),
+		},
+	}
+}
+
+// Returns the output format requested by the command's '--format' option, the global '--format' option or the JFROG_CLI_OUTPUT_FORMAT environment variable, in this order.
+// The default format is returned if none of them is set.
+// supportedFormats - The formats supported by the command. All formats are supported if empty.
+func GetOutputFormat(c *cli.Context, defaultFormat OutputFormat, supportedFormats ...OutputFormat) (OutputFormat, error) {
+	format := c.String(Format)
+	if format == "" {
+		format = c.GlobalString(Format)
+	}
+	if format == "" {
+		format = os.Getenv(OutputFormatEnv)
+	}
+	if format == "" {
+		return defaultFormat, nil
+	}
+	return parseOutputFormat(format, supportedFormats)
+}
+
+// Sets the JFROG_CLI_OUTPUT_FORMAT environment variable to the value of the global '--format' option, if set.
+// This allows output which is printed without the command's context, such as the summaries of the commands, to be printed in the requested format.
+func ExportGlobalOutputFormat(c *cli.Context) error {
+	if format := c.GlobalString(Format); format != "" {
+		return errorutils.CheckError(os.Setenv(OutputFormatEnv, format))
+	}
+	return nil
+}
+
+// Returns the output format requested by the global '--format' option or the JFROG_CLI_OUTPUT_FORMAT environment variable.
+// An empty format is returned if none is requested.
+func GetRequestedOutputFormat() (OutputFormat, error) {
+	format := os.Getenv(OutputFormatEnv)
+	if format == "" {
+		return "", nil
+	}
+	return parseOutputFormat(format, OutputFormats)
+}
+
+func parseOutputFormat(format string, supportedFormats []OutputFormat) (OutputFormat, error) {
+	if len(supportedFormats) == 0 {
+		supportedFormats = OutputFormats
+	}
+	for _, supportedFormat := range supportedFormats {
+		if strings.EqualFold(format, string(supportedFormat)) {
+			return supportedFormat, nil
+		}
+	}
+	return "", errorutils.CheckErrorf("unsupported format '%s'. Acceptable values are: %s", format, joinOutputFormats(supportedFormats))
+}
+
+func joinOutputFormats(formats []OutputFormat) string {
+	var names []string
+	for _, format := range formats {
+		names = append(names, string(format))
+	}
+	return strings.Join(names, ", ")
+}
+
+// The structured result of a command.
+type CommandOutput struct {
+	// The result, as printed in the json and yaml formats. This is the command's stable schema, so fields should only be added to it.
+	Data interface{}
+	// A slice of structs with 'col-name' tags, printed in the table and csv formats. Data is used if not provided.
+	Rows interface{}
+	// The title and the message for empty results, printed in the table format only.
+	Title        string
+	EmptyMessage string
+}
+
+// Prints the command's output in the provided format.
+func PrintCommandOutput(output CommandOutput, format OutputFormat) error {
+	rows := output.Rows
+	if rows == nil {
+		rows = output.Data
+	}
+	switch format {
+	case Json:
+		content, err := json.Marshal(emptySliceIfNil(output.Data))
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientUtils.IndentJson(content))
+	case Yaml:
+		content, err := yaml.Marshal(emptySliceIfNil(output.Data))
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(strings.TrimSuffix(string(content), "\n"))
+	case Table:
+		return coreutils.PrintTable(rows, output.Title, output.EmptyMessage, false)
+	case Csv:
+		content, err := toCsv(rows)
+		if err != nil {
+			return err
+		}
+		log.Output(strings.TrimSuffix(content, "\n"))
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s'", format)
+	}
+	return nil
+}
+
+// Scripts parsing the output expect an empty list rather than null, when there are no results.
+func emptySliceIfNil(data interface{}) interface{} {
+	value := reflect.ValueOf(data)
+	if value.Kind() == reflect.Slice && value.IsNil() {
+		return reflect.MakeSlice(value.Type(), 0, 0).Interface()
+	}
+	return data
+}
+
+// Converts a slice of structs to CSV. The columns are the fields with the 'col-name' tag, as in the table format.
+func toCsv(rows interface{}) (string, error) {
+	rowsValue := reflect.ValueOf(rows)
+	if rowsValue.Kind() != reflect.Slice {
+		return "", errorutils.CheckErrorf("the csv format requires a slice, but got: %s", rowsValue.Kind())
+	}
+	rowType := rowsValue.Type().Elem()
+	if rowType.Kind() == reflect.Ptr {
+		rowType = rowType.Elem()
+	}
+	if rowType.Kind() != reflect.Struct {
+		return "", errorutils.CheckErrorf("the csv format requires a slice of structs, but got a slice of: %s", rowType.Kind())
+	}
+	var header []string
+	var columns []int
+	for i := 0; i < rowType.NumField(); i++ {
+		if columnName, ok := rowType.Field(i).Tag.Lookup("col-name"); ok {
+			header = append(header, strings.ReplaceAll(columnName, "\n", " "))
+			columns = append(columns, i)
+		}
+	}
+	var builder strings.Builder
+	writer := encodingCsv.NewWriter(&builder)
+	if err := writer.Write(header); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	for i := 0; i < rowsValue.Len(); i++ {
+		rowValue := reflect.Indirect(rowsValue.Index(i))
+		record := make([]string, len(columns))
+		for j, column := range columns {
+			record[j] = fmt.Sprint(rowValue.Field(column))
+		}
+		if err := writer.Write(record); err != nil {
+			return "", errorutils.CheckError(err)
+		}
+	}
+	writer.Flush()
+	return builder.String(), errorutils.CheckError(writer.Error())
+}
+
+type summaryOutput struct {
+	Status string          `yaml:"status"`
+	Totals *summary.Totals `yaml:"totals"`
+	Files  interface{}     `yaml:"files,omitempty"`
+}
+
+type summaryRow struct {
+	Status  string `col-name:"Status"`
+	Success int    `col-name:"Success"`
+	Failure int    `col-name:"Failure"`
+}
+
+type summaryFileRow struct {
+	Source string `yaml:"source,omitempty" col-name:"Source"`
+	Target string `yaml:"target" col-name:"Target"`
+	Sha256 string `yaml:"sha256,omitempty" col-name:"Sha256"`
+}
+
+type buildInfoFileRow struct {
+	Sha256 string `yaml:"sha256" col-name:"Sha256"`
+}
+
+// Prints the basic summary json, or its content in the requested output format.
+func printBasicSummary(basicSummary string) error {
+	format, err := GetRequestedOutputFormat()
+	if err != nil {
+		log.Output(basicSummary)
+		return err
+	}
+	if format == "" || format == Json {
+		log.Output(basicSummary)
+		return nil
+	}
+	return printSummaryInFormat(format, basicSummary, nil)
+}
+
+// Prints a summary json in the yaml, table or csv format.
+// files - A slice of the affected files, if a detailed summary was requested. In the csv format, only the files are printed.
+func printSummaryInFormat(format OutputFormat, summaryJson string, files interface{}) error {
+	basicSummary := new(summary.Summary)
+	if err := json.Unmarshal([]byte(summaryJson), basicSummary); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if basicSummary.Totals == nil {
+		basicSummary.Totals = &summary.Totals{}
+	}
+	totals := CommandOutput{
+		Data: summaryOutput{Status: summary.StatusTypes[basicSummary.Status], Totals: basicSummary.Totals, Files: files},
+		Rows: []summaryRow{{summary.StatusTypes[basicSummary.Status], basicSummary.Totals.Success, basicSummary.Totals.Failure}},
+	}
+	switch {
+	case files == nil || format == Yaml:
+		return PrintCommandOutput(totals, format)
+	case format == Csv:
+		return PrintCommandOutput(CommandOutput{Data: files}, format)
+	default:
+		if err := PrintCommandOutput(CommandOutput{Data: files, Title: "Files", EmptyMessage: "No files were affected"}, format); err != nil {
+			return err
+		}
+		return PrintCommandOutput(totals, format)
+	}
+}
+
+// Prints a detailed summary, including the files in the reader, in the yaml, table or csv format.
+func printDetailedSummaryInFormat(format OutputFormat, basicSummary string, reader *content.ContentReader, uploaded bool) error {
+	files := []summaryFileRow{}
+	for transferDetails := new(clientUtils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientUtils.FileTransferDetails) {
+		file := summaryFileRow{Source: transferDetails.SourcePath, Target: transferDetails.TargetPath}
+		if uploaded {
+			file.Target = transferDetails.RtUrl + file.Target
+			file.Sha256 = transferDetails.Sha256
+		} else {
+			file.Source = transferDetails.RtUrl + file.Source
+		}
+		files = append(files, file)
+	}
+	if err := reader.GetError(); err != nil {
+		return err
+	}
+	reader.Reset()
+	return printSummaryInFormat(format, basicSummary, files)
+}
+
+type searchResultRow struct {
+	Path     string `col-name:"Path"`
+	Type     string `col-name:"Type"`
+	Size     int64  `col-name:"Size"`
+	Modified string `col-name:"Modified"`
+	Sha256   string `col-name:"Sha256"`
+}
+
+// Prints the search results in the reader in the requested output format. The results are printed as a json array by default.
+func PrintSearchResults(reader *content.ContentReader) error {
+	format, err := GetRequestedOutputFormat()
+	if err != nil {
+		return err
+	}
+	if format == "" || format == Json {
+		return artifactoryUtils.PrintSearchResults(reader)
+	}
+	// The results are converted to yaml through their json representation, to keep the keys of the json format.
+	var results []yaml.MapSlice
+	var rows []searchResultRow
+	for searchResult := new(artifactoryUtils.SearchResult); reader.NextRecord(searchResult) == nil; searchResult = new(artifactoryUtils.SearchResult) {
+		rows = append(rows, searchResultRow{searchResult.Path, searchResult.Type, searchResult.Size, searchResult.Modified, searchResult.Sha256})
+		data, err := json.Marshal(searchResult)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		var result yaml.MapSlice
+		if err = yaml.Unmarshal(data, &result); err != nil {
+			return errorutils.CheckError(err)
+		}
+		results = append(results, result)
+	}
+	if err = reader.GetError(); err != nil {
+		return err
+	}
+	reader.Reset()
+	return PrintCommandOutput(CommandOutput{Data: results, Rows: rows, EmptyMessage: "No artifacts were found"}, format)
+}
@@ -0,0 +1,105 @@
+package cliutils
+
+import (
+	"testing"
+
+	coreTests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func TestGetOutputFormat(t *testing.T) {
+	testCases := []struct {
+		name           string
+		args           []string
+		env            string
+		supported      []OutputFormat
+		expectedFormat OutputFormat
+		expectedErr    string
+	}{
+		{"default", []string{"jf", "cmd"}, "", nil, Table, ""},
+		{"env", []string{"jf", "cmd"}, "yaml", nil, Yaml, ""},
+		{"global option", []string{"jf", "--format=csv", "cmd"}, "yaml", nil, Csv, ""},
+		{"command option", []string{"jf", "--format=csv", "cmd", "--format=JSON"}, "yaml", nil, Json, ""},
+		{"unsupported", []string{"jf", "cmd", "--format=csv"}, "", []OutputFormat{Json, Table}, "", "unsupported format 'csv'. Acceptable values are: json, table"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv(OutputFormatEnv, testCase.env)
+			var format OutputFormat
+			var err error
+			app := cli.NewApp()
+			app.Flags = GetGlobalFlags()
+			app.Commands = []cli.Command{{
+				Name:  "cmd",
+				Flags: []cli.Flag{cli.StringFlag{Name: Format}},
+				Action: func(c *cli.Context) error {
+					format, err = GetOutputFormat(c, Table, testCase.supported...)
+					return nil
+				},
+			}}
+			require.NoError(t, app.Run(testCase.args))
+			if testCase.expectedErr != "" {
+				assert.EqualError(t, err, testCase.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedFormat, format)
+		})
+	}
+}
+
+type testRow struct {
+	Name  string `col-name:"Name"`
+	Count int    `col-name:"Item\nCount"`
+	note  string
+}
+
+func TestToCsv(t *testing.T) {
+	content, err := toCsv([]testRow{{"a", 1, ""}, {"b, c", 2, "ignored"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Name,Item Count\na,1\n\"b, c\",2\n", content)
+
+	content, err = toCsv([]*testRow(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "Name,Item Count\n", content)
+
+	_, err = toCsv(testRow{})
+	assert.ErrorContains(t, err, "requires a slice")
+}
+
+func TestEmptySliceIfNil(t *testing.T) {
+	assert.Equal(t, []testRow{}, emptySliceIfNil([]testRow(nil)))
+	assert.Equal(t, []testRow{{Name: "a"}}, emptySliceIfNil([]testRow{{Name: "a"}}))
+	assert.Nil(t, emptySliceIfNil(nil))
+}
+
+func TestPrintBriefSummaryReportInFormat(t *testing.T) {
+	testCases := []struct {
+		format         string
+		expectedOutput string
+	}{
+		{"", "{\n  \"status\": \"failure\",\n  \"totals\": {\n    \"success\": 2,\n    \"failure\": 1\n  }\n}\n"},
+		{"yaml", "status: failure\ntotals:\n  success: 2\n  failure: 1\n"},
+		{"csv", "Status,Success,Failure\nfailure,2,1\n"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.format, func(t *testing.T) {
+			t.Setenv(OutputFormatEnv, testCase.format)
+			buffer, _, previousLog := coreTests.RedirectLogOutputToBuffer()
+			defer log.SetLogger(previousLog)
+			assert.NoError(t, PrintBriefSummaryReport(2, 1, false, nil))
+			assert.Equal(t, testCase.expectedOutput, buffer.String())
+		})
+	}
+}
+
+func TestPrintBuildInfoSummaryReportInFormat(t *testing.T) {
+	t.Setenv(OutputFormatEnv, "yaml")
+	buffer, _, previousLog := coreTests.RedirectLogOutputToBuffer()
+	defer log.SetLogger(previousLog)
+	assert.NoError(t, PrintBuildInfoSummaryReport(true, "abc", nil))
+	assert.Equal(t, "status: success\ntotals:\n  success: 1\n  failure: 0\nfiles:\n- sha256: abc\n", buffer.String())
+}
@@ -0,0 +1,54 @@
+package cliutils
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/agnivade/levenshtein"
+	"github.com/urfave/cli"
+)
+
+// Detects typos and can identify one or more valid commands similar to the error command.
+// In Addition, if a subcommand is found with exact match, preferred it over similar commands, for example:
+// "jf bp" -> return "jf rt bp"
+func SearchSimilarCmds(cmds []cli.Command, toCompare string) (bestSimilarity []string) {
+	// Set min diff between two commands.
+	minDistance := 2
+	for _, cmd := range cmds {
+		// Check if we have an exact match with the next level.
+		for _, subCmd := range cmd.Subcommands {
+			for _, subCmdName := range subCmd.Names() {
+				// Found exact match, return it.
+				distance := levenshtein.ComputeDistance(subCmdName, toCompare)
+				if distance == 0 {
+					return []string{cmd.Name + " " + subCmdName}
+				}
+			}
+		}
+		// Search similar commands with max diff of 'minDistance'.
+		for _, cmdName := range cmd.Names() {
+			distance := levenshtein.ComputeDistance(cmdName, toCompare)
+			if distance == minDistance {
+				// In the case of an alias, we don't want to show the full command name, but the alias.
+				// Therefore, we trim the end of the full name and concat the actual matched (alias/full command name)
+				bestSimilarity = append(bestSimilarity, strings.Replace(cmd.FullName(), cmd.Name, cmdName, 1))
+			}
+			if distance < minDistance {
+				// Found a cmd with a smaller distance.
+				minDistance = distance
+				bestSimilarity = []string{strings.Replace(cmd.FullName(), cmd.Name, cmdName, 1)}
+			}
+		}
+	}
+	return
+}
+
+// Returns a message listing the similar commands. The prefix is added to each command, such as 'jf '.
+func GetSimilarCmdsMessage(bestSimilarity []string, prefix string) string {
+	text := "The most similar "
+	if len(bestSimilarity) == 1 {
+		return text + "command is:\n\t" + prefix + bestSimilarity[0]
+	}
+	sort.Strings(bestSimilarity)
+	return text + "commands are:\n\t" + prefix + strings.Join(bestSimilarity, "\n\t"+prefix)
+}
@@ -29,7 +29,9 @@ import (
 	speccore "github.com/jfrog/jfrog-cli-core/v2/common/spec"
 	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/credentialhelper"
 	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-cli/utils/tracing"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/content"
@@ -117,7 +119,7 @@ func summaryPrintError(summaryError, originalError error) error {
 func PrintBriefSummaryReport(success, failed int, failNoOp bool, originalErr error) error {
 	basicSummary, mErr := CreateSummaryReportString(success, failed, failNoOp, originalErr)
 	if mErr == nil {
-		log.Output(basicSummary)
+		mErr = printBasicSummary(basicSummary)
 	}
 	return summaryPrintError(mErr, originalErr)
 }
@@ -142,10 +144,17 @@ func PrintDeploymentView(reader *content.ContentReader) error {
 // Prints a summary report.
 // If a resultReader is provided, we will iterate over the result and print a detailed summary including the affected files.
 func PrintDetailedSummaryReport(basicSummary string, reader *content.ContentReader, uploaded bool, originalErr error) error {
-	// A reader wasn't provided, prints the basic summary json and return.
+	// A reader wasn't provided, prints the basic summary and return.
 	if reader == nil {
+		return summaryPrintError(printBasicSummary(basicSummary), originalErr)
+	}
+	format, mErr := GetRequestedOutputFormat()
+	if mErr != nil {
 		log.Output(basicSummary)
-		return nil
+		return summaryPrintError(mErr, originalErr)
+	}
+	if format != "" && format != Json {
+		return summaryPrintError(printDetailedSummaryInFormat(format, basicSummary, reader, uploaded), originalErr)
 	}
 	writer, mErr := content.NewContentWriter("files", false, true)
 	if mErr != nil {
@@ -205,8 +214,16 @@ func PrintBuildInfoSummaryReport(succeeded bool, sha256 string, originalErr erro
 	if mErr != nil {
 		return summaryPrintError(mErr, originalErr)
 	}
-	log.Output(buildInfoSummary)
-	return summaryPrintError(mErr, originalErr)
+	format, mErr := GetRequestedOutputFormat()
+	if mErr != nil || format == "" || format == Json {
+		log.Output(buildInfoSummary)
+		return summaryPrintError(mErr, originalErr)
+	}
+	files := []buildInfoFileRow{}
+	if succeeded {
+		files = append(files, buildInfoFileRow{Sha256: sha256})
+	}
+	return summaryPrintError(printSummaryInFormat(format, buildInfoSummary, files), originalErr)
 }
 
 func PrintCommandSummary(result *commandUtils.Result, detailedSummary, printDeploymentView, failNoOp bool, originalErr error) (err error) {
@@ -222,16 +239,20 @@ func PrintCommandSummary(result *commandUtils.Result, detailedSummary, printDepl
 	basicSummary, err := CreateSummaryReportString(result.SuccessCount(), result.FailCount(), failNoOp, err)
 	if err != nil {
 		// Print the basic summary and return the original error.
-		log.Output(basicSummary)
+		err = summaryPrintError(printBasicSummary(basicSummary), err)
 		return
 	}
+	// The deployment view is a tree of the uploaded files, so when an output format is requested, the files are printed in the detailed summary instead.
+	if format, formatErr := GetRequestedOutputFormat(); printDeploymentView && format != "" && formatErr == nil {
+		detailedSummary = true
+	}
 	if detailedSummary {
 		err = PrintDetailedSummaryReport(basicSummary, result.Reader(), true, err)
 	} else {
 		if printDeploymentView {
 			err = PrintDeploymentView(result.Reader())
 		}
-		log.Output(basicSummary)
+		err = summaryPrintError(printBasicSummary(basicSummary), err)
 	}
 	return
 }
@@ -403,6 +424,7 @@ func handleSecretInput(c *cli.Context, stringFlag, stdinFlag string) (secret str
 }
 
 func GetSpec(c *cli.Context, isDownload, overrideFieldsIfSet bool) (specFiles *speccore.SpecFiles, err error) {
+	defer tracing.StartSpan("Parse spec").End()
 	specFiles, err = speccore.CreateSpecFromFile(c.String("spec"), coreutils.SpecVarsStringToMap(c.String("spec-vars")))
 	if err != nil {
 		return nil, err
@@ -429,6 +451,7 @@ func trimPatternPrefix(specFiles *speccore.SpecFiles) {
 }
 
 func GetFileSystemSpec(c *cli.Context) (fsSpec *speccore.SpecFiles, err error) {
+	defer tracing.StartSpan("Parse spec").End()
 	fsSpec, err = speccore.CreateSpecFromFile(c.String("spec"), coreutils.SpecVarsStringToMap(c.String("spec-vars")))
 	if err != nil {
 		return
@@ -464,8 +487,13 @@ func overrideIntIfSet(field *int, c *cli.Context, fieldName string) {
 
 // Exclude refreshable tokens parameter should be true when working with external tools (build tools, curl, etc)
 // or when sending requests not via ArtifactoryHttpClient.
+// The secrets of servers which use a credential helper are resolved through the helper.
 func CreateServerDetailsWithConfigOffer(c *cli.Context, excludeRefreshableTokens bool, domain cliutils.CommandDomain) (*coreConfig.ServerDetails, error) {
-	return cliutils.CreateServerDetailsWithConfigOffer(func() (*coreConfig.ServerDetails, error) { return createServerDetailsFromFlags(c, domain) }, excludeRefreshableTokens)
+	details, err := cliutils.CreateServerDetailsWithConfigOffer(func() (*coreConfig.ServerDetails, error) { return createServerDetailsFromFlags(c, domain) }, excludeRefreshableTokens)
+	if err != nil {
+		return nil, err
+	}
+	return details, credentialhelper.Resolve(details)
 }
 
 func createServerDetailsFromFlags(c *cli.Context, domain cliutils.CommandDomain) (details *coreConfig.ServerDetails, err error) {
@@ -682,7 +710,7 @@ func CheckNewCliVersionAvailable(currentVersion string) (warningMessage string,
 				fmt.Sprintf("You are using JFrog CLI version %s, however version ", currentVersion)) +
 				coreutils.PrintTitle(latestVersion) +
 				coreutils.PrintComment(" is available."),
-			coreutils.PrintComment("To install the latest version, visit: ") + coreutils.PrintLink(coreutils.JFrogComUrl+"getcli"),
+			coreutils.PrintComment("To install the latest version, run 'jf self-update' or visit: ") + coreutils.PrintLink(coreutils.JFrogComUrl+"getcli"),
 			coreutils.PrintComment("To see the release notes, visit: ") + coreutils.PrintLink("https://github.com/jfrog/jfrog-cli/releases"),
 			coreutils.PrintComment(fmt.Sprintf("To avoid this message, set the %s variable to TRUE", JfrogCliAvoidNewVersionWarning)),
 		},
@@ -0,0 +1,139 @@
+package credentialhelper
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	biutils "github.com/jfrog/build-info-go/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/lock"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Runs the 'config add' or 'config edit' command of a server whose secrets are held by a credential helper.
+// The config command saves the server's secrets in the config file, so it's run against a copy of the config in a temporary JFrog home.
+// The secrets are then stored by the helper, and only then the config is saved without them.
+// If the config fails to be saved, the secrets the helper held before are restored.
+func RunConfigCommand(configCmd *commands.ConfigCommand, helperName string) (err error) {
+	lockDirPath, err := coreutils.GetJfrogConfigLockDir()
+	if err != nil {
+		return
+	}
+	unlock, err := lock.CreateLock(lockDirPath)
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+	if err != nil {
+		return
+	}
+	servers, err := runInTempHome(configCmd)
+	if err != nil {
+		return
+	}
+	details, err := configCmd.ServerDetails()
+	if err != nil || details == nil {
+		return
+	}
+	var server *config.ServerDetails
+	for _, configured := range servers {
+		if configured.ServerId == details.ServerId {
+			server = configured
+		}
+	}
+	if server == nil {
+		return errorutils.CheckErrorf("Server ID '%s' doesn't exist.", details.ServerId)
+	}
+	return storeAndSaveServers(servers, server, helperName)
+}
+
+// Runs the config command with a temporary JFrog home, holding a copy of the config and its encryption settings,
+// and returns the servers the command saved there.
+func runInTempHome(configCmd *commands.ConfigCommand) (servers []*config.ServerDetails, err error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return
+	}
+	tempHomeDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempHomeDir))
+	}()
+	configFileName := coreutils.JfrogConfigFile + ".v" + strconv.Itoa(coreutils.GetCliConfigVersion())
+	exists, err := fileutils.IsFileExists(filepath.Join(homeDir, configFileName), false)
+	if err != nil {
+		return
+	}
+	if exists {
+		if err = biutils.CopyFile(tempHomeDir, filepath.Join(homeDir, configFileName)); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	securityDir := filepath.Join(homeDir, coreutils.JfrogSecurityDirName)
+	if exists, err = fileutils.IsDirExists(securityDir, false); err != nil {
+		return
+	}
+	if exists {
+		if err = biutils.CopyDir(securityDir, filepath.Join(tempHomeDir, coreutils.JfrogSecurityDirName), true, nil); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+
+	homeDirEnv, homeDirEnvExists := os.LookupEnv(coreutils.HomeDir)
+	if err = errorutils.CheckError(os.Setenv(coreutils.HomeDir, tempHomeDir)); err != nil {
+		return
+	}
+	defer func() {
+		if homeDirEnvExists {
+			err = errors.Join(err, errorutils.CheckError(os.Setenv(coreutils.HomeDir, homeDirEnv)))
+		} else {
+			err = errors.Join(err, errorutils.CheckError(os.Unsetenv(coreutils.HomeDir)))
+		}
+	}()
+	if err = configCmd.Run(); err != nil {
+		return
+	}
+	return config.GetAllServersConfigs()
+}
+
+// Stores the server's secrets by the helper, and saves the servers to the config without them.
+func storeAndSaveServers(servers []*config.ServerDetails, server *config.ServerDetails, helperName string) error {
+	previousHelperName, err := GetHelperName(server.ServerId)
+	if err != nil {
+		return err
+	}
+	helper := NewHelper(helperName)
+	var previousCredentials *Credentials
+	if previousHelperName == helperName {
+		if previousCredentials, err = helper.Get(server.ServerId, getServerUrl(server)); err != nil {
+			log.Debug("Couldn't get the server's previous credentials from the credential helper:", err.Error())
+		}
+	}
+	credentials := GetCredentials(server)
+	if !credentials.IsEmpty() {
+		if err = helper.Store(server.ServerId, getServerUrl(server), credentials); err != nil {
+			return err
+		}
+	}
+	StripCredentials(server)
+	if err = SetHelperName(server.ServerId, helperName); err == nil {
+		if err = config.SaveServersConf(servers); err == nil {
+			return nil
+		}
+	}
+
+	log.Warn("Rolling back the credentials of server '" + server.ServerId + "'...")
+	if previousCredentials != nil {
+		err = errors.Join(err, helper.Store(server.ServerId, getServerUrl(server), *previousCredentials))
+	} else if !credentials.IsEmpty() {
+		err = errors.Join(err, helper.Erase(server.ServerId, getServerUrl(server)))
+	}
+	return errors.Join(err, SetHelperName(server.ServerId, previousHelperName))
+}
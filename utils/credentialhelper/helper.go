@@ -0,0 +1,128 @@
+package credentialhelper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Credential helpers are external executables, named with this prefix followed by the helper's name, and found in the PATH.
+const HelperExecutablePrefix = "jf-credential-"
+
+// The actions a credential helper must support. The action is passed as the helper's single argument.
+const (
+	ActionGet   = "get"
+	ActionStore = "store"
+	ActionErase = "erase"
+)
+
+// Sent to the credential helper's stdin for all actions. Credentials are sent only with the 'store' action.
+type Request struct {
+	ServerId string `json:"serverId"`
+	Url      string `json:"url,omitempty"`
+	Credentials
+}
+
+// Written by the credential helper to its stdout in response to the 'get' action.
+type Credentials struct {
+	User         string `json:"user,omitempty"`
+	Password     string `json:"password,omitempty"`
+	AccessToken  string `json:"accessToken,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+}
+
+func (c *Credentials) IsEmpty() bool {
+	return c.Password == "" && c.AccessToken == "" && c.RefreshToken == ""
+}
+
+// Extracts the secrets of the server details.
+func GetCredentials(details *config.ServerDetails) Credentials {
+	return Credentials{User: details.User, Password: details.Password, AccessToken: details.AccessToken, RefreshToken: details.RefreshToken}
+}
+
+// Sets the credentials on the server details. The user is kept if the helper doesn't return one.
+func (c *Credentials) apply(details *config.ServerDetails) {
+	if c.User != "" {
+		details.User = c.User
+	}
+	details.Password = c.Password
+	details.AccessToken = c.AccessToken
+	details.RefreshToken = c.RefreshToken
+}
+
+// Removes the secrets from the server details, before the server is saved to the config file.
+func StripCredentials(details *config.ServerDetails) {
+	details.Password = ""
+	details.AccessToken = ""
+	details.RefreshToken = ""
+	details.ArtifactoryRefreshToken = ""
+	details.ArtifactoryTokenRefreshInterval = 0
+}
+
+// Runs a credential helper according to the docker-style credential helpers protocol:
+// The request is written as JSON to the helper's stdin, and the response to the 'get' action is read as JSON from its stdout.
+// A non-zero exit code fails the action, with the helper's stderr as the error message.
+type Helper struct {
+	Name string
+}
+
+func NewHelper(name string) *Helper {
+	return &Helper{Name: name}
+}
+
+func (h *Helper) executableName() string {
+	return HelperExecutablePrefix + h.Name
+}
+
+func (h *Helper) Get(serverId, url string) (*Credentials, error) {
+	output, err := h.run(ActionGet, &Request{ServerId: serverId, Url: url})
+	if err != nil {
+		return nil, err
+	}
+	credentials := new(Credentials)
+	if err = json.Unmarshal(output, credentials); err != nil {
+		return nil, errorutils.CheckErrorf("credential helper '%s' returned an invalid response: %s", h.executableName(), err.Error())
+	}
+	return credentials, nil
+}
+
+func (h *Helper) Store(serverId, url string, credentials Credentials) error {
+	_, err := h.run(ActionStore, &Request{ServerId: serverId, Url: url, Credentials: credentials})
+	return err
+}
+
+func (h *Helper) Erase(serverId, url string) error {
+	_, err := h.run(ActionErase, &Request{ServerId: serverId, Url: url})
+	return err
+}
+
+func (h *Helper) run(action string, request *Request) ([]byte, error) {
+	execPath, err := exec.LookPath(h.executableName())
+	if err != nil {
+		return nil, errorutils.CheckErrorf("credential helper '%s' wasn't found in the PATH: %s", h.executableName(), err.Error())
+	}
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	log.Debug(fmt.Sprintf("Running credential helper '%s %s' for server '%s'", h.executableName(), action, request.ServerId))
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(execPath, action)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, errorutils.CheckErrorf("credential helper '%s %s' failed: %s", h.executableName(), action, message)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,140 @@
+package credentialhelper
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	coreTests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A credential helper which stores the last request it received in a file per server ID.
+const fakeHelperScript = `#!/bin/sh
+request=$(cat)
+server=$(echo "$request" | sed 's/.*"serverId":"\([^"]*\)".*/\1/')
+store="$STORE_DIR/$server.json"
+case "$1" in
+  store) echo "$request" > "$store" ;;
+  get) [ -f "$store" ] || { echo "no credentials for $server" >&2; exit 1; }; cat "$store" ;;
+  erase) rm -f "$store" ;;
+  *) exit 1 ;;
+esac
+`
+
+// Installs the fake helper in a temp dir, which is added to the PATH, and returns the helper's store directory.
+func installFakeHelper(t *testing.T) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("The fake credential helper is a shell script.")
+	}
+	binDir := t.TempDir()
+	storeDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, HelperExecutablePrefix+"fake"), []byte(fakeHelperScript), 0755))
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("STORE_DIR", storeDir)
+	return storeDir
+}
+
+func TestHelper(t *testing.T) {
+	storeDir := installFakeHelper(t)
+	helper := NewHelper("fake")
+
+	assert.NoError(t, helper.Store("my-server", "https://acme.jfrog.io/", Credentials{User: "admin", AccessToken: "token"}))
+	assert.FileExists(t, filepath.Join(storeDir, "my-server.json"))
+	credentials, err := helper.Get("my-server", "https://acme.jfrog.io/")
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{User: "admin", AccessToken: "token"}, *credentials)
+
+	assert.NoError(t, helper.Erase("my-server", "https://acme.jfrog.io/"))
+	_, err = helper.Get("my-server", "https://acme.jfrog.io/")
+	assert.ErrorContains(t, err, "no credentials for my-server")
+
+	_, err = NewHelper("missing").Get("my-server", "https://acme.jfrog.io/")
+	assert.ErrorContains(t, err, "wasn't found in the PATH")
+}
+
+func TestStoreAndResolveServerCredentials(t *testing.T) {
+	installFakeHelper(t)
+	cleanUpJfrogHome, err := coreTests.SetJfrogHome()
+	require.NoError(t, err)
+	defer cleanUpJfrogHome()
+	require.NoError(t, config.SaveServersConf([]*config.ServerDetails{{ServerId: "my-server", Url: "https://acme.jfrog.io/", User: "admin", Password: "password", IsDefault: true}}))
+
+	// The secrets are moved from the config file to the helper.
+	require.NoError(t, StoreServerCredentials("my-server", "fake"))
+	stored, err := config.GetSpecificConfig("my-server", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "admin", stored.User)
+	assert.Empty(t, stored.Password)
+	helperName, err := GetHelperName("my-server")
+	assert.NoError(t, err)
+	assert.Equal(t, "fake", helperName)
+
+	// The secrets are resolved at runtime.
+	resolved, err := GetSpecificConfig("", true, false)
+	require.NoError(t, err)
+	assert.Equal(t, "password", resolved.Password)
+
+	// Secrets set explicitly take precedence over the helper.
+	explicit := &config.ServerDetails{ServerId: "my-server", AccessToken: "explicit"}
+	assert.NoError(t, Resolve(explicit))
+	assert.Equal(t, "explicit", explicit.AccessToken)
+	assert.Empty(t, explicit.Password)
+
+	// Copied servers use the same helper.
+	require.NoError(t, CopyServerCredentials(stored, "copy"))
+	copied := &config.ServerDetails{ServerId: "copy"}
+	assert.NoError(t, Resolve(copied))
+	assert.Equal(t, "password", copied.Password)
+
+	require.NoError(t, EraseServerCredentials(stored))
+	helperName, err = GetHelperName("my-server")
+	assert.NoError(t, err)
+	assert.Empty(t, helperName)
+	unresolved := &config.ServerDetails{ServerId: "my-server"}
+	assert.NoError(t, Resolve(unresolved))
+	assert.Empty(t, unresolved.Password)
+}
+
+func TestRunConfigCommand(t *testing.T) {
+	installFakeHelper(t)
+	cleanUpJfrogHome, err := coreTests.SetJfrogHome()
+	require.NoError(t, err)
+	defer cleanUpJfrogHome()
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	require.NoError(t, err)
+	require.NoError(t, config.SaveServersConf([]*config.ServerDetails{{ServerId: "other", Url: "https://other.jfrog.io/", User: "admin", Password: "other-password", IsDefault: true}}))
+
+	// The secrets are stored by the helper, and never saved to the config file.
+	configCmd := commands.NewConfigCommand(commands.AddOrEdit, "my-server").SetInteractive(false).SetUseBasicAuthOnly(true).
+		SetDetails(&config.ServerDetails{Url: "https://acme.jfrog.io/", User: "admin", Password: "password"})
+	require.NoError(t, RunConfigCommand(configCmd, "fake"))
+	currentHomeDir, err := coreutils.GetJfrogHomeDir()
+	assert.NoError(t, err)
+	assert.Equal(t, homeDir, currentHomeDir)
+	stored, err := config.GetSpecificConfig("my-server", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "https://acme.jfrog.io/", stored.Url)
+	assert.Empty(t, stored.Password)
+	assert.NoError(t, Resolve(stored))
+	assert.Equal(t, "password", stored.Password)
+	other, err := config.GetSpecificConfig("other", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "other-password", other.Password)
+
+	// If the helper fails storing the secrets, the config isn't changed.
+	configCmd = commands.NewConfigCommand(commands.AddOrEdit, "failed-server").SetInteractive(false).SetUseBasicAuthOnly(true).
+		SetDetails(&config.ServerDetails{Url: "https://acme.jfrog.io/", User: "admin", Password: "password"})
+	assert.ErrorContains(t, RunConfigCommand(configCmd, "missing"), "wasn't found in the PATH")
+	servers, err := config.GetAllServersConfigs()
+	require.NoError(t, err)
+	assert.Len(t, servers, 2)
+	helperName, err := GetHelperName("failed-server")
+	assert.NoError(t, err)
+	assert.Empty(t, helperName)
+}
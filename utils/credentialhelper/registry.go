@@ -0,0 +1,190 @@
+package credentialhelper
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	// Stored in the JFrog home directory, next to the config file, which can't hold the helper's name.
+	credentialHelpersFileName = "credential-helpers.json"
+	credentialHelpersVersion  = 1
+)
+
+// Maps server IDs to the names of the credential helpers which hold their secrets.
+type helpersRegistry struct {
+	Version int               `json:"version"`
+	Servers map[string]string `json:"servers"`
+}
+
+func getRegistryPath() (string, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, credentialHelpersFileName), nil
+}
+
+func loadRegistry() (*helpersRegistry, error) {
+	registry := &helpersRegistry{Version: credentialHelpersVersion, Servers: map[string]string{}}
+	registryPath, err := getRegistryPath()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(registryPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return registry, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	if err = json.Unmarshal(content, registry); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing %s: %s", registryPath, err.Error())
+	}
+	if registry.Servers == nil {
+		registry.Servers = map[string]string{}
+	}
+	return registry, nil
+}
+
+func (hr *helpersRegistry) save() error {
+	registryPath, err := getRegistryPath()
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(hr)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.MkdirAll(filepath.Dir(registryPath), 0700); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(registryPath, content, 0600))
+}
+
+// Returns the name of the credential helper of the server, or an empty string if the server's secrets are stored in the config file.
+func GetHelperName(serverId string) (string, error) {
+	registry, err := loadRegistry()
+	if err != nil {
+		return "", err
+	}
+	return registry.Servers[serverId], nil
+}
+
+// Sets the credential helper of the server. An empty name unsets it.
+func SetHelperName(serverId, helperName string) error {
+	registry, err := loadRegistry()
+	if err != nil {
+		return err
+	}
+	if registry.Servers[serverId] == helperName {
+		return nil
+	}
+	if helperName == "" {
+		delete(registry.Servers, serverId)
+	} else {
+		registry.Servers[serverId] = helperName
+	}
+	return registry.save()
+}
+
+func getServerUrl(details *config.ServerDetails) string {
+	if details.Url != "" {
+		return details.Url
+	}
+	return details.ArtifactoryUrl
+}
+
+// Fills the server's secrets from its credential helper, if it has one.
+// Secrets which are already set on the server details, for example by command options, take precedence.
+func Resolve(details *config.ServerDetails) error {
+	if details == nil || details.ServerId == "" || details.Password != "" || details.AccessToken != "" {
+		return nil
+	}
+	helperName, err := GetHelperName(details.ServerId)
+	if err != nil || helperName == "" {
+		return err
+	}
+	credentials, err := NewHelper(helperName).Get(details.ServerId, getServerUrl(details))
+	if err != nil {
+		return err
+	}
+	credentials.apply(details)
+	return nil
+}
+
+// Same as config.GetSpecificConfig, with the server's secrets resolved through its credential helper.
+func GetSpecificConfig(serverId string, defaultOrEmpty, excludeRefreshableTokens bool) (*config.ServerDetails, error) {
+	details, err := config.GetSpecificConfig(serverId, defaultOrEmpty, excludeRefreshableTokens)
+	if err != nil {
+		return nil, err
+	}
+	return details, Resolve(details)
+}
+
+// Moves the secrets of a configured server to the credential helper, and removes them from the config file.
+func StoreServerCredentials(serverId, helperName string) error {
+	servers, err := config.GetAllServersConfigs()
+	if err != nil {
+		return err
+	}
+	var details *config.ServerDetails
+	for _, server := range servers {
+		if server.ServerId == serverId {
+			details = server
+		}
+	}
+	if details == nil {
+		return errorutils.CheckErrorf("Server ID '%s' doesn't exist.", serverId)
+	}
+	credentials := GetCredentials(details)
+	if !credentials.IsEmpty() {
+		if err = NewHelper(helperName).Store(serverId, getServerUrl(details), credentials); err != nil {
+			return err
+		}
+		StripCredentials(details)
+		if err = config.SaveServersConf(servers); err != nil {
+			return err
+		}
+	}
+	return SetHelperName(serverId, helperName)
+}
+
+// Stores the server's credentials by its credential helper, keyed the same way as when they are resolved.
+func StoreCredentials(helperName string, details *config.ServerDetails, credentials Credentials) error {
+	return NewHelper(helperName).Store(details.ServerId, getServerUrl(details), credentials)
+}
+
+// Copies the secrets of a server which uses a credential helper to a new server ID, using the same helper.
+func CopyServerCredentials(source *config.ServerDetails, targetServerId string) error {
+	helperName, err := GetHelperName(source.ServerId)
+	if err != nil || helperName == "" {
+		return err
+	}
+	helper := NewHelper(helperName)
+	credentials, err := helper.Get(source.ServerId, getServerUrl(source))
+	if err != nil {
+		return err
+	}
+	if err = helper.Store(targetServerId, getServerUrl(source), *credentials); err != nil {
+		return err
+	}
+	return SetHelperName(targetServerId, helperName)
+}
+
+// Erases the secrets of the server from its credential helper, if it has one.
+func EraseServerCredentials(details *config.ServerDetails) error {
+	helperName, err := GetHelperName(details.ServerId)
+	if err != nil || helperName == "" {
+		return err
+	}
+	if err = NewHelper(helperName).Erase(details.ServerId, getServerUrl(details)); err != nil {
+		return err
+	}
+	return SetHelperName(details.ServerId, "")
+}
@@ -0,0 +1,359 @@
+package httpintercept
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/httprecord"
+	"github.com/jfrog/jfrog-cli/utils/httptransport"
+	"github.com/jfrog/jfrog-cli/utils/jsonlog"
+	"github.com/jfrog/jfrog-cli/utils/tracing"
+	"github.com/jfrog/jfrog-client-go/auth/cert"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	// The CA certificate of the local proxy is written to the JFrog CLI certificates directory, with the process ID in its name.
+	caCertificatePrefix = "jfrog-cli-local-proxy-ca-"
+	caCertificateSuffix = ".pem"
+	// A host which is routed through the local proxy, used to check that the proxy environment variables were read.
+	probeHost = "jfrog-cli-local-proxy.invalid"
+	// The directories which the system's roots are loaded from, in addition to the SSL_CERT_FILE file.
+	sslCertDirEnv = "SSL_CERT_DIR"
+)
+
+var (
+	// The environment variables which http.ProxyFromEnvironment reads.
+	proxyEnvVars = []string{"HTTP_PROXY", "http_proxy", "HTTPS_PROXY", "https_proxy", "NO_PROXY", "no_proxy", "REQUEST_METHOD"}
+	// The directories which the system's roots are loaded from if SSL_CERT_DIR isn't set, on the Unix systems Go supports.
+	defaultCertDirs = []string{"/etc/ssl/certs", "/etc/pki/tls/certs", "/system/etc/security/cacerts", "/usr/local/share/certs", "/etc/openssl/certs", "/etc/certs/CA"}
+)
+
+// The HTTP requests of the command are intercepted by a local proxy, which all the HTTP clients of the process send their requests through,
+// including the clients created by jfrog-cli-core and jfrog-client-go. The proxy sends the requests through a single upstream transport,
+// which logs their HTTP events and traces them, so each request is logged and traced once, whichever client sent it.
+// The upstream transport also records the requests, or replays recorded requests without sending them.
+type Session struct {
+	proxy          *Proxy
+	caPath         string
+	resetTransport func()
+	// Saves the cassette of the recorded HTTP interactions. Nil if not recording.
+	saveCassette func() error
+}
+
+// Returns true if the HTTP requests of the command should be intercepted, which is when their HTTP events are logged or traced,
+// or when they're recorded or replayed.
+func IsRequired() bool {
+	logger := jsonlog.GetLogger()
+	return logger != nil && logger.GetLogLevel() >= log.DEBUG || tracing.IsEnabled() || httprecord.IsRequested()
+}
+
+// Starts intercepting the HTTP requests of the process, if required. Returns nil if not required.
+// It must be called before the HTTP clients send their first request, since the proxy environment variables are read once per process.
+// args - The command's arguments, whose TLS options apply to the requests the proxy sends.
+func Start(args []string) (*Session, error) {
+	if !IsRequired() {
+		return nil, nil
+	}
+	// The requests are sent through the proxy set by the environment variables, before they're routed through the local proxy.
+	proxyFunc := httpproxy.FromEnvironment().ProxyFunc()
+	upstream, err := newUpstreamTransport(args, proxyFunc)
+	if err != nil {
+		return nil, err
+	}
+	upstream, saveCassette, err := httprecord.NewTransport(upstream)
+	if err != nil {
+		return nil, err
+	}
+	session, err := start(instrument(upstream), proxyFunc)
+	if err != nil {
+		return nil, err
+	}
+	session.saveCassette = saveCassette
+	return session, nil
+}
+
+// The HTTP events and spans of the requests are created by the proxy's upstream transport only, so the clients don't create them too.
+func instrument(upstream http.RoundTripper) http.RoundTripper {
+	return tracing.NewTransport(jsonlog.NewTransport(upstream))
+}
+
+func start(upstream http.RoundTripper, tunnelProxy func(*url.URL) (*url.URL, error)) (*Session, error) {
+	proxy, err := NewProxy(upstream, tunnelProxy)
+	if err != nil {
+		return nil, err
+	}
+	if err = proxy.Start(); err != nil {
+		return nil, err
+	}
+	session := &Session{proxy: proxy}
+	if err = session.routeProcess(); err != nil {
+		return nil, errors.Join(err, session.Stop())
+	}
+	log.Debug("Sending the HTTP requests through the local proxy at", proxy.GetUrl())
+	return session, nil
+}
+
+// Routes the HTTP clients of the process through the proxy, and makes them trust its CA.
+func (session *Session) routeProcess() (err error) {
+	proxyUrl, err := url.Parse(session.proxy.GetUrl())
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	caCertificate := session.proxy.GetCaCertificate()
+	// The system's roots are loaded once per process, so the CA is added to them before they're loaded by the clients.
+	// The clients which trust them only don't trust the CA if it can't be added, and the requests to their hosts aren't intercepted.
+	if err = trustInSystemRoots(caCertificate); err != nil {
+		log.Debug("The HTTP clients which trust the system's CA certificates only don't trust the local proxy:", err.Error())
+	}
+	// The clients created by JFrog CLI itself send their requests through the shared transport.
+	if session.resetTransport, err = httptransport.RouteThroughProxy(proxyUrl, caCertificate); err != nil {
+		return err
+	}
+	// The clients created by jfrog-cli-core trust the certificates in the JFrog CLI certificates directory, and use the proxy environment variables.
+	if session.caPath, err = writeCaCertificate(caCertificate); err != nil {
+		return err
+	}
+	return routeProxyFromEnvironment(proxyUrl)
+}
+
+// Stops the proxy, and removes its CA certificate from the certificates directory.
+func (session *Session) Stop() error {
+	var errs []error
+	if session.resetTransport != nil {
+		session.resetTransport()
+	}
+	errs = append(errs, session.proxy.Stop())
+	if session.caPath != "" {
+		errs = append(errs, errorutils.CheckError(os.Remove(session.caPath)))
+	}
+	if session.saveCassette != nil {
+		errs = append(errs, session.saveCassette())
+	}
+	return errors.Join(errs...)
+}
+
+func writeCaCertificate(caCertificate []byte) (string, error) {
+	certsDir, err := coreutils.GetJfrogCertsDir()
+	if err != nil {
+		return "", err
+	}
+	if err = os.MkdirAll(certsDir, 0700); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	removeExpiredCaCertificates(certsDir)
+	caPath := filepath.Join(certsDir, fmt.Sprintf("%s%d%s", caCertificatePrefix, os.Getpid(), caCertificateSuffix))
+	return caPath, errorutils.CheckError(os.WriteFile(caPath, caCertificate, 0600))
+}
+
+// The CA certificates of processes which were killed before removing them are removed once they expire.
+func removeExpiredCaCertificates(certsDir string) {
+	paths, err := filepath.Glob(filepath.Join(certsDir, caCertificatePrefix+"*"+caCertificateSuffix))
+	if err != nil {
+		return
+	}
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > certificateValidity {
+			if err = os.Remove(path); err != nil {
+				log.Debug("Failed removing an expired CA certificate of the local proxy:", err.Error())
+			}
+		}
+	}
+}
+
+// Adds the CA to the system's roots, which the clients that don't set their own roots verify the servers with.
+// On Linux and the other Unix systems except macOS, the roots are loaded once per process, from the SSL_CERT_FILE file and the SSL_CERT_DIR directories.
+// They're loaded while SSL_CERT_DIR also includes a directory with the CA certificate, and it's restored afterwards.
+// The roots of macOS and Windows are verified by the operating system, so the CA can't be added to them.
+func trustInSystemRoots(caCertificate []byte) error {
+	block, _ := pem.Decode(caCertificate)
+	if block == nil {
+		return errorutils.CheckErrorf("failed parsing the CA certificate of the local proxy")
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if runtime.GOOS != "darwin" && !coreutils.IsWindows() {
+		if err = loadSystemRoots(caCertificate); err != nil {
+			return err
+		}
+	}
+	_, err = certificate.Verify(x509.VerifyOptions{})
+	return errorutils.CheckError(err)
+}
+
+func loadSystemRoots(caCertificate []byte) (err error) {
+	caDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(caDir))
+	}()
+	if err = os.WriteFile(filepath.Join(caDir, "ca.pem"), caCertificate, 0600); err != nil {
+		return errorutils.CheckError(err)
+	}
+	certDirs, exists := os.LookupEnv(sslCertDirEnv)
+	if !exists {
+		certDirs = strings.Join(defaultCertDirs, string(os.PathListSeparator))
+	}
+	if err = os.Setenv(sslCertDirEnv, caDir+string(os.PathListSeparator)+certDirs); err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, err = x509.SystemCertPool()
+	if exists {
+		err = errors.Join(err, os.Setenv(sslCertDirEnv, certDirs))
+	} else {
+		err = errors.Join(err, os.Unsetenv(sslCertDirEnv))
+	}
+	return errorutils.CheckError(err)
+}
+
+// http.ProxyFromEnvironment, which the clients of jfrog-client-go use, reads the proxy environment variables once per process.
+// It reads them while they point to the proxy, and they're restored afterwards,
+// so the processes started by the command, such as the package managers, aren't routed through the proxy.
+// Like any proxy set by these variables, the proxy isn't used for requests to loopback addresses.
+func routeProxyFromEnvironment(proxyUrl *url.URL) error {
+	previous := map[string]*string{}
+	for _, key := range proxyEnvVars {
+		if value, exists := os.LookupEnv(key); exists {
+			previous[key] = &value
+		}
+	}
+	defer func() {
+		for _, key := range proxyEnvVars {
+			if value := previous[key]; value != nil {
+				_ = os.Setenv(key, *value)
+			} else {
+				_ = os.Unsetenv(key)
+			}
+		}
+	}()
+	for _, key := range proxyEnvVars {
+		if err := os.Unsetenv(key); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	for _, key := range []string{"HTTP_PROXY", "HTTPS_PROXY"} {
+		if err := os.Setenv(key, proxyUrl.String()); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	usedProxy, err := http.ProxyFromEnvironment(&http.Request{URL: &url.URL{Scheme: "https", Host: probeHost}})
+	if err != nil || usedProxy == nil || usedProxy.String() != proxyUrl.String() {
+		return errorutils.CheckErrorf("failed routing the HTTP clients through the local proxy, since the proxy environment variables were already read")
+	}
+	return nil
+}
+
+// The requests are sent with the proxy settings, the certificates and the TLS options the command would have used without the local proxy.
+// The servers which were configured with a client certificate are sent their requests with it.
+func newUpstreamTransport(args []string, proxyFunc func(*url.URL) (*url.URL, error)) (http.RoundTripper, error) {
+	certsDir, err := coreutils.GetJfrogCertsDir()
+	if err != nil {
+		return nil, err
+	}
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.Proxy = func(request *http.Request) (*url.URL, error) {
+		return proxyFunc(request.URL)
+	}
+	insecureTls, clientCertPath, clientCertKeyPath := getTlsArgs(args)
+	if base, err = cert.GetTransportWithLoadedCert(certsDir, insecureTls, base); err != nil {
+		return nil, err
+	}
+	if err = addClientCertificate(base, clientCertPath, clientCertKeyPath); err != nil {
+		return nil, err
+	}
+	upstream := &upstreamTransport{base: base, hosts: map[string]*http.Transport{}}
+	servers, err := config.GetAllServersConfigs()
+	if err != nil {
+		log.Debug("Failed reading the configured servers' client certificates:", err.Error())
+		return upstream, nil
+	}
+	for _, server := range servers {
+		// A client certificate provided by the command's options overrides the configured one.
+		if server.ClientCertPath == "" || clientCertPath != "" {
+			continue
+		}
+		transport := base.Clone()
+		if err = addClientCertificate(transport, server.ClientCertPath, server.ClientCertKeyPath); err != nil {
+			log.Debug(fmt.Sprintf("Failed loading the client certificate of server '%s': %s", server.ServerId, err.Error()))
+			continue
+		}
+		for _, serverUrl := range []string{server.Url, server.ArtifactoryUrl, server.DistributionUrl, server.XrayUrl, server.XscUrl,
+			server.MissionControlUrl, server.PipelinesUrl, server.AccessUrl, server.LifecycleUrl, server.EvidenceUrl, server.MetadataUrl} {
+			if parsedUrl, err := url.Parse(serverUrl); err == nil && parsedUrl.Host != "" {
+				upstream.hosts[parsedUrl.Host] = transport
+			}
+		}
+	}
+	return upstream, nil
+}
+
+func addClientCertificate(transport *http.Transport, clientCertPath, clientCertKeyPath string) error {
+	if clientCertPath == "" {
+		return nil
+	}
+	certificate, err := cert.LoadCertificate(clientCertPath, clientCertKeyPath)
+	if err != nil {
+		return err
+	}
+	transport.TLSClientConfig.Certificates = []tls.Certificate{certificate}
+	return nil
+}
+
+// Returns the values of the --insecure-tls, --client-cert-path and --client-cert-key-path options of the command.
+func getTlsArgs(args []string) (insecureTls bool, clientCertPath, clientCertKeyPath string) {
+	for i, arg := range args {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		if !hasValue && i+1 < len(args) {
+			value = args[i+1]
+		}
+		switch name {
+		case "insecure-tls":
+			insecureTls = !hasValue || value == "true"
+		case "client-cert-path":
+			clientCertPath = value
+		case "client-cert-key-path":
+			clientCertKeyPath = value
+		}
+	}
+	return
+}
+
+// Sends the requests of the servers which were configured with a client certificate with it.
+type upstreamTransport struct {
+	base *http.Transport
+	// The transports of the servers' hosts, in the form of 'host:port' or 'host' for the default port.
+	hosts map[string]*http.Transport
+}
+
+func (upstream *upstreamTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	return upstream.getTransport(request).RoundTrip(request)
+}
+
+func (upstream *upstreamTransport) getTransport(request *http.Request) *http.Transport {
+	if transport, exists := upstream.hosts[request.URL.Host]; exists {
+		return transport
+	}
+	return upstream.base
+}
@@ -0,0 +1,151 @@
+package httpintercept
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/httptransport"
+	"github.com/jfrog/jfrog-cli/utils/jsonlog"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (roundTrip roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return roundTrip(request)
+}
+
+// Serves the requests without a network, so the intercepted requests can be sent to any host.
+var testUpstream = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Header: http.Header{}, Request: request,
+		Body: io.NopCloser(strings.NewReader(request.Method + " " + request.URL.String()))}, nil
+})
+
+// The proxy environment variables are read once per process, so this is the only test which starts a session.
+func TestStart(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("NO_PROXY", "")
+	logsBuffer := &bytes.Buffer{}
+	previousLogger := log.GetLogger()
+	log.SetLogger(jsonlog.NewLogger(log.DEBUG).SetLogsWriter(logsBuffer))
+	defer log.SetLogger(previousLogger)
+	require.True(t, IsRequired())
+
+	session, err := start(instrument(testUpstream), nil)
+	require.NoError(t, err)
+	// The processes started by the CLI aren't routed through the proxy.
+	assert.Empty(t, os.Getenv("HTTPS_PROXY"))
+	certsDir, err := coreutils.GetJfrogCertsDir()
+	require.NoError(t, err)
+	caPaths, err := filepath.Glob(filepath.Join(certsDir, caCertificatePrefix+"*"))
+	require.NoError(t, err)
+	assert.Len(t, caPaths, 1)
+
+	// A client created the way jfrog-cli-core creates its clients.
+	coreClient, err := httpclient.ClientBuilder().SetCertificatesPath(certsDir).Build()
+	require.NoError(t, err)
+	_, body, _, err := coreClient.SendGet("https://acme.jfrog.io/artifactory/api/system/ping", true, httputils.HttpClientDetails{}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "GET https://acme.jfrog.io/artifactory/api/system/ping", string(body))
+	// A client which trusts the system's roots only, such as the clients of the Mission Control commands.
+	systemRootsClient, err := httpclient.ClientBuilder().Build()
+	require.NoError(t, err)
+	_, body, _, err = systemRootsClient.SendGet("https://acme.jfrog.io/mc/api/v1/system/ping", true, httputils.HttpClientDetails{}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "GET https://acme.jfrog.io/mc/api/v1/system/ping", string(body))
+	// A client created by JFrog CLI itself.
+	response, err := httptransport.NewClient().Get("https://acme.jfrog.io/artifactory/api/system/version")
+	require.NoError(t, err)
+	body, err = io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	assert.Equal(t, "GET https://acme.jfrog.io/artifactory/api/system/version", string(body))
+
+	require.NoError(t, session.Stop())
+	caPaths, err = filepath.Glob(filepath.Join(certsDir, caCertificatePrefix+"*"))
+	require.NoError(t, err)
+	assert.Empty(t, caPaths)
+
+	// Each request is logged once, by the proxy.
+	var urls []string
+	for _, line := range strings.Split(strings.TrimSpace(logsBuffer.String()), "\n") {
+		var entry jsonlog.Entry
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		if entry.Event == jsonlog.HttpResponseEvent {
+			urls = append(urls, entry.Url)
+		}
+	}
+	assert.Equal(t, []string{"https://acme.jfrog.io/artifactory/api/system/ping", "https://acme.jfrog.io/mc/api/v1/system/ping",
+		"https://acme.jfrog.io/artifactory/api/system/version"}, urls)
+}
+
+func TestTunnelUntrustedHost(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("tunneled"))
+	}))
+	defer server.Close()
+	proxy, err := NewProxy(testUpstream, nil)
+	require.NoError(t, err)
+	require.NoError(t, proxy.Start())
+	defer func() {
+		assert.NoError(t, proxy.Stop())
+	}()
+	proxyUrl, err := url.Parse(proxy.GetUrl())
+	require.NoError(t, err)
+	// The client trusts the server's certificate, but not the proxy's CA.
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyURL(proxyUrl)
+	client := &http.Client{Transport: transport}
+
+	_, err = client.Get(server.URL)
+	assert.ErrorContains(t, err, "certificate")
+	// The client closes the connection once it rejects the certificate, before the proxy handles the rejection.
+	require.Eventually(t, func() bool {
+		return proxy.isTunneled(strings.TrimPrefix(server.URL, "https://"))
+	}, 10*time.Second, 10*time.Millisecond)
+	response, err := client.Get(server.URL)
+	require.NoError(t, err)
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	assert.Equal(t, "tunneled", string(body))
+}
+
+func TestGetTlsArgs(t *testing.T) {
+	insecureTls, clientCertPath, clientCertKeyPath := getTlsArgs([]string{"rt", "ping", "--insecure-tls", "--client-cert-path", "cert.pem", "--client-cert-key-path=key.pem"})
+	assert.True(t, insecureTls)
+	assert.Equal(t, "cert.pem", clientCertPath)
+	assert.Equal(t, "key.pem", clientCertKeyPath)
+	insecureTls, clientCertPath, _ = getTlsArgs([]string{"rt", "ping", "--insecure-tls=false", "client-cert-path"})
+	assert.False(t, insecureTls)
+	assert.Empty(t, clientCertPath)
+}
+
+func TestUpstreamTransport(t *testing.T) {
+	base, serverTransport := &http.Transport{}, &http.Transport{}
+	upstream := &upstreamTransport{base: base, hosts: map[string]*http.Transport{"acme.jfrog.io": serverTransport}}
+	for requestUrl, expected := range map[string]*http.Transport{
+		"https://acme.jfrog.io/artifactory":      serverTransport,
+		"https://acme.jfrog.io:8443/artifactory": base,
+		"https://other.jfrog.io/artifactory":     base,
+	} {
+		request, err := http.NewRequest(http.MethodGet, requestUrl, nil)
+		require.NoError(t, err)
+		assert.Same(t, expected, upstream.getTransport(request), requestUrl)
+	}
+}
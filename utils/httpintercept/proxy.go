@@ -0,0 +1,415 @@
+package httpintercept
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The validity of the CA and of the certificates it issues.
+	certificateValidity = 24 * time.Hour
+	handshakeTimeout    = time.Minute
+	dialTimeout         = 30 * time.Second
+)
+
+// A local HTTP proxy, which sends the requests of its clients through the upstream transport, and streams the responses back.
+// HTTPS requests are intercepted by terminating their TLS with certificates issued by an ephemeral CA,
+// which the proxy's clients must trust.
+// The HTTPS requests to hosts whose clients don't trust the CA are tunneled to the hosts without intercepting them.
+type Proxy struct {
+	upstream http.RoundTripper
+	// Returns the proxy which the tunnels to the hosts are opened through, or nil to open them directly.
+	tunnelProxy func(*url.URL) (*url.URL, error)
+	ca          *certificateAuthority
+	listener    net.Listener
+	server      *http.Server
+	serveDone   chan struct{}
+	mutex       sync.Mutex
+	// The hosts whose HTTPS requests aren't intercepted, in the form of 'host:port'.
+	tunneledHosts map[string]bool
+}
+
+func NewProxy(upstream http.RoundTripper, tunnelProxy func(*url.URL) (*url.URL, error)) (*Proxy, error) {
+	ca, err := newCertificateAuthority()
+	if err != nil {
+		return nil, err
+	}
+	return &Proxy{upstream: upstream, tunnelProxy: tunnelProxy, ca: ca, tunneledHosts: map[string]bool{}}, nil
+}
+
+// Starts listening on a random local port.
+func (proxy *Proxy) Start() (err error) {
+	proxy.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	proxy.server = &http.Server{Handler: proxy, ReadHeaderTimeout: time.Minute}
+	proxy.serveDone = make(chan struct{})
+	go func() {
+		defer close(proxy.serveDone)
+		if serveErr := proxy.server.Serve(proxy.listener); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			log.Debug("The local HTTP proxy stopped:", serveErr.Error())
+		}
+	}()
+	return nil
+}
+
+func (proxy *Proxy) Stop() error {
+	if proxy.server == nil {
+		return nil
+	}
+	err := proxy.server.Close()
+	<-proxy.serveDone
+	return errorutils.CheckError(err)
+}
+
+func (proxy *Proxy) GetUrl() string {
+	return "http://" + proxy.listener.Addr().String()
+}
+
+// The PEM encoded certificate of the CA, which issues the certificates of the intercepted HTTPS servers.
+func (proxy *Proxy) GetCaCertificate() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: proxy.ca.certificate.Raw})
+}
+
+func (proxy *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodConnect {
+		proxy.interceptTls(w, r)
+		return
+	}
+	if !r.URL.IsAbs() {
+		http.Error(w, "the local HTTP proxy accepts proxy requests only", http.StatusBadRequest)
+		return
+	}
+	proxy.serveExchange(w, r, r.URL)
+}
+
+// Terminates the TLS of the tunnel requested by the client, and serves the requests sent through it.
+// If the client rejects the certificate issued for the host, the next tunnels to the host are opened without intercepting them.
+func (proxy *Proxy) interceptTls(w http.ResponseWriter, r *http.Request) {
+	address := r.Host
+	// The requests' URLs are the same as the URLs the clients requested, which omit the default port.
+	host := address
+	if hostname, port, err := net.SplitHostPort(address); err == nil && port == "443" {
+		host = hostname
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "webserver doesn't support hijacking", http.StatusInternalServerError)
+		return
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if _, err = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n")); err != nil {
+		log.Debug("Failed establishing a tunnel to", address+":", err.Error())
+		_ = conn.Close()
+		return
+	}
+	if proxy.isTunneled(address) {
+		proxy.tunnel(conn, address)
+		return
+	}
+	tlsConn := tls.Server(conn, &tls.Config{GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		serverName := hello.ServerName
+		if serverName == "" {
+			serverName = r.URL.Hostname()
+		}
+		return proxy.ca.getCertificate(serverName)
+	}})
+	handshakeCtx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+	err = tlsConn.HandshakeContext(handshakeCtx)
+	cancel()
+	if err != nil {
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "remote error" {
+			proxy.setTunneled(address)
+			log.Debug(fmt.Sprintf("An HTTP client doesn't trust the certificate of the local HTTP proxy for %s, so the next requests to it aren't intercepted: %s", address, err.Error()))
+		}
+		_ = conn.Close()
+		return
+	}
+	tunnelServer := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxy.serveExchange(w, r, &url.URL{Scheme: "https", Host: host, Path: r.URL.Path, RawPath: r.URL.RawPath, RawQuery: r.URL.RawQuery})
+		}),
+		ReadHeaderTimeout: time.Minute,
+	}
+	// The connection is served in the background until the client closes it.
+	_ = tunnelServer.Serve(&singleConnListener{conn: tlsConn})
+}
+
+func (proxy *Proxy) isTunneled(address string) bool {
+	proxy.mutex.Lock()
+	defer proxy.mutex.Unlock()
+	return proxy.tunneledHosts[address]
+}
+
+func (proxy *Proxy) setTunneled(address string) {
+	proxy.mutex.Lock()
+	defer proxy.mutex.Unlock()
+	proxy.tunneledHosts[address] = true
+}
+
+// Copies the client's connection to the host and back, until either of them closes it.
+func (proxy *Proxy) tunnel(conn net.Conn, address string) {
+	defer func() {
+		_ = conn.Close()
+	}()
+	hostConn, err := proxy.dialTunnel(address)
+	if err != nil {
+		log.Debug("Failed opening a tunnel to", address+":", err.Error())
+		return
+	}
+	defer func() {
+		_ = hostConn.Close()
+	}()
+	copyDone := make(chan struct{})
+	go func() {
+		defer close(copyDone)
+		_, _ = io.Copy(hostConn, conn)
+		// Unblocks the copy of the host's connection, if the host doesn't close it.
+		_ = hostConn.Close()
+	}()
+	_, _ = io.Copy(conn, hostConn)
+	_ = conn.Close()
+	<-copyDone
+}
+
+// Opens a connection to the host, through the tunnel proxy if there is one.
+func (proxy *Proxy) dialTunnel(address string) (net.Conn, error) {
+	var proxyUrl *url.URL
+	if proxy.tunnelProxy != nil {
+		var err error
+		if proxyUrl, err = proxy.tunnelProxy(&url.URL{Scheme: "https", Host: address}); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	if proxyUrl == nil {
+		conn, err := dialer.Dial("tcp", address)
+		return conn, errorutils.CheckError(err)
+	}
+	proxyAddress := proxyUrl.Host
+	if proxyUrl.Port() == "" {
+		port := "80"
+		if proxyUrl.Scheme == "https" {
+			port = "443"
+		}
+		proxyAddress = net.JoinHostPort(proxyUrl.Hostname(), port)
+	}
+	var conn net.Conn
+	var err error
+	if proxyUrl.Scheme == "https" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", proxyAddress, &tls.Config{ServerName: proxyUrl.Hostname()})
+	} else {
+		conn, err = dialer.Dial("tcp", proxyAddress)
+	}
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	connectRequest := &http.Request{Method: http.MethodConnect, URL: &url.URL{Opaque: address}, Host: address, Header: http.Header{}}
+	if proxyUrl.User != nil {
+		password, _ := proxyUrl.User.Password()
+		connectRequest.SetBasicAuth(proxyUrl.User.Username(), password)
+		connectRequest.Header.Set("Proxy-Authorization", connectRequest.Header.Get("Authorization"))
+		connectRequest.Header.Del("Authorization")
+	}
+	if err = connectRequest.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, errorutils.CheckError(err)
+	}
+	response, err := http.ReadResponse(bufio.NewReader(conn), connectRequest)
+	if err != nil {
+		_ = conn.Close()
+		return nil, errorutils.CheckError(err)
+	}
+	_ = response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, errorutils.CheckErrorf("the proxy %s refused opening a tunnel to %s: %s", proxyUrl.Redacted(), address, response.Status)
+	}
+	return conn, nil
+}
+
+// Sends the request through the upstream transport, and streams the response back to the client. The bodies aren't held in memory.
+func (proxy *Proxy) serveExchange(w http.ResponseWriter, r *http.Request, targetUrl *url.URL) {
+	body := r.Body
+	if r.ContentLength == 0 {
+		body = http.NoBody
+	}
+	outRequest, err := http.NewRequestWithContext(r.Context(), r.Method, targetUrl.String(), body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	copyHeaders(outRequest.Header, r.Header)
+	removeProxyHeaders(outRequest)
+	outRequest.ContentLength = r.ContentLength
+	response, err := proxy.upstream.RoundTrip(outRequest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer func() {
+		if closeErr := response.Body.Close(); closeErr != nil {
+			log.Debug("Failed closing the response body:", closeErr.Error())
+		}
+	}()
+	copyHeaders(w.Header(), response.Header)
+	if response.Uncompressed {
+		// The body was decompressed by the upstream transport, so its encoding and length have changed.
+		w.Header().Del("Content-Encoding")
+		w.Header().Del("Content-Length")
+	}
+	w.WriteHeader(response.StatusCode)
+	if _, err = io.Copy(w, response.Body); err != nil {
+		log.Debug("Failed streaming the response body:", err.Error())
+	}
+}
+
+// Removes the headers which apply to the connection with the proxy, and shouldn't be forwarded.
+func removeProxyHeaders(r *http.Request) {
+	r.RequestURI = "" // this must be reset when serving a request with the client
+	// If no Accept-Encoding header exists, Transport will add the headers it can accept
+	// and would wrap the response body with the relevant reader.
+	r.Header.Del("Accept-Encoding")
+	// curl can add that, see
+	// https://jdebp.eu./FGA/web-proxy-connection-header.html
+	r.Header.Del("Proxy-Connection")
+	r.Header.Del("Proxy-Authenticate")
+	r.Header.Del("Proxy-Authorization")
+	// Connection, Authenticate and Authorization are single hop Header:
+	// http://www.w3.org/Protocols/rfc2616/rfc2616.txt
+	// 14.10 Connection
+	//   The Connection general-header field allows the sender to specify
+	//   options that are desired for that particular connection and MUST NOT
+	//   be communicated by proxies over further connections.
+	r.Header.Del("Connection")
+}
+
+func copyHeaders(dst, src http.Header) {
+	for k := range dst {
+		dst.Del(k)
+	}
+	for k, vs := range src {
+		for _, v := range vs {
+			dst.Add(k, v)
+		}
+	}
+}
+
+// Serves a single connection with an http.Server.
+type singleConnListener struct {
+	conn     net.Conn
+	accepted bool
+}
+
+func (listener *singleConnListener) Accept() (net.Conn, error) {
+	if listener.accepted {
+		return nil, io.EOF
+	}
+	listener.accepted = true
+	return listener.conn, nil
+}
+
+func (listener *singleConnListener) Close() error {
+	return nil
+}
+
+func (listener *singleConnListener) Addr() net.Addr {
+	return listener.conn.LocalAddr()
+}
+
+// An in-memory CA. Its private key never leaves the process, so trusting it doesn't allow others to intercept traffic.
+type certificateAuthority struct {
+	certificate  *x509.Certificate
+	key          *ecdsa.PrivateKey
+	mutex        sync.Mutex
+	certificates map[string]*tls.Certificate
+}
+
+func newCertificateAuthority() (*certificateAuthority, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	template, err := createCertificateTemplate()
+	if err != nil {
+		return nil, err
+	}
+	template.Subject = pkix.Name{Organization: []string{"JFrog CLI"}, CommonName: "JFrog CLI Local HTTP Proxy CA"}
+	template.IsCA = true
+	template.KeyUsage = x509.KeyUsageCertSign
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return &certificateAuthority{certificate: certificate, key: key, certificates: map[string]*tls.Certificate{}}, nil
+}
+
+func createCertificateTemplate() (*x509.Certificate, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return &x509.Certificate{
+		SerialNumber:          serialNumber,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(certificateValidity),
+		BasicConstraintsValid: true,
+	}, nil
+}
+
+// Returns a certificate for the host, issued by the CA.
+func (ca *certificateAuthority) getCertificate(host string) (*tls.Certificate, error) {
+	ca.mutex.Lock()
+	defer ca.mutex.Unlock()
+	if certificate, exists := ca.certificates[host]; exists {
+		return certificate, nil
+	}
+	template, err := createCertificateTemplate()
+	if err != nil {
+		return nil, err
+	}
+	template.Subject = pkix.Name{CommonName: host}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	if ip := net.ParseIP(host); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else {
+		template.DNSNames = []string{host}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.certificate, &ca.key.PublicKey, ca.key)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	certificate := &tls.Certificate{Certificate: [][]byte{der, ca.certificate.Raw}, PrivateKey: ca.key}
+	ca.certificates[host] = certificate
+	return certificate, nil
+}
@@ -0,0 +1,205 @@
+package httprecord
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+const (
+	cassetteVersion = 1
+	// The maximal size of a body recorded in the cassette.
+	maxRecordedBodySize = 10 * 1024 * 1024
+	redacted            = "REDACTED"
+	base64Encoding      = "base64"
+)
+
+var (
+	// Headers which carry credentials.
+	secretHeaders = []string{"Authorization", "Proxy-Authorization", "X-JFrog-Art-Api", "X-Api-Key", "Cookie", "Set-Cookie"}
+	// JSON fields, form fields and query params which carry credentials.
+	secretFieldPattern = regexp.MustCompile(`(?i)^(access_?token|refresh_?token|reference_?token|id_?token|subject_?token|token|password|api_?key|secret|client_?secret)$`)
+)
+
+// The HTTP interactions of a single command, in the order they were sent.
+type Cassette struct {
+	Version      int            `json:"version"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string      `json:"method"`
+	Url    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body
+}
+
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body
+}
+
+// Text bodies are stored as is, to keep the cassette readable. Binary bodies are base64 encoded.
+// Bodies larger than maxRecordedBodySize are truncated.
+type Body struct {
+	Body         string `json:"body,omitempty"`
+	BodyEncoding string `json:"bodyEncoding,omitempty"`
+	Truncated    bool   `json:"bodyTruncated,omitempty"`
+}
+
+func newBody(content []byte) Body {
+	if utf8.Valid(content) {
+		return Body{Body: string(content)}
+	}
+	return Body{Body: base64.StdEncoding.EncodeToString(content), BodyEncoding: base64Encoding}
+}
+
+func (body Body) GetContent() ([]byte, error) {
+	if body.BodyEncoding == base64Encoding {
+		content, err := base64.StdEncoding.DecodeString(body.Body)
+		return content, errorutils.CheckError(err)
+	}
+	return []byte(body.Body), nil
+}
+
+func LoadCassette(path string) (*Cassette, error) {
+	content, err := fileutils.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cassette := &Cassette{}
+	if err = json.Unmarshal(content, cassette); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the HTTP cassette at '%s': %s", path, err.Error())
+	}
+	return cassette, nil
+}
+
+// The cassette is redacted, but may still contain sensitive data, such as the content of files. Therefore, only the owner can read it.
+func (cassette *Cassette) Save(path string) error {
+	content, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(path, content, 0600))
+}
+
+// Creates an interaction from an exchange, scrubbing the credentials.
+func newRedactedInteraction(request *http.Request, requestBody []byte, response *http.Response, responseBody []byte) *Interaction {
+	secrets := getHeadersSecrets(request.Header)
+	return &Interaction{
+		Request: Request{
+			Method: request.Method,
+			Url:    redactUrl(request.URL),
+			Header: redactHeader(request.Header),
+			Body:   newBody(redactBody(requestBody, request.Header.Get("Content-Type"), secrets)),
+		},
+		Response: Response{
+			StatusCode: response.StatusCode,
+			Header:     redactHeader(response.Header),
+			Body:       newBody(redactBody(responseBody, response.Header.Get("Content-Type"), secrets)),
+		},
+	}
+}
+
+func redactHeader(header http.Header) http.Header {
+	redactedHeader := header.Clone()
+	for _, name := range secretHeaders {
+		if redactedHeader.Get(name) != "" {
+			redactedHeader.Set(name, redacted)
+		}
+	}
+	return redactedHeader
+}
+
+// Returns the tokens and passwords sent in the request's headers, to scrub them wherever they're echoed.
+func getHeadersSecrets(header http.Header) (secrets []string) {
+	for _, name := range secretHeaders {
+		value := header.Get(name)
+		if scheme, credentials, found := strings.Cut(value, " "); found && (strings.EqualFold(scheme, "Bearer") || strings.EqualFold(scheme, "Basic")) {
+			value = credentials
+		}
+		if len(value) >= 8 {
+			secrets = append(secrets, value)
+		}
+	}
+	return
+}
+
+func redactUrl(requestUrl *url.URL) string {
+	redactedUrl := *requestUrl
+	redactedUrl.User = nil
+	query := redactedUrl.Query()
+	if redactValues(query) {
+		redactedUrl.RawQuery = query.Encode()
+	}
+	return redactedUrl.String()
+}
+
+func redactValues(values url.Values) (changed bool) {
+	for key := range values {
+		if secretFieldPattern.MatchString(key) {
+			values.Set(key, redacted)
+			changed = true
+		}
+	}
+	return
+}
+
+func redactBody(body []byte, contentType string, secrets []string) []byte {
+	if len(body) == 0 || !utf8.Valid(body) {
+		return body
+	}
+	switch {
+	case strings.Contains(contentType, "application/x-www-form-urlencoded"):
+		if values, err := url.ParseQuery(string(body)); err == nil && redactValues(values) {
+			body = []byte(values.Encode())
+		}
+	case strings.Contains(contentType, "json"):
+		var content interface{}
+		if err := json.Unmarshal(body, &content); err == nil && redactJson(content) {
+			if redactedBody, err := json.Marshal(content); err == nil {
+				body = redactedBody
+			}
+		}
+	}
+	text := string(body)
+	for _, secret := range secrets {
+		text = strings.ReplaceAll(text, secret, redacted)
+	}
+	return []byte(text)
+}
+
+func redactJson(content interface{}) (changed bool) {
+	switch value := content.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if _, isString := field.(string); isString && secretFieldPattern.MatchString(key) {
+				value[key] = redacted
+				changed = true
+			} else if redactJson(field) {
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if redactJson(item) {
+				changed = true
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,48 @@
+package httprecord
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// Records the HTTP interactions of the command to the provided cassette file.
+	RecordEnv = "JFROG_CLI_HTTP_RECORD"
+	// Serves the HTTP requests of the command from the provided cassette file, without contacting the servers.
+	ReplayEnv = "JFROG_CLI_HTTP_REPLAY"
+)
+
+// Returns true if recording or replaying the HTTP interactions was requested by the JFROG_CLI_HTTP_RECORD or JFROG_CLI_HTTP_REPLAY environment variables.
+func IsRequested() bool {
+	return os.Getenv(RecordEnv) != "" || os.Getenv(ReplayEnv) != ""
+}
+
+// Returns a transport which records the interactions of the upstream transport, or replays recorded interactions,
+// as requested by the JFROG_CLI_HTTP_RECORD or JFROG_CLI_HTTP_REPLAY environment variables. Returns the upstream transport if neither is set.
+// save - Saves the recorded cassette once the command ends. Nil if not recording.
+func NewTransport(upstream http.RoundTripper) (transport http.RoundTripper, save func() error, err error) {
+	recordPath, replayPath := os.Getenv(RecordEnv), os.Getenv(ReplayEnv)
+	switch {
+	case recordPath != "" && replayPath != "":
+		return nil, nil, errorutils.CheckErrorf("the %s and %s environment variables can't be used together", RecordEnv, ReplayEnv)
+	case recordPath != "":
+		log.Debug(fmt.Sprintf("Recording the HTTP interactions to '%s'.", recordPath))
+		recorder := NewRecorder(upstream)
+		return recorder, func() error {
+			return recorder.GetCassette().Save(recordPath)
+		}, nil
+	case replayPath != "":
+		cassette, err := LoadCassette(replayPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		log.Debug(fmt.Sprintf("Replaying the HTTP interactions recorded in '%s'.", replayPath))
+		return NewReplayer(cassette), nil, nil
+	default:
+		return upstream, nil, nil
+	}
+}
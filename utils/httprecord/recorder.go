@@ -0,0 +1,171 @@
+package httprecord
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Records the interactions of the upstream transport. The bodies are streamed, and aren't held in memory.
+// Only their first maxRecordedBodySize bytes are recorded in the cassette.
+type Recorder struct {
+	upstream http.RoundTripper
+	mutex    sync.Mutex
+	cassette *Cassette
+}
+
+func NewRecorder(upstream http.RoundTripper) *Recorder {
+	return &Recorder{upstream: upstream, cassette: &Cassette{Version: cassetteVersion}}
+}
+
+func (recorder *Recorder) GetCassette() *Cassette {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return recorder.cassette
+}
+
+// The interaction is recorded once the response body is closed.
+func (recorder *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestBody, err := newBodyRecorder()
+	if err != nil {
+		return nil, err
+	}
+	if request.Body != nil && request.Body != http.NoBody {
+		request = request.Clone(request.Context())
+		request.Body = &teeReadCloser{Reader: io.TeeReader(request.Body, requestBody), Closer: request.Body}
+	}
+	response, err := recorder.upstream.RoundTrip(request)
+	if err != nil {
+		requestBody.close()
+		return nil, err
+	}
+	responseBody, err := newBodyRecorder()
+	if err != nil {
+		requestBody.close()
+		return nil, errors.Join(err, errorutils.CheckError(response.Body.Close()))
+	}
+	response.Body = &recordedBody{Reader: io.TeeReader(response.Body, responseBody), body: response.Body, onClose: func(readErr error) {
+		defer requestBody.close()
+		defer responseBody.close()
+		if readErr != nil {
+			return
+		}
+		interaction, err := newRecordedInteraction(request, requestBody, response, responseBody)
+		if err != nil {
+			log.Warn(fmt.Sprintf("Failed recording the HTTP interaction %s %s: %s", request.Method, redactUrl(request.URL), err.Error()))
+			return
+		}
+		recorder.mutex.Lock()
+		recorder.cassette.Interactions = append(recorder.cassette.Interactions, interaction)
+		recorder.mutex.Unlock()
+	}}
+	return response, nil
+}
+
+func newRecordedInteraction(request *http.Request, requestBody *bodyRecorder, response *http.Response, responseBody *bodyRecorder) (*Interaction, error) {
+	requestContent, err := requestBody.getContent()
+	if err != nil {
+		return nil, err
+	}
+	responseContent, err := responseBody.getContent()
+	if err != nil {
+		return nil, err
+	}
+	interaction := newRedactedInteraction(request, requestContent, response, responseContent)
+	interaction.Request.Truncated, interaction.Response.Truncated = requestBody.isTruncated(), responseBody.isTruncated()
+	if interaction.Request.Truncated || interaction.Response.Truncated {
+		log.Warn(fmt.Sprintf("The body of the HTTP interaction %s %s exceeds %d bytes, so only its beginning is recorded.",
+			interaction.Request.Method, interaction.Request.Url, maxRecordedBodySize))
+	}
+	return interaction, nil
+}
+
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// A response body, which calls onClose once when it's closed, with the error which failed reading it, if any.
+type recordedBody struct {
+	io.Reader
+	body    io.Closer
+	readErr error
+	closed  bool
+	onClose func(readErr error)
+}
+
+func (body *recordedBody) Read(p []byte) (int, error) {
+	n, err := body.Reader.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		body.readErr = err
+	}
+	return n, err
+}
+
+func (body *recordedBody) Close() error {
+	err := body.body.Close()
+	if !body.closed {
+		body.closed = true
+		body.onClose(body.readErr)
+	}
+	return err
+}
+
+// Spools the beginning of a streamed body to a temporary file, up to maxRecordedBodySize bytes.
+// Writes never fail, so that failing to record the body doesn't fail the exchange.
+type bodyRecorder struct {
+	file     *os.File
+	size     int64
+	recorded int64
+	err      error
+}
+
+func newBodyRecorder() (*bodyRecorder, error) {
+	file, err := fileutils.CreateTempFile()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return &bodyRecorder{file: file}, nil
+}
+
+func (recorder *bodyRecorder) Write(p []byte) (int, error) {
+	recorder.size += int64(len(p))
+	if remaining := maxRecordedBodySize - recorder.recorded; recorder.err == nil && remaining > 0 {
+		toRecord := p[:min(int64(len(p)), remaining)]
+		var written int
+		written, recorder.err = recorder.file.Write(toRecord)
+		recorder.recorded += int64(written)
+	}
+	return len(p), nil
+}
+
+func (recorder *bodyRecorder) isTruncated() bool {
+	return recorder.size > recorder.recorded
+}
+
+// Returns the recorded beginning of the body.
+func (recorder *bodyRecorder) getContent() ([]byte, error) {
+	if recorder.err != nil {
+		return nil, errorutils.CheckError(recorder.err)
+	}
+	content := make([]byte, recorder.recorded)
+	_, err := recorder.file.ReadAt(content, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, errorutils.CheckError(err)
+	}
+	return content, nil
+}
+
+func (recorder *bodyRecorder) close() {
+	err := errors.Join(recorder.file.Close(), os.Remove(recorder.file.Name()))
+	if err != nil {
+		log.Debug("Failed removing the temporary file of a recorded body:", err.Error())
+	}
+}
@@ -0,0 +1,80 @@
+package httprecord
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Serves the requests from the interactions recorded in a cassette, without contacting the servers.
+type Replayer struct {
+	cassette *Cassette
+	mutex    sync.Mutex
+	// Interactions which were already replayed.
+	replayed map[*Interaction]bool
+}
+
+func NewReplayer(cassette *Cassette) *Replayer {
+	return &Replayer{cassette: cassette, replayed: map[*Interaction]bool{}}
+}
+
+// Serves the first recorded interaction of the request, which wasn't replayed yet.
+// If all the request's interactions were replayed, the last one is served again, as clients may poll the same URL.
+func (replayer *Replayer) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Body != nil {
+		// The request body isn't sent anywhere, but the client may wait for it to be read.
+		_, _ = io.Copy(io.Discard, request.Body)
+		_ = request.Body.Close()
+	}
+	requestUrl := redactUrl(request.URL)
+	replayer.mutex.Lock()
+	var match *Interaction
+	for _, interaction := range replayer.cassette.Interactions {
+		if interaction.Request.Method != request.Method || interaction.Request.Url != requestUrl {
+			continue
+		}
+		match = interaction
+		if !replayer.replayed[interaction] {
+			break
+		}
+	}
+	if match != nil {
+		replayer.replayed[match] = true
+	}
+	replayer.mutex.Unlock()
+	if match == nil {
+		log.Warn(fmt.Sprintf("No recorded HTTP interaction matches the request: %s %s", request.Method, requestUrl))
+		return nil, errorutils.CheckErrorf("no recorded HTTP interaction matches the request: %s %s", request.Method, requestUrl)
+	}
+	if match.Response.Truncated {
+		log.Warn(fmt.Sprintf("The recorded response body of %s %s was truncated, so only its beginning is replayed.", request.Method, requestUrl))
+	}
+	body, err := match.Response.GetContent()
+	if err != nil {
+		return nil, err
+	}
+	header := match.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	// The body may have been decompressed by the upstream transport, so its length may have changed.
+	header.Del("Content-Encoding")
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", match.Response.StatusCode, http.StatusText(match.Response.StatusCode)),
+		StatusCode:    match.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}, nil
+}
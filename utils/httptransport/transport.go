@@ -0,0 +1,84 @@
+package httptransport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// The transport of the HTTP clients created by JFrog CLI itself, such as the clients of the plugins commands.
+// The clients created by jfrog-cli-core create their own transports, so their requests aren't sent through it.
+// The transport is shared, so that all commands run by the process reuse its connections.
+var (
+	transport = roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		return getBaseTransport().RoundTrip(request)
+	})
+	baseTransport = newBaseTransport()
+	mutex         sync.RWMutex
+	// TLS sessions are resumed when new connections are opened to the same servers.
+	tlsSessionCache = tls.NewLRUClientSessionCache(0)
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (roundTrip roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return roundTrip(request)
+}
+
+func newBaseTransport() *http.Transport {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.Proxy = proxyFromCurrentEnvironment
+	base.TLSClientConfig = &tls.Config{ClientSessionCache: tlsSessionCache, MinVersion: tls.VersionTLS12}
+	return base
+}
+
+// Unlike http.ProxyFromEnvironment, which reads the proxy environment variables once per process, they're read for each request,
+// since the commands run by the daemon use the environment variables of their clients.
+func proxyFromCurrentEnvironment(request *http.Request) (*url.URL, error) {
+	return httpproxy.FromEnvironment().ProxyFunc()(request.URL)
+}
+
+func getBaseTransport() *http.Transport {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return baseTransport
+}
+
+// Returns a new HTTP client, which sends its requests through the shared transport.
+// Usage example: httpclient.ClientBuilder().SetHttpClient(httptransport.NewClient()).Build()
+func NewClient() *http.Client {
+	return &http.Client{Transport: transport}
+}
+
+// Sends all requests of the shared transport through the proxy, including requests to loopback addresses.
+// The certificates issued by the provided CA are trusted, in addition to the system's CAs.
+// Other HTTP clients and the processes started by the CLI aren't affected.
+// Returns a function which stops sending the requests through the proxy.
+func RouteThroughProxy(proxyUrl *url.URL, caCertificate []byte) (reset func(), err error) {
+	rootCas, err := x509.SystemCertPool()
+	if err != nil {
+		rootCas = x509.NewCertPool()
+	}
+	if !rootCas.AppendCertsFromPEM(caCertificate) {
+		return nil, errorutils.CheckErrorf("failed parsing the CA certificate of the proxy")
+	}
+	proxied := newBaseTransport()
+	proxied.Proxy = http.ProxyURL(proxyUrl)
+	proxied.TLSClientConfig.RootCAs = rootCas
+	previous := setBaseTransport(proxied)
+	return func() {
+		setBaseTransport(previous).CloseIdleConnections()
+	}, nil
+}
+
+func setBaseTransport(base *http.Transport) (previous *http.Transport) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	previous, baseTransport = baseTransport, base
+	return
+}
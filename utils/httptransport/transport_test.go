@@ -0,0 +1,26 @@
+package httptransport
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyFromCurrentEnvironment(t *testing.T) {
+	request := &http.Request{URL: &url.URL{Scheme: "https", Host: "acme.jfrog.io"}}
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("https_proxy", "")
+	t.Setenv("NO_PROXY", "")
+	t.Setenv("no_proxy", "")
+	proxyUrl, err := proxyFromCurrentEnvironment(request)
+	require.NoError(t, err)
+	assert.Nil(t, proxyUrl)
+
+	t.Setenv("HTTPS_PROXY", "http://proxy:8080")
+	proxyUrl, err = proxyFromCurrentEnvironment(request)
+	require.NoError(t, err)
+	assert.Equal(t, "http://proxy:8080", proxyUrl.String())
+}
@@ -0,0 +1,77 @@
+package jsonlog
+
+import (
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+const serverIdFlag = "server-id"
+
+// Returns the JSON logger, or nil if JSON logging isn't enabled.
+// Loggers which decorate other loggers are expected to implement Unwrap.
+func GetLogger() *Logger {
+	logger := log.GetLogger()
+	for {
+		switch current := logger.(type) {
+		case *Logger:
+			return current
+		case interface{ Unwrap() log.Log }:
+			logger = current.Unwrap()
+		default:
+			return nil
+		}
+	}
+}
+
+// Returns the full name of the command executed with the provided arguments, such as 'rt upload'.
+// Aliases are replaced by the commands' names.
+func GetCommandPath(commands []cli.Command, args []string) string {
+	var path []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		command := findCommand(commands, arg)
+		if command == nil {
+			break
+		}
+		path = append(path, command.Name)
+		commands = command.Subcommands
+	}
+	return strings.Join(path, " ")
+}
+
+func findCommand(commands []cli.Command, name string) *cli.Command {
+	for i := range commands {
+		if commands[i].HasName(name) {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+// Returns the server ID used by the command, by the same precedence as the commands use:
+// the --server-id option, the JFROG_CLI_SERVER_ID environment variable and the default server.
+func GetServerId(args []string) string {
+	for i, arg := range args {
+		if value, found := strings.CutPrefix(arg, "--"+serverIdFlag+"="); found {
+			return value
+		}
+		if arg == "--"+serverIdFlag && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	if serverId := os.Getenv(coreutils.ServerID); serverId != "" {
+		return serverId
+	}
+	defaultServer, err := config.GetDefaultServerConf()
+	if err != nil || defaultServer == nil {
+		return ""
+	}
+	return defaultServer.ServerId
+}
@@ -0,0 +1,138 @@
+package jsonlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// Set JFROG_CLI_LOG_FORMAT to 'json' to log each message as a single line JSON object.
+	LogFormatEnv = "JFROG_CLI_LOG_FORMAT"
+	JsonFormat   = "json"
+	TextFormat   = "text"
+)
+
+// Messages colored by the log.Format functions contain ANSI escape sequences, which are meaningless in JSON.
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// A single log line.
+type Entry struct {
+	Timestamp string `json:"timestamp"`
+	Level     string `json:"level"`
+	Command   string `json:"command,omitempty"`
+	ServerId  string `json:"serverId,omitempty"`
+	TraceId   string `json:"traceId,omitempty"`
+	Message   string `json:"message"`
+	// HTTP events fields.
+	Event     string  `json:"event,omitempty"`
+	Method    string  `json:"method,omitempty"`
+	Url       string  `json:"url,omitempty"`
+	Status    int     `json:"status,omitempty"`
+	LatencyMs float64 `json:"latencyMs,omitempty"`
+	// The number of failed attempts which preceded the request, when the HTTP client retries it.
+	Retries int `json:"retries,omitempty"`
+}
+
+// Logs a JSON object per line to the logs writer, correlated with the command and the trace ID sent in the 'uber-trace-id' header.
+// The command's output isn't a log message, so it's written to the output writer as is.
+type Logger struct {
+	level        log.LevelType
+	logsWriter   io.Writer
+	outputWriter io.Writer
+	mutex        sync.Mutex
+	command      string
+	serverId     string
+	traceId      string
+}
+
+// Creates a logger which writes logs to the Stderr and the command's output to the Stdout.
+func NewLogger(level log.LevelType) *Logger {
+	return &Logger{level: level, logsWriter: os.Stderr, outputWriter: os.Stdout}
+}
+
+func (logger *Logger) SetLogsWriter(writer io.Writer) *Logger {
+	logger.logsWriter = writer
+	return logger
+}
+
+func (logger *Logger) SetOutputWriter(writer io.Writer) *Logger {
+	logger.outputWriter = writer
+	return logger
+}
+
+// Sets the fields which are added to every log line. The command is its full name, such as 'rt upload'.
+func (logger *Logger) SetContext(command, serverId string) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.command = command
+	logger.serverId = serverId
+}
+
+func (logger *Logger) SetTraceId(traceId string) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	logger.traceId = traceId
+}
+
+func (logger *Logger) GetLogLevel() log.LevelType {
+	return logger.level
+}
+
+func (logger *Logger) Debug(a ...interface{}) {
+	if logger.level >= log.DEBUG {
+		logger.log("debug", a...)
+	}
+}
+
+func (logger *Logger) Info(a ...interface{}) {
+	if logger.level >= log.INFO {
+		logger.log("info", a...)
+	}
+}
+
+func (logger *Logger) Warn(a ...interface{}) {
+	if logger.level >= log.WARN {
+		logger.log("warn", a...)
+	}
+}
+
+func (logger *Logger) Error(a ...interface{}) {
+	logger.log("error", a...)
+}
+
+func (logger *Logger) Output(a ...interface{}) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	_, _ = fmt.Fprintln(logger.outputWriter, a...)
+}
+
+// Formats the values as the default logger does, without colors.
+func FormatMessage(a ...interface{}) string {
+	return ansiEscapePattern.ReplaceAllString(strings.TrimSuffix(fmt.Sprintln(a...), "\n"), "")
+}
+
+func (logger *Logger) log(level string, a ...interface{}) {
+	logger.write(Entry{Level: level, Message: FormatMessage(a...)})
+}
+
+// Adds the timestamp and the context fields to the entry, and writes it as a single line.
+func (logger *Logger) write(entry Entry) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	entry.Timestamp = time.Now().UTC().Format(time.RFC3339Nano)
+	entry.Command, entry.ServerId, entry.TraceId = logger.command, logger.serverId, logger.traceId
+	content, err := json.Marshal(entry)
+	if err != nil {
+		// Entry contains strings and numbers only, so it can always be marshaled.
+		return
+	}
+	_, _ = fmt.Fprintln(logger.logsWriter, string(content))
+}
@@ -0,0 +1,118 @@
+package jsonlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func readEntries(t *testing.T, buffer *bytes.Buffer) (entries []Entry) {
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry Entry
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		entries = append(entries, entry)
+	}
+	return
+}
+
+func TestLogger(t *testing.T) {
+	logsBuffer, outputBuffer := &bytes.Buffer{}, &bytes.Buffer{}
+	logger := NewLogger(log.INFO).SetLogsWriter(logsBuffer).SetOutputWriter(outputBuffer)
+	logger.SetContext("rt upload", "my-server")
+	logger.SetTraceId("abcdef0123456789")
+
+	logger.Debug("filtered out")
+	logger.Info("Uploading", "\x1b[36mfile.txt\x1b[0m")
+	logger.Error("multi\nline")
+	logger.Output("{\"status\": \"success\"}")
+
+	assert.Equal(t, "{\"status\": \"success\"}\n", outputBuffer.String())
+	entries := readEntries(t, logsBuffer)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "info", entries[0].Level)
+	assert.Equal(t, "Uploading file.txt", entries[0].Message)
+	assert.Equal(t, "rt upload", entries[0].Command)
+	assert.Equal(t, "my-server", entries[0].ServerId)
+	assert.Equal(t, "abcdef0123456789", entries[0].TraceId)
+	assert.NotEmpty(t, entries[0].Timestamp)
+	assert.Equal(t, "error", entries[1].Level)
+	assert.Equal(t, "multi\nline", entries[1].Message)
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	logsBuffer := &bytes.Buffer{}
+	previousLogger := log.GetLogger()
+	log.SetLogger(NewLogger(log.DEBUG).SetLogsWriter(logsBuffer))
+	defer log.SetLogger(previousLogger)
+
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+	url := server.URL + "/artifactory/api/system/ping"
+	// The second request is a retry of the failed first request.
+	for i := 0; i < 2; i++ {
+		response, err := client.Get(url)
+		require.NoError(t, err)
+		assert.NoError(t, response.Body.Close())
+	}
+	_, err := client.Get("http://127.0.0.1:0")
+	assert.Error(t, err)
+
+	entries := readEntries(t, logsBuffer)
+	require.Len(t, entries, 6)
+	assert.Equal(t, Entry{Event: HttpRequestEvent, Method: http.MethodGet, Url: url}, Entry{Event: entries[0].Event, Method: entries[0].Method, Url: entries[0].Url})
+	assert.Equal(t, Entry{Event: HttpResponseEvent, Method: http.MethodGet, Url: url, Status: http.StatusServiceUnavailable},
+		Entry{Event: entries[1].Event, Method: entries[1].Method, Url: entries[1].Url, Status: entries[1].Status})
+	assert.Positive(t, entries[1].LatencyMs)
+	assert.Zero(t, entries[1].Retries)
+	assert.Equal(t, 1, entries[2].Retries)
+	assert.Equal(t, 1, entries[3].Retries)
+	assert.Equal(t, HttpErrorEvent, entries[5].Event)
+	assert.Equal(t, "http://127.0.0.1:0", entries[5].Url)
+	assert.Zero(t, entries[5].Retries)
+}
+
+func TestTransportWithoutJsonLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	previousLogger := log.GetLogger()
+	log.SetLogger(log.NewLogger(log.DEBUG, nil))
+	defer log.SetLogger(previousLogger)
+
+	response, err := (&http.Client{Transport: NewTransport(http.DefaultTransport)}).Get(server.URL)
+	require.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func TestGetCommandPath(t *testing.T) {
+	commands := []cli.Command{
+		{Name: "rt", Subcommands: []cli.Command{{Name: "upload", Aliases: []string{"u"}}}},
+		{Name: "login"},
+	}
+	assert.Equal(t, "rt upload", GetCommandPath(commands, []string{"rt", "u", "a", "b"}))
+	assert.Equal(t, "rt", GetCommandPath(commands, []string{"rt", "--help"}))
+	assert.Equal(t, "login", GetCommandPath(commands, []string{"login"}))
+	assert.Empty(t, GetCommandPath(commands, []string{"unknown"}))
+}
+
+func TestGetServerId(t *testing.T) {
+	t.Setenv(coreutils.ServerID, "env-server")
+	assert.Equal(t, "flag-server", GetServerId([]string{"rt", "ping", "--server-id=flag-server"}))
+	assert.Equal(t, "flag-server", GetServerId([]string{"rt", "ping", "--server-id", "flag-server"}))
+	assert.Equal(t, "env-server", GetServerId([]string{"rt", "ping"}))
+}
@@ -0,0 +1,74 @@
+package jsonlog
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	HttpRequestEvent  = "http.request"
+	HttpResponseEvent = "http.response"
+	HttpErrorEvent    = "http.error"
+)
+
+// Logs an HTTP event for each request and its response, with the method, the URL, the status, the latency and the retries as separate fields.
+// The events are logged at the DEBUG log level, and only when JSON logging is enabled.
+type loggingTransport struct {
+	base http.RoundTripper
+	// The number of consecutive failed attempts of each method and URL. The HTTP clients retry failed requests by sending them again,
+	// so a request which follows failed attempts of the same method and URL is a retry.
+	failures map[string]int
+	mutex    sync.Mutex
+}
+
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	return &loggingTransport{base: base, failures: map[string]int{}}
+}
+
+func (transport *loggingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	logger := GetLogger()
+	if logger == nil || logger.GetLogLevel() < log.DEBUG {
+		return transport.base.RoundTrip(request)
+	}
+	// Credentials embedded in the URL aren't logged.
+	requestUrl := request.URL.Redacted()
+	attempt := request.Method + " " + requestUrl
+	retries := transport.getFailures(attempt)
+	logger.write(Entry{Level: "debug", Message: fmt.Sprintf("Sending HTTP %s request to: %s", request.Method, requestUrl),
+		Event: HttpRequestEvent, Method: request.Method, Url: requestUrl, Retries: retries})
+	start := time.Now()
+	response, err := transport.base.RoundTrip(request)
+	// The latency is the time until the response headers were received.
+	latencyMs := float64(time.Since(start).Microseconds()) / 1000
+	if err != nil {
+		transport.setFailed(attempt, true)
+		logger.write(Entry{Level: "debug", Message: fmt.Sprintf("HTTP %s request to %s failed: %s", request.Method, requestUrl, err.Error()),
+			Event: HttpErrorEvent, Method: request.Method, Url: requestUrl, LatencyMs: latencyMs, Retries: retries})
+		return response, err
+	}
+	// These are the responses which the HTTP clients retry.
+	transport.setFailed(attempt, response.StatusCode >= http.StatusInternalServerError || response.StatusCode == http.StatusTooManyRequests)
+	logger.write(Entry{Level: "debug", Message: fmt.Sprintf("HTTP %s request to %s response: %s", request.Method, requestUrl, response.Status),
+		Event: HttpResponseEvent, Method: request.Method, Url: requestUrl, Status: response.StatusCode, LatencyMs: latencyMs, Retries: retries})
+	return response, nil
+}
+
+func (transport *loggingTransport) getFailures(attempt string) int {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	return transport.failures[attempt]
+}
+
+func (transport *loggingTransport) setFailed(attempt string, failed bool) {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	if failed {
+		transport.failures[attempt]++
+	} else {
+		delete(transport.failures, attempt)
+	}
+}
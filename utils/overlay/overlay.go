@@ -0,0 +1,275 @@
+package overlay
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v2"
+)
+
+// The overlay is discovered by walking up from the working directory, so a monorepo can keep a single overlay at its root,
+// or a different overlay per sub-project.
+const (
+	OverlayDirName  = ".jfrog"
+	OverlayFileName = "cli.yaml"
+)
+
+// Per-project defaults, which take precedence over the global config, but not over command options and environment variables.
+// Example:
+//
+//	serverId: my-server
+//	project: my-project
+//	buildName: my-build
+//	commands:
+//	  rt upload:
+//	    threads: 8
+//	    flat: true
+type Overlay struct {
+	ServerId  string `yaml:"serverId,omitempty"`
+	Project   string `yaml:"project,omitempty"`
+	BuildName string `yaml:"buildName,omitempty"`
+	// Default command options, keyed by the full command name, such as 'rt upload'.
+	Commands map[string]map[string]interface{} `yaml:"commands,omitempty"`
+	path     string
+}
+
+// The path of the overlay file.
+func (o *Overlay) GetPath() string {
+	return o.path
+}
+
+// Returns the overlay of the provided directory, which is the closest overlay found in the directory or in one of its parents.
+// Returns nil if no overlay was found.
+func Find(dir string) (*Overlay, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for {
+		overlayPath := filepath.Join(dir, OverlayDirName, OverlayFileName)
+		exists, err := fileutils.IsFileExists(overlayPath, false)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return read(overlayPath)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+func read(overlayPath string) (*Overlay, error) {
+	content, err := fileutils.ReadFile(overlayPath)
+	if err != nil {
+		return nil, err
+	}
+	overlay := &Overlay{path: overlayPath}
+	if err = yaml.Unmarshal(content, overlay); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the CLI overlay at '%s': %s", overlayPath, err.Error())
+	}
+	log.Debug("Using the CLI overlay at:", overlayPath)
+	return overlay, nil
+}
+
+var (
+	loadOnce      sync.Once
+	loadedOverlay *Overlay
+	errLoad       error
+)
+
+// Returns the overlay of the working directory, or nil if no overlay was found. The overlay is read once per process.
+func Load() (*Overlay, error) {
+	loadOnce.Do(func() {
+		var wd string
+		if wd, errLoad = os.Getwd(); errLoad != nil {
+			errLoad = errorutils.CheckError(errLoad)
+			return
+		}
+		loadedOverlay, errLoad = Find(wd)
+	})
+	return loadedOverlay, errLoad
+}
+
+// Forgets the loaded overlay, so it's discovered again by the next Load.
+// Used by processes which run commands from different working directories.
+func Reset() {
+	loadOnce = sync.Once{}
+	loadedOverlay, errLoad = nil, nil
+	appliedEnv = map[string]bool{}
+}
+
+// The environment variables which are set from the overlay, so that both JFrog CLI and its embedded plugins use the overlay's values.
+func (o *Overlay) getEnvValues() map[string]string {
+	return map[string]string{
+		coreutils.ServerID:  o.ServerId,
+		coreutils.Project:   o.Project,
+		coreutils.BuildName: o.BuildName,
+	}
+}
+
+// Environment variables which were set by ApplyEnv, rather than by the user.
+var appliedEnv = map[string]bool{}
+
+// Sets the overlay's values as environment variables, unless already set by the user.
+func ApplyEnv(overlay *Overlay) error {
+	if overlay == nil {
+		return nil
+	}
+	for key, value := range overlay.getEnvValues() {
+		if value == "" || os.Getenv(key) != "" {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return errorutils.CheckError(err)
+		}
+		appliedEnv[key] = true
+	}
+	return nil
+}
+
+// Describes an effective setting and where its value came from.
+type Setting struct {
+	Name   string `json:"name" yaml:"name" col-name:"Setting"`
+	Value  string `json:"value" yaml:"value" col-name:"Value"`
+	Source string `json:"source" yaml:"source" col-name:"Source"`
+}
+
+// Returns the settings which may be provided by the overlay, and their sources.
+// The global default server is provided by the caller, as it's read from the CLI config.
+func GetEffectiveSettings(overlay *Overlay, globalDefaultServerId string) []Setting {
+	settings := []Setting{
+		getEffectiveSetting("Server ID", coreutils.ServerID, overlay),
+		getEffectiveSetting("Project", coreutils.Project, overlay),
+		getEffectiveSetting("Build name", coreutils.BuildName, overlay),
+	}
+	if settings[0].Value == "" && globalDefaultServerId != "" {
+		settings[0].Value = globalDefaultServerId
+		settings[0].Source = "Default server ('jf c use')"
+	}
+	if overlay == nil {
+		return settings
+	}
+	var commandNames []string
+	for commandName := range overlay.Commands {
+		commandNames = append(commandNames, commandName)
+	}
+	sort.Strings(commandNames)
+	for _, commandName := range commandNames {
+		for _, flagName := range getSortedFlagNames(overlay.Commands[commandName]) {
+			// Options which can't be set by the overlay are ignored.
+			if !slices.Contains(overlayAllowedFlags, flagName) {
+				continue
+			}
+			settings = append(settings, Setting{
+				Name:   fmt.Sprintf("'jf %s' --%s", commandName, flagName),
+				Value:  fmt.Sprint(overlay.Commands[commandName][flagName]),
+				Source: overlay.path,
+			})
+		}
+	}
+	return settings
+}
+
+func getEffectiveSetting(name, envKey string, overlay *Overlay) Setting {
+	value := os.Getenv(envKey)
+	switch {
+	case value == "":
+		return Setting{Name: name, Source: "Not set"}
+	case appliedEnv[envKey] && overlay != nil:
+		return Setting{Name: name, Value: value, Source: overlay.path}
+	default:
+		return Setting{Name: name, Value: value, Source: envKey + " environment variable"}
+	}
+}
+
+func getSortedFlagNames(flags map[string]interface{}) []string {
+	var flagNames []string
+	for flagName := range flags {
+		flagNames = append(flagNames, flagName)
+	}
+	sort.Strings(flagNames)
+	return flagNames
+}
+
+// Wraps the actions of the commands and their subcommands, to apply the overlay's default options to options which weren't set explicitly.
+func WrapCommandsWithDefaults(commands []cli.Command) []cli.Command {
+	return wrapCommands(commands, "")
+}
+
+func wrapCommands(commands []cli.Command, parentName string) []cli.Command {
+	for i := range commands {
+		commandName := strings.TrimSpace(parentName + " " + commands[i].Name)
+		if len(commands[i].Subcommands) > 0 {
+			commands[i].Subcommands = wrapCommands(commands[i].Subcommands, commandName)
+		}
+		// Options of commands which skip flags parsing are parsed by the commands themselves, so they can't be set.
+		if commands[i].SkipFlagParsing {
+			continue
+		}
+		action, ok := commands[i].Action.(func(*cli.Context) error)
+		if !ok {
+			if actionFunc, isActionFunc := commands[i].Action.(cli.ActionFunc); isActionFunc {
+				action, ok = actionFunc, true
+			}
+		}
+		if !ok {
+			continue
+		}
+		commands[i].Action = func(c *cli.Context) error {
+			overlay, err := Load()
+			if err != nil {
+				return err
+			}
+			if err = applyCommandDefaults(c, overlay, commandName); err != nil {
+				return err
+			}
+			return action(c)
+		}
+	}
+	return commands
+}
+
+// The overlay is read from the repository being worked on, which may not be trusted.
+// So it can only set options which tune how a command runs, and never options which affect the connection's security,
+// select the server the credentials are sent to, or skip confirmations.
+var overlayAllowedFlags = []string{
+	"threads", "flat", "recursive", "explode", "include-dirs", "exclusions", "detailed-summary", "format", "fail-no-op",
+	"retries", "retry-wait-time", "split-count", "min-split", "build-name", "build-number", "module", "project",
+}
+
+func applyCommandDefaults(c *cli.Context, overlay *Overlay, commandName string) error {
+	if overlay == nil {
+		return nil
+	}
+	defaults := overlay.Commands[commandName]
+	var errs []error
+	for _, flagName := range getSortedFlagNames(defaults) {
+		if !slices.Contains(overlayAllowedFlags, flagName) {
+			log.Warn(fmt.Sprintf("Ignoring the default option '%s' for 'jf %s' in %s. Only the following options can be set by the CLI overlay: %s",
+				flagName, commandName, overlay.path, strings.Join(overlayAllowedFlags, ", ")))
+			continue
+		}
+		if c.IsSet(flagName) {
+			continue
+		}
+		if err := c.Set(flagName, fmt.Sprint(defaults[flagName])); err != nil {
+			errs = append(errs, fmt.Errorf("invalid default option '%s' for 'jf %s' in %s: %s", flagName, commandName, overlay.path, err.Error()))
+		}
+	}
+	return errorutils.CheckError(errors.Join(errs...))
+}
@@ -0,0 +1,167 @@
+package overlay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+const testOverlay = `serverId: overlay-server
+project: overlay-project
+buildName: overlay-build
+commands:
+  rt upload:
+    threads: 8
+    flat: true
+`
+
+func createTestOverlay(t *testing.T) (rootDir string) {
+	rootDir = t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(rootDir, OverlayDirName), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, OverlayDirName, OverlayFileName), []byte(testOverlay), 0644))
+	return
+}
+
+func TestFind(t *testing.T) {
+	rootDir := createTestOverlay(t)
+	nestedDir := filepath.Join(rootDir, "a", "b")
+	require.NoError(t, os.MkdirAll(nestedDir, 0755))
+
+	overlay, err := Find(nestedDir)
+	require.NoError(t, err)
+	require.NotNil(t, overlay)
+	assert.Equal(t, filepath.Join(rootDir, OverlayDirName, OverlayFileName), overlay.GetPath())
+	assert.Equal(t, "overlay-server", overlay.ServerId)
+	assert.Equal(t, "overlay-project", overlay.Project)
+	assert.Equal(t, "overlay-build", overlay.BuildName)
+	assert.Equal(t, 8, overlay.Commands["rt upload"]["threads"])
+
+	overlay, err = Find(t.TempDir())
+	assert.NoError(t, err)
+	assert.Nil(t, overlay)
+}
+
+func TestFindInvalidOverlay(t *testing.T) {
+	rootDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(rootDir, OverlayDirName), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, OverlayDirName, OverlayFileName), []byte("commands: [a"), 0644))
+	_, err := Find(rootDir)
+	assert.ErrorContains(t, err, "failed parsing the CLI overlay")
+}
+
+func TestApplyEnv(t *testing.T) {
+	overlay, err := Find(createTestOverlay(t))
+	require.NoError(t, err)
+	// The environment variables are set and restored by t.Setenv, also when ApplyEnv sets them.
+	t.Setenv(coreutils.ServerID, "")
+	t.Setenv(coreutils.Project, "env-project")
+	t.Setenv(coreutils.BuildName, "")
+	defer func() { appliedEnv = map[string]bool{} }()
+
+	require.NoError(t, ApplyEnv(overlay))
+	assert.Equal(t, "overlay-server", os.Getenv(coreutils.ServerID))
+	assert.Equal(t, "env-project", os.Getenv(coreutils.Project))
+	assert.Equal(t, "overlay-build", os.Getenv(coreutils.BuildName))
+
+	settings := GetEffectiveSettings(overlay, "default-server")
+	assert.Equal(t, []Setting{
+		{Name: "Server ID", Value: "overlay-server", Source: overlay.GetPath()},
+		{Name: "Project", Value: "env-project", Source: coreutils.Project + " environment variable"},
+		{Name: "Build name", Value: "overlay-build", Source: overlay.GetPath()},
+		{Name: "'jf rt upload' --flat", Value: "true", Source: overlay.GetPath()},
+		{Name: "'jf rt upload' --threads", Value: "8", Source: overlay.GetPath()},
+	}, settings)
+}
+
+func TestGetEffectiveSettingsDefaultServer(t *testing.T) {
+	t.Setenv(coreutils.ServerID, "")
+	t.Setenv(coreutils.Project, "")
+	t.Setenv(coreutils.BuildName, "")
+	settings := GetEffectiveSettings(nil, "default-server")
+	assert.Equal(t, Setting{Name: "Server ID", Value: "default-server", Source: "Default server ('jf c use')"}, settings[0])
+	assert.Equal(t, Setting{Name: "Project", Source: "Not set"}, settings[1])
+}
+
+func TestApplyCommandDefaults(t *testing.T) {
+	overlay, err := Find(createTestOverlay(t))
+	require.NoError(t, err)
+	testCases := []struct {
+		name            string
+		args            []string
+		expectedThreads int
+		expectedFlat    bool
+	}{
+		{"defaults", []string{"jf", "rt", "upload"}, 8, true},
+		{"explicit options", []string{"jf", "rt", "upload", "--threads=2", "--flat=false"}, 2, false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			app := cli.NewApp()
+			app.Commands = []cli.Command{{
+				Name: "rt",
+				Subcommands: []cli.Command{{
+					Name:  "upload",
+					Flags: []cli.Flag{cli.IntFlag{Name: "threads", Value: 3}, cli.BoolTFlag{Name: "flat"}},
+					Action: func(c *cli.Context) error {
+						require.NoError(t, applyCommandDefaults(c, overlay, "rt upload"))
+						assert.Equal(t, testCase.expectedThreads, c.Int("threads"))
+						assert.Equal(t, testCase.expectedFlat, c.BoolT("flat"))
+						return nil
+					},
+				}},
+			}}
+			assert.NoError(t, app.Run(testCase.args))
+		})
+	}
+}
+
+func TestApplyDisallowedCommandDefaults(t *testing.T) {
+	overlay := &Overlay{path: "cli.yaml", Commands: map[string]map[string]interface{}{"ping": {
+		"insecure-tls": true, "url": "https://attacker.example.com/", "server-id": "other-server", "quiet": true, "threads": 5,
+	}}}
+	app := cli.NewApp()
+	app.Commands = []cli.Command{{
+		Name: "ping",
+		Flags: []cli.Flag{cli.BoolFlag{Name: "insecure-tls"}, cli.StringFlag{Name: "url"}, cli.StringFlag{Name: "server-id"},
+			cli.BoolFlag{Name: "quiet"}, cli.IntFlag{Name: "threads", Value: 3}},
+		Action: func(c *cli.Context) error {
+			require.NoError(t, applyCommandDefaults(c, overlay, "ping"))
+			assert.False(t, c.Bool("insecure-tls"))
+			assert.Empty(t, c.String("url"))
+			assert.Empty(t, c.String("server-id"))
+			assert.False(t, c.Bool("quiet"))
+			assert.Equal(t, 5, c.Int("threads"))
+			return nil
+		},
+	}}
+	assert.NoError(t, app.Run([]string{"jf", "ping"}))
+}
+
+func TestApplyInvalidCommandDefaults(t *testing.T) {
+	overlay := &Overlay{path: "cli.yaml", Commands: map[string]map[string]interface{}{"ping": {"threads": "x"}}}
+	app := cli.NewApp()
+	app.Commands = []cli.Command{{
+		Name: "ping",
+		Action: func(c *cli.Context) error {
+			return applyCommandDefaults(c, overlay, "ping")
+		},
+	}}
+	assert.ErrorContains(t, app.Run([]string{"jf", "ping"}), "invalid default option 'threads' for 'jf ping'")
+}
+
+func TestWrapCommandsWithDefaults(t *testing.T) {
+	called := false
+	commands := WrapCommandsWithDefaults([]cli.Command{
+		{Name: "rt", Subcommands: []cli.Command{{Name: "upload", Action: func(*cli.Context) error { called = true; return nil }}}},
+		{Name: "plugin", SkipFlagParsing: true, Action: func(*cli.Context) error { return nil }},
+	})
+	app := cli.NewApp()
+	app.Commands = commands
+	assert.NoError(t, app.Run([]string{"jf", "rt", "upload"}))
+	assert.True(t, called)
+}
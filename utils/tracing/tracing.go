@@ -0,0 +1,229 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// The standard OpenTelemetry environment variables, which configure the exporter.
+// The endpoint, headers, timeout and TLS options are read by the OTLP exporter itself.
+const (
+	otelSdkDisabled          = "OTEL_SDK_DISABLED"
+	otelTracesExporter       = "OTEL_TRACES_EXPORTER"
+	otelExporterEndpoint     = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	otelTracesEndpoint       = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	otelExporterProtocol     = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	otelTracesProtocol       = "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"
+	supportedExporter        = "otlp"
+	supportedProtocol        = "http/protobuf"
+	defaultServiceName       = "jfrog-cli"
+	exportTimeout            = 10 * time.Second
+	instrumentationScopeName = "github.com/jfrog/jfrog-cli"
+)
+
+var (
+	provider *sdktrace.TracerProvider
+	tracer   trace.Tracer = noop.NewTracerProvider().Tracer(instrumentationScopeName)
+	rootSpan trace.Span   = trace.SpanFromContext(context.Background())
+	rootCtx               = context.Background()
+	// The contexts of the phases' spans which haven't ended, from the outermost to the innermost.
+	phaseCtxs []context.Context
+	mutex     sync.Mutex
+)
+
+// Returns true if an OTLP endpoint is configured, and tracing wasn't disabled.
+func IsEnabled() bool {
+	if strings.EqualFold(os.Getenv(otelSdkDisabled), "true") {
+		return false
+	}
+	if exporter := os.Getenv(otelTracesExporter); exporter != "" && exporter != supportedExporter {
+		return false
+	}
+	return os.Getenv(otelExporterEndpoint) != "" || os.Getenv(otelTracesEndpoint) != ""
+}
+
+// Starts the root span of the command, if tracing is enabled.
+// The trace and root span IDs are derived from the trace ID sent in the 'uber-trace-id' header,
+// so that the JFrog Platform's spans of the command's requests are children of the root span.
+// commandPath - The full name of the command, such as 'rt upload'.
+// uberTraceId - A 16 chars hexadecimal trace ID, or an empty string to generate random IDs.
+func Start(commandPath, uberTraceId, cliVersion string) error {
+	if !IsEnabled() {
+		return nil
+	}
+	protocol := os.Getenv(otelTracesProtocol)
+	if protocol == "" {
+		protocol = os.Getenv(otelExporterProtocol)
+	}
+	if protocol != "" && protocol != supportedProtocol {
+		return errorutils.CheckErrorf("unsupported OTLP protocol '%s'. JFrog CLI supports the %s protocol only", protocol, supportedProtocol)
+	}
+	// The spans are exported with the proxy set by the environment variables, and not through the local proxy which traces the command's requests.
+	proxyFunc := httpproxy.FromEnvironment().ProxyFunc()
+	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithProxy(func(request *http.Request) (*url.URL, error) {
+		return proxyFunc(request.URL)
+	}))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	res, err := resource.Merge(
+		resource.NewSchemaless(semconv.ServiceName(defaultServiceName), semconv.ServiceVersion(cliVersion)),
+		// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the defaults.
+		resource.Environment(),
+	)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	idGenerator, err := newIdGenerator(uberTraceId)
+	if err != nil {
+		return err
+	}
+	spanName := "jf"
+	if commandPath != "" {
+		spanName += " " + commandPath
+	}
+	mutex.Lock()
+	// Spans are exported in batches in the background, and the remaining spans are exported when the command ends.
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithIDGenerator(idGenerator),
+	)
+	tracer = provider.Tracer(instrumentationScopeName)
+	rootCtx, rootSpan = tracer.Start(context.Background(), spanName,
+		trace.WithAttributes(attribute.String("jfrog.cli.command", commandPath), attribute.String("jfrog.cli.trace_id", uberTraceId)))
+	traceId := rootSpan.SpanContext().TraceID().String()
+	mutex.Unlock()
+	log.Debug("Exporting the command's trace to the OTLP endpoint. Trace ID:", traceId)
+	return nil
+}
+
+// Starts a span of a phase of the command, such as parsing the spec.
+// The spans of the requests sent until the phase's span ends are its children.
+// Returns a span which does nothing if tracing is disabled, so the span can always be ended.
+// Usage example: defer tracing.StartSpan("Parse spec").End()
+func StartSpan(name string, attributes ...attribute.KeyValue) trace.Span {
+	mutex.Lock()
+	defer mutex.Unlock()
+	ctx, span := tracer.Start(getParentCtx(), name, trace.WithAttributes(attributes...))
+	phaseCtxs = append(phaseCtxs, ctx)
+	return &phaseSpan{Span: span, ctx: ctx}
+}
+
+// Spans are children of the innermost phase's span which hasn't ended, or of the root span,
+// as the phases' and the requests' contexts aren't passed through jfrog-cli-core and jfrog-client-go.
+func getParentCtx() context.Context {
+	if len(phaseCtxs) > 0 {
+		return phaseCtxs[len(phaseCtxs)-1]
+	}
+	return rootCtx
+}
+
+// The span of a phase of the command, which is removed from the active phases when it ends.
+type phaseSpan struct {
+	trace.Span
+	ctx context.Context
+}
+
+func (span *phaseSpan) End(options ...trace.SpanEndOption) {
+	mutex.Lock()
+	for i := len(phaseCtxs) - 1; i >= 0; i-- {
+		if phaseCtxs[i] == span.ctx {
+			phaseCtxs = append(phaseCtxs[:i], phaseCtxs[i+1:]...)
+			break
+		}
+	}
+	mutex.Unlock()
+	span.Span.End(options...)
+}
+
+// Ends the root span with the command's result and exports all spans.
+func Shutdown(commandErr error) {
+	mutex.Lock()
+	endedProvider, endedRootSpan := provider, rootSpan
+	provider = nil
+	tracer = noop.NewTracerProvider().Tracer(instrumentationScopeName)
+	rootSpan = trace.SpanFromContext(context.Background())
+	rootCtx = context.Background()
+	phaseCtxs = nil
+	mutex.Unlock()
+	if endedProvider == nil {
+		return
+	}
+	if commandErr != nil {
+		endedRootSpan.RecordError(commandErr)
+		endedRootSpan.SetStatus(codes.Error, commandErr.Error())
+	}
+	endedRootSpan.End()
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	if err := endedProvider.Shutdown(ctx); err != nil {
+		log.Warn("Failed exporting the command's trace:", err.Error())
+	}
+}
+
+// Generates the IDs of the spans. The trace ID and the root span ID are derived from the Uber trace ID.
+type idGenerator struct {
+	mutex      sync.Mutex
+	traceId    trace.TraceID
+	rootSpanId trace.SpanID
+	rootIssued bool
+}
+
+func newIdGenerator(uberTraceId string) (*idGenerator, error) {
+	generator := &idGenerator{}
+	if uberTraceId == "" {
+		if _, err := rand.Read(generator.traceId[:]); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		generator.rootSpanId = generator.NewSpanID(context.Background(), generator.traceId)
+		return generator, nil
+	}
+	decoded, err := hex.DecodeString(uberTraceId)
+	if err != nil || len(decoded) != len(generator.rootSpanId) {
+		return nil, errorutils.CheckErrorf("invalid trace ID '%s'. Expected 16 hexadecimal chars", uberTraceId)
+	}
+	// 64-bit trace IDs are left-padded with zeros, as Jaeger does.
+	copy(generator.traceId[len(generator.traceId)-len(decoded):], decoded)
+	copy(generator.rootSpanId[:], decoded)
+	return generator, nil
+}
+
+func (generator *idGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	generator.mutex.Lock()
+	rootIssued := generator.rootIssued
+	generator.rootIssued = true
+	generator.mutex.Unlock()
+	if !rootIssued {
+		return generator.traceId, generator.rootSpanId
+	}
+	return generator.traceId, generator.NewSpanID(ctx, generator.traceId)
+}
+
+func (generator *idGenerator) NewSpanID(context.Context, trace.TraceID) trace.SpanID {
+	var spanId trace.SpanID
+	// An all zeros span ID is invalid.
+	for !spanId.IsValid() {
+		_, _ = rand.Read(spanId[:])
+	}
+	return spanId
+}
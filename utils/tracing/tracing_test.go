@@ -0,0 +1,151 @@
+package tracing
+
+import (
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// A local stand-in for an OpenTelemetry collector, which receives spans over OTLP/HTTP.
+type testCollector struct {
+	*httptest.Server
+	mutex sync.Mutex
+	spans []*tracepb.Span
+	// The attributes of the last exported resource.
+	resource []*commonpb.KeyValue
+}
+
+func newTestCollector(t *testing.T) *testCollector {
+	collector := &testCollector{}
+	collector.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		request := &collectortrace.ExportTraceServiceRequest{}
+		assert.NoError(t, proto.Unmarshal(body, request))
+		collector.mutex.Lock()
+		defer collector.mutex.Unlock()
+		for _, resourceSpans := range request.ResourceSpans {
+			collector.resource = resourceSpans.Resource.Attributes
+			for _, scopeSpans := range resourceSpans.ScopeSpans {
+				collector.spans = append(collector.spans, scopeSpans.Spans...)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(collector.Close)
+	return collector
+}
+
+func (collector *testCollector) getSpan(t *testing.T, name string) *tracepb.Span {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	for _, span := range collector.spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	require.Fail(t, "span wasn't exported", name)
+	return nil
+}
+
+func getAttribute(attributes []*commonpb.KeyValue, key string) *commonpb.AnyValue {
+	for _, attribute := range attributes {
+		if attribute.Key == key {
+			return attribute.Value
+		}
+	}
+	return &commonpb.AnyValue{}
+}
+
+func TestIsEnabled(t *testing.T) {
+	t.Setenv(otelExporterEndpoint, "")
+	t.Setenv(otelTracesEndpoint, "")
+	assert.False(t, IsEnabled())
+	t.Setenv(otelExporterEndpoint, "http://localhost:4318")
+	assert.True(t, IsEnabled())
+	t.Setenv(otelTracesExporter, "none")
+	assert.False(t, IsEnabled())
+	t.Setenv(otelTracesExporter, "")
+	t.Setenv(otelSdkDisabled, "true")
+	assert.False(t, IsEnabled())
+}
+
+func TestUnsupportedProtocol(t *testing.T) {
+	t.Setenv(otelExporterEndpoint, "http://localhost:4318")
+	t.Setenv(otelExporterProtocol, "grpc")
+	assert.ErrorContains(t, Start("rt ping", "", "1.0.0"), "unsupported OTLP protocol 'grpc'")
+}
+
+func TestExportTrace(t *testing.T) {
+	collector := newTestCollector(t)
+	t.Setenv(otelExporterEndpoint, collector.URL)
+	t.Setenv("OTEL_SERVICE_NAME", "my-pipeline")
+	previousLogger := log.GetLogger()
+	log.SetLogger(log.NewLogger(log.ERROR, nil))
+	defer log.SetLogger(previousLogger)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+
+	require.NoError(t, Start("rt upload", "0123456789abcdef", "2.0.0"))
+	StartSpan("Parse spec").End()
+	uploadSpan := StartSpan("Upload")
+	request, err := http.NewRequest(http.MethodPut, server.URL+"/artifactory/repo/a.txt", nil)
+	require.NoError(t, err)
+	response, err := client.Do(request)
+	require.NoError(t, err)
+	_, err = io.ReadAll(response.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	uploadSpan.End()
+	response, err = client.Head(server.URL)
+	require.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	Shutdown(errors.New("upload failed"))
+
+	root := collector.getSpan(t, "jf rt upload")
+	assert.Equal(t, "00000000000000000123456789abcdef", hex.EncodeToString(root.TraceId))
+	assert.Equal(t, "0123456789abcdef", hex.EncodeToString(root.SpanId))
+	assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, root.Status.Code)
+	assert.Equal(t, "rt upload", getAttribute(root.Attributes, "jfrog.cli.command").GetStringValue())
+	assert.Equal(t, "my-pipeline", getAttribute(collector.resource, "service.name").GetStringValue())
+	assert.Equal(t, "2.0.0", getAttribute(collector.resource, "service.version").GetStringValue())
+
+	specSpan := collector.getSpan(t, "Parse spec")
+	assert.Equal(t, root.SpanId, specSpan.ParentSpanId)
+
+	// The requests sent during a phase are children of its span.
+	phaseSpan := collector.getSpan(t, "Upload")
+	assert.Equal(t, root.SpanId, phaseSpan.ParentSpanId)
+	putSpan := collector.getSpan(t, "HTTP PUT")
+	assert.Equal(t, phaseSpan.SpanId, putSpan.ParentSpanId)
+	assert.Equal(t, root.SpanId, collector.getSpan(t, "HTTP HEAD").ParentSpanId)
+	assert.Equal(t, tracepb.Span_SPAN_KIND_CLIENT, putSpan.Kind)
+	assert.Equal(t, server.URL+"/artifactory/repo/a.txt", getAttribute(putSpan.Attributes, "http.url").GetStringValue())
+	assert.Equal(t, int64(500), getAttribute(putSpan.Attributes, "http.status_code").GetIntValue())
+	assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, putSpan.Status.Code)
+	assert.LessOrEqual(t, putSpan.StartTimeUnixNano, putSpan.EndTimeUnixNano)
+
+	// Requests sent while tracing is disabled don't fail.
+	response, err = client.Get(server.URL)
+	require.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	// Spans started after the shutdown aren't exported.
+	StartSpan("After shutdown").End()
+}
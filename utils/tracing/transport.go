@@ -0,0 +1,30 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Creates a span for each request, as a child of the span of the command's active phase, or of the root span.
+// The requests' contexts aren't passed through jfrog-client-go, so this parent is set for requests with no span.
+// The requests sent by the threads of a phase, such as the upload threads, are children of the phase's span, since the threads have no spans.
+type rootSpanTransport struct {
+	base http.RoundTripper
+}
+
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	return &rootSpanTransport{base: otelhttp.NewTransport(base)}
+}
+
+func (transport *rootSpanTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if !trace.SpanContextFromContext(request.Context()).IsValid() {
+		mutex.Lock()
+		parentCtx := getParentCtx()
+		mutex.Unlock()
+		// The instrumentation uses the tracer provider of the parent span, which is a no-op provider if tracing isn't enabled.
+		request = request.WithContext(trace.ContextWithSpan(request.Context(), trace.SpanFromContext(parentCtx)))
+	}
+	return transport.base.RoundTrip(request)
+}